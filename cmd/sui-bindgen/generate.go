@@ -0,0 +1,599 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"slices"
+	"sort"
+	"strings"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/0xdraco/sui-go-sdk/types"
+)
+
+const (
+	importBig         = "math/big"
+	importContext     = "context"
+	importMovevalue   = "github.com/0xdraco/sui-go-sdk/movevalue"
+	importTransaction = "github.com/0xdraco/sui-go-sdk/transaction"
+	importTypes       = "github.com/0xdraco/sui-go-sdk/types"
+)
+
+// Framework types with a natural Go representation, keyed by normalized type name.
+var (
+	typeString    = wellKnown("0x1::string::String")
+	typeASCII     = wellKnown("0x1::ascii::String")
+	typeOption    = wellKnown("0x1::option::Option")
+	typeUID       = wellKnown("0x2::object::UID")
+	typeID        = wellKnown("0x2::object::ID")
+	typeBalance   = wellKnown("0x2::balance::Balance")
+	typeTxContext = wellKnown("0x2::tx_context::TxContext")
+)
+
+// goInitialisms are identifier parts written in upper case, as Go style asks.
+var goInitialisms = map[string]string{"id": "ID", "uid": "UID", "url": "URL", "uri": "URI"}
+
+func wellKnown(name string) string {
+	key, err := typeKey(name)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// typeKey normalizes the address of a fully qualified datatype name.
+func typeKey(name string) (string, error) {
+	tag, err := types.ParseStructTag(name)
+	if err != nil {
+		return "", err
+	}
+	return tag.Address.String() + "::" + tag.Module + "::" + tag.Name, nil
+}
+
+// generator renders the bindings of one Move package as a single Go file.
+type generator struct {
+	pkg       *v2.Package
+	packageID string
+	goPackage string
+	modules   []*v2.Module
+	prefix    bool
+
+	// datatypes maps the normalized name of each generated datatype to its Go type.
+	datatypes map[string]string
+	used      map[string]bool
+	imports   map[string]bool
+	body      bytes.Buffer
+}
+
+// generate returns Go bindings for the named modules of pkg, or all of its
+// modules when names is empty. packageID is the ID calls are made against.
+func generate(pkg *v2.Package, packageID, goPackage string, names []string) ([]byte, error) {
+	id, err := types.NormalizeAddress(packageID)
+	if err != nil {
+		return nil, fmt.Errorf("package ID: %w", err)
+	}
+	g := &generator{
+		pkg:       pkg,
+		packageID: id,
+		goPackage: goPackage,
+		datatypes: make(map[string]string),
+		used:      map[string]bool{"PackageID": true},
+		imports:   make(map[string]bool),
+	}
+	if err := g.selectModules(names); err != nil {
+		return nil, err
+	}
+	if g.goPackage == "" {
+		g.goPackage = "bindings"
+		if len(g.modules) == 1 {
+			g.goPackage = goPackageName(g.modules[0].GetName())
+		}
+	}
+	if !token.IsIdentifier(g.goPackage) || token.IsKeyword(g.goPackage) {
+		return nil, fmt.Errorf("invalid Go package name %q", g.goPackage)
+	}
+
+	for _, module := range g.modules {
+		if err := g.declareDatatypes(module); err != nil {
+			return nil, err
+		}
+	}
+	for _, module := range g.modules {
+		for _, desc := range sortedDatatypes(module) {
+			if err := g.writeDatatype(module, desc); err != nil {
+				return nil, err
+			}
+		}
+		for _, fn := range sortedFunctions(module) {
+			if err := g.writeFunction(module, fn); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return g.file()
+}
+
+func (g *generator) selectModules(names []string) error {
+	modules := slices.Clone(g.pkg.GetModules())
+	sort.Slice(modules, func(i, j int) bool { return modules[i].GetName() < modules[j].GetName() })
+	if len(names) == 0 {
+		g.modules = modules
+	}
+	for _, name := range names {
+		i := slices.IndexFunc(modules, func(m *v2.Module) bool { return m.GetName() == name })
+		if i < 0 {
+			return fmt.Errorf("package has no module %q", name)
+		}
+		if !slices.Contains(g.modules, modules[i]) {
+			g.modules = append(g.modules, modules[i])
+		}
+	}
+	if len(g.modules) == 0 {
+		return fmt.Errorf("package has no modules")
+	}
+	g.prefix = len(g.modules) > 1
+	return nil
+}
+
+// declareDatatypes assigns Go names to the datatypes of module up front so
+// fields can refer to datatypes declared later or in other modules.
+func (g *generator) declareDatatypes(module *v2.Module) error {
+	for _, desc := range sortedDatatypes(module) {
+		name := g.unique(g.qualified(module, desc.GetName()))
+		for _, id := range []string{desc.GetDefiningId(), g.pkg.GetOriginalId(), g.pkg.GetStorageId()} {
+			if id == "" {
+				continue
+			}
+			key, err := typeKey(id + "::" + module.GetName() + "::" + desc.GetName())
+			if err != nil {
+				return fmt.Errorf("datatype %s::%s: %w", module.GetName(), desc.GetName(), err)
+			}
+			g.datatypes[key] = name
+		}
+		g.datatypes[module.GetName()+"::"+desc.GetName()] = name
+	}
+	return nil
+}
+
+func (g *generator) writeDatatype(module *v2.Module, desc *v2.DatatypeDescriptor) error {
+	name := g.datatypes[module.GetName()+"::"+desc.GetName()]
+	definingID := desc.GetDefiningId()
+	if definingID == "" {
+		definingID = g.pkg.GetOriginalId()
+	}
+	if definingID == "" {
+		definingID = g.packageID
+	}
+	address, err := types.NormalizeAddress(definingID)
+	if err != nil {
+		return fmt.Errorf("datatype %s::%s: %w", module.GetName(), desc.GetName(), err)
+	}
+	moveName := fmt.Sprintf("%s::%s::%s%s", address, module.GetName(), desc.GetName(), typeParamList(len(desc.GetTypeParameters())))
+	constName := g.unique(name + "Type")
+	decodeName := g.unique("Decode" + name)
+
+	fmt.Fprintf(&g.body, "// %s is the type of %s values, without type arguments.\n", constName, name)
+	fmt.Fprintf(&g.body, "const %s = %q\n\n", constName, fmt.Sprintf("%s::%s::%s", address, module.GetName(), desc.GetName()))
+
+	if desc.GetKind() == v2.DatatypeDescriptor_ENUM {
+		variants := slices.Clone(desc.GetVariants())
+		sort.Slice(variants, func(i, j int) bool { return variants[i].GetPosition() < variants[j].GetPosition() })
+		fmt.Fprintf(&g.body, "// %s mirrors the Move enum %s. Exactly one variant field is set.\n", name, moveName)
+		fmt.Fprintf(&g.body, "type %s struct {\n", name)
+		variantTypes := make([]string, len(variants))
+		used := make(map[string]bool)
+		for i, variant := range variants {
+			variantTypes[i] = g.unique(name + exportedName(variant.GetName()))
+			fmt.Fprintf(&g.body, "\t%s *%s `move:%q`\n", uniqueField(used, exportedName(variant.GetName()), i), variantTypes[i], variant.GetName())
+		}
+		g.body.WriteString("}\n\n")
+		for i, variant := range variants {
+			fmt.Fprintf(&g.body, "// %s is the %s variant of %s.\n", variantTypes[i], variant.GetName(), name)
+			if err := g.writeStruct(variantTypes[i], variant.GetFields()); err != nil {
+				return fmt.Errorf("datatype %s: %w", moveName, err)
+			}
+		}
+	} else {
+		fmt.Fprintf(&g.body, "// %s mirrors the Move struct %s.\n", name, moveName)
+		if err := g.writeStruct(name, desc.GetFields()); err != nil {
+			return fmt.Errorf("datatype %s: %w", moveName, err)
+		}
+	}
+
+	g.imports[importContext] = true
+	g.imports[importMovevalue] = true
+	g.imports[importTypes] = true
+	typeArgs, typeParams := "", ""
+	if len(desc.GetTypeParameters()) > 0 {
+		typeArgs, typeParams = ", TypeParams: typeArgs", ", typeArgs ...types.TypeTag"
+	}
+	fmt.Fprintf(&g.body, "// %s decodes the BCS contents of a %s::%s", decodeName, module.GetName(), desc.GetName())
+	if typeParams != "" {
+		g.body.WriteString(" instantiated with typeArgs")
+	}
+	g.body.WriteString(".\n")
+	fmt.Fprintf(&g.body, "func %s(ctx context.Context, d *movevalue.Decoder, data []byte%s) (*%s, error) {\n", decodeName, typeParams, name)
+	fmt.Fprintf(&g.body, "\ttag := types.StructTag{Address: types.MustParseAddress(%q), Module: %q, Name: %q%s}\n", address, module.GetName(), desc.GetName(), typeArgs)
+	fmt.Fprintf(&g.body, "\tvar out %s\n", name)
+	g.body.WriteString("\tif err := d.DecodeInto(ctx, types.TypeTag{Struct: &tag}, data, &out); err != nil {\n\t\treturn nil, err\n\t}\n")
+	g.body.WriteString("\treturn &out, nil\n}\n\n")
+	return nil
+}
+
+func (g *generator) writeStruct(name string, fields []*v2.FieldDescriptor) error {
+	fields = slices.Clone(fields)
+	sort.Slice(fields, func(i, j int) bool { return fields[i].GetPosition() < fields[j].GetPosition() })
+	if len(fields) == 0 {
+		fmt.Fprintf(&g.body, "type %s struct{}\n\n", name)
+		return nil
+	}
+	fmt.Fprintf(&g.body, "type %s struct {\n", name)
+	used := make(map[string]bool)
+	for i, field := range fields {
+		typ, err := g.fieldType(field.GetType())
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.GetName(), err)
+		}
+		fmt.Fprintf(&g.body, "\t%s %s `move:%q`\n", uniqueField(used, exportedName(field.GetName()), i), typ, field.GetName())
+	}
+	g.body.WriteString("}\n\n")
+	return nil
+}
+
+// fieldType returns the Go type movevalue.Unmarshal fills for a field of type body.
+func (g *generator) fieldType(body *v2.OpenSignatureBody) (string, error) {
+	switch body.GetType() {
+	case v2.OpenSignatureBody_BOOL:
+		return "bool", nil
+	case v2.OpenSignatureBody_U8:
+		return "uint8", nil
+	case v2.OpenSignatureBody_U16:
+		return "uint16", nil
+	case v2.OpenSignatureBody_U32:
+		return "uint32", nil
+	case v2.OpenSignatureBody_U64:
+		return "uint64", nil
+	case v2.OpenSignatureBody_U128, v2.OpenSignatureBody_U256:
+		g.imports[importBig] = true
+		return "*big.Int", nil
+	case v2.OpenSignatureBody_ADDRESS:
+		g.imports[importTypes] = true
+		return "types.Address", nil
+	case v2.OpenSignatureBody_VECTOR:
+		elem, err := vectorElement(body)
+		if err != nil {
+			return "", err
+		}
+		if elem.GetType() == v2.OpenSignatureBody_U8 {
+			return "[]byte", nil
+		}
+		typ, err := g.fieldType(elem)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + typ, nil
+	case v2.OpenSignatureBody_DATATYPE:
+		key, err := typeKey(body.GetTypeName())
+		if err != nil {
+			return "", err
+		}
+		args := body.GetTypeParameterInstantiation()
+		switch {
+		case key == typeString || key == typeASCII:
+			return "string", nil
+		case key == typeUID || key == typeID:
+			g.imports[importTypes] = true
+			return "types.Address", nil
+		case key == typeBalance:
+			return "uint64", nil
+		case key == typeOption && len(args) == 1:
+			typ, err := g.fieldType(args[0])
+			if err != nil {
+				return "", err
+			}
+			return "*" + typ, nil
+		}
+		if name, ok := g.datatypes[key]; ok {
+			return name, nil
+		}
+		g.imports[importMovevalue] = true
+		return "movevalue.Value", nil
+	case v2.OpenSignatureBody_TYPE_PARAMETER:
+		g.imports[importMovevalue] = true
+		return "movevalue.Value", nil
+	default:
+		return "", fmt.Errorf("unsupported signature %s", body.GetType())
+	}
+}
+
+// parameter is how a builder function accepts one Move argument.
+type parameter struct {
+	goType string
+	// encode wraps the Go parameter into a transaction.Argument; "%s" is
+	// replaced by the parameter name.
+	encode string
+}
+
+var argumentParameter = parameter{goType: "transaction.Argument", encode: "%s"}
+
+// parameterFor maps pure values to Go types and everything else, including
+// all references, to a transaction.Argument.
+func (g *generator) parameterFor(sig *v2.OpenSignature) parameter {
+	if sig.GetReference() != v2.OpenSignature_REFERENCE_UNKNOWN {
+		return argumentParameter
+	}
+	body := sig.GetBody()
+	switch body.GetType() {
+	case v2.OpenSignatureBody_BOOL:
+		return parameter{"bool", "tx.PureBool(%s)"}
+	case v2.OpenSignatureBody_U8:
+		return parameter{"uint8", "tx.PureU8(%s)"}
+	case v2.OpenSignatureBody_U16:
+		return parameter{"uint16", "tx.Pure(%s)"}
+	case v2.OpenSignatureBody_U32:
+		return parameter{"uint32", "tx.Pure(%s)"}
+	case v2.OpenSignatureBody_U64:
+		return parameter{"uint64", "tx.PureU64(%s)"}
+	case v2.OpenSignatureBody_U128:
+		g.imports[importBig] = true
+		return parameter{"*big.Int", "tx.PureU128(%s)"}
+	case v2.OpenSignatureBody_U256:
+		g.imports[importBig] = true
+		return parameter{"*big.Int", "tx.PureU256(%s)"}
+	case v2.OpenSignatureBody_ADDRESS:
+		return parameter{"string", "tx.PureAddress(%s)"}
+	case v2.OpenSignatureBody_VECTOR:
+		elem, err := vectorElement(body)
+		if err != nil {
+			return argumentParameter
+		}
+		switch elem.GetType() {
+		case v2.OpenSignatureBody_U8:
+			return parameter{"[]byte", "tx.Pure(%s)"}
+		case v2.OpenSignatureBody_BOOL, v2.OpenSignatureBody_U16, v2.OpenSignatureBody_U32, v2.OpenSignatureBody_U64:
+			inner := g.parameterFor(&v2.OpenSignature{Body: elem})
+			return parameter{"[]" + inner.goType, "tx.Pure(%s)"}
+		case v2.OpenSignatureBody_DATATYPE:
+			if key, _ := typeKey(elem.GetTypeName()); key == typeString || key == typeASCII {
+				return parameter{"[]string", "tx.Pure(%s)"}
+			}
+		}
+	case v2.OpenSignatureBody_DATATYPE:
+		switch key, _ := typeKey(body.GetTypeName()); key {
+		case typeString, typeASCII:
+			return parameter{"string", "tx.PureString(%s)"}
+		case typeID:
+			return parameter{"string", "tx.PureAddress(%s)"}
+		}
+	}
+	return argumentParameter
+}
+
+func (g *generator) writeFunction(module *v2.Module, fn *v2.FunctionDescriptor) error {
+	name := g.unique(g.qualified(module, fn.GetName()))
+	g.imports[importTransaction] = true
+
+	params := []string{"tx *transaction.TransactionBuilder"}
+	typeArgs := "nil"
+	if n := len(fn.GetTypeParameters()); n > 0 {
+		params = append(params, fmt.Sprintf("typeArgs [%d]string", n))
+		typeArgs = "typeArgs[:]"
+	}
+	var args, signature []string
+	for i, sig := range fn.GetParameters() {
+		rendered, err := renderSignature(sig)
+		if err != nil {
+			return fmt.Errorf("function %s::%s: %w", module.GetName(), fn.GetName(), err)
+		}
+		signature = append(signature, rendered)
+		if isTxContext(sig) {
+			continue
+		}
+		param := g.parameterFor(sig)
+		argName := fmt.Sprintf("arg%d", i)
+		params = append(params, argName+" "+param.goType)
+		args = append(args, fmt.Sprintf(param.encode, argName))
+	}
+
+	fmt.Fprintf(&g.body, "// %s adds a call to %s::%s%s(%s) to tx.\n", name, module.GetName(), fn.GetName(), typeParamList(len(fn.GetTypeParameters())), strings.Join(signature, ", "))
+	switch len(fn.GetReturns()) {
+	case 0:
+	case 1:
+		g.body.WriteString("// The returned argument refers to its result.\n")
+	default:
+		g.body.WriteString("// Its results are selected with Nested on the returned argument.\n")
+	}
+	fmt.Fprintf(&g.body, "func %s(%s) transaction.Argument {\n", name, strings.Join(params, ", "))
+	call := []string{fmt.Sprintf("PackageID+%q", "::"+module.GetName()+"::"+fn.GetName()), typeArgs}
+	fmt.Fprintf(&g.body, "\treturn tx.MoveCall(%s)\n}\n\n", strings.Join(append(call, args...), ", "))
+	return nil
+}
+
+func (g *generator) file() ([]byte, error) {
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by sui-bindgen from package %s; DO NOT EDIT.\n\n", g.packageID)
+	fmt.Fprintf(&out, "package %s\n\n", g.goPackage)
+	// Standard library imports come first, as goimports groups them.
+	var std, sdk []string
+	for path := range g.imports {
+		if strings.Contains(path, ".") {
+			sdk = append(sdk, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(sdk)
+	if len(std)+len(sdk) > 0 {
+		out.WriteString("import (\n")
+		for _, path := range std {
+			fmt.Fprintf(&out, "\t%q\n", path)
+		}
+		if len(std) > 0 && len(sdk) > 0 {
+			out.WriteString("\n")
+		}
+		for _, path := range sdk {
+			fmt.Fprintf(&out, "\t%q\n", path)
+		}
+		out.WriteString(")\n\n")
+	}
+	out.WriteString("// PackageID is the package the generated functions call.\n")
+	fmt.Fprintf(&out, "const PackageID = %q\n\n", g.packageID)
+	out.Write(g.body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
+
+// qualified names a datatype or function of module, prefixed with the module
+// when several modules share the generated file.
+func (g *generator) qualified(module *v2.Module, name string) string {
+	if g.prefix {
+		return exportedName(module.GetName()) + exportedName(name)
+	}
+	return exportedName(name)
+}
+
+// unique reserves name among the file's top-level declarations, adding a
+// numeric suffix when it is taken.
+func (g *generator) unique(name string) string {
+	candidate := name
+	for i := 2; g.used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	g.used[candidate] = true
+	return candidate
+}
+
+func uniqueField(used map[string]bool, name string, position int) string {
+	if used[name] {
+		name = fmt.Sprintf("%s%d", name, position)
+	}
+	used[name] = true
+	return name
+}
+
+// exportedName converts a Move identifier such as pool_id to PoolID.
+func exportedName(ident string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(ident, "_") {
+		if part == "" {
+			continue
+		}
+		if initialism, ok := goInitialisms[strings.ToLower(part)]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	name := b.String()
+	if name == "" || !token.IsExported(name) {
+		name = "X" + name
+	}
+	return name
+}
+
+func goPackageName(module string) string {
+	name := strings.ToLower(strings.ReplaceAll(module, "_", ""))
+	if !token.IsIdentifier(name) || token.IsKeyword(name) {
+		return "bindings"
+	}
+	return name
+}
+
+func typeParamList(n int) string {
+	if n == 0 {
+		return ""
+	}
+	params := make([]string, n)
+	for i := range params {
+		params[i] = fmt.Sprintf("T%d", i)
+	}
+	return "<" + strings.Join(params, ", ") + ">"
+}
+
+func vectorElement(body *v2.OpenSignatureBody) (*v2.OpenSignatureBody, error) {
+	if len(body.GetTypeParameterInstantiation()) != 1 {
+		return nil, fmt.Errorf("vector signature without element type")
+	}
+	return body.GetTypeParameterInstantiation()[0], nil
+}
+
+func isTxContext(sig *v2.OpenSignature) bool {
+	if sig.GetBody().GetType() != v2.OpenSignatureBody_DATATYPE {
+		return false
+	}
+	key, err := typeKey(sig.GetBody().GetTypeName())
+	return err == nil && key == typeTxContext
+}
+
+// renderSignature formats sig as Move source would, naming datatypes by
+// module and name.
+func renderSignature(sig *v2.OpenSignature) (string, error) {
+	body, err := renderBody(sig.GetBody())
+	if err != nil {
+		return "", err
+	}
+	switch sig.GetReference() {
+	case v2.OpenSignature_IMMUTABLE:
+		return "&" + body, nil
+	case v2.OpenSignature_MUTABLE:
+		return "&mut " + body, nil
+	}
+	return body, nil
+}
+
+func renderBody(body *v2.OpenSignatureBody) (string, error) {
+	var args []string
+	for _, param := range body.GetTypeParameterInstantiation() {
+		arg, err := renderBody(param)
+		if err != nil {
+			return "", err
+		}
+		args = append(args, arg)
+	}
+	switch body.GetType() {
+	case v2.OpenSignatureBody_VECTOR:
+		return "vector<" + strings.Join(args, ", ") + ">", nil
+	case v2.OpenSignatureBody_DATATYPE:
+		tag, err := types.ParseStructTag(body.GetTypeName())
+		if err != nil {
+			return "", err
+		}
+		name := tag.Module + "::" + tag.Name
+		if len(args) > 0 {
+			name += "<" + strings.Join(args, ", ") + ">"
+		}
+		return name, nil
+	case v2.OpenSignatureBody_TYPE_PARAMETER:
+		return fmt.Sprintf("T%d", body.GetTypeParameter()), nil
+	case v2.OpenSignatureBody_TYPE_UNKNOWN:
+		return "", fmt.Errorf("signature without type")
+	default:
+		return strings.ToLower(body.GetType().String()), nil
+	}
+}
+
+func sortedDatatypes(module *v2.Module) []*v2.DatatypeDescriptor {
+	datatypes := slices.Clone(module.GetDatatypes())
+	sort.Slice(datatypes, func(i, j int) bool { return datatypes[i].GetName() < datatypes[j].GetName() })
+	return datatypes
+}
+
+// sortedFunctions returns the functions of module a transaction can call:
+// public functions and entry functions of any visibility.
+func sortedFunctions(module *v2.Module) []*v2.FunctionDescriptor {
+	var functions []*v2.FunctionDescriptor
+	for _, fn := range module.GetFunctions() {
+		if fn.GetVisibility() == v2.FunctionDescriptor_PUBLIC || fn.GetIsEntry() {
+			functions = append(functions, fn)
+		}
+	}
+	sort.Slice(functions, func(i, j int) bool { return functions[i].GetName() < functions[j].GetName() })
+	return functions
+}
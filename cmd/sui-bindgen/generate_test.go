@@ -0,0 +1,278 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+)
+
+const testPackageID = "0xabc"
+
+func strPtr(s string) *string { return &s }
+
+func u32Ptr(v uint32) *uint32 { return &v }
+
+func sig(typ v2.OpenSignatureBody_Type, args ...*v2.OpenSignatureBody) *v2.OpenSignatureBody {
+	return &v2.OpenSignatureBody{Type: &typ, TypeParameterInstantiation: args}
+}
+
+func sigDatatype(name string, args ...*v2.OpenSignatureBody) *v2.OpenSignatureBody {
+	body := sig(v2.OpenSignatureBody_DATATYPE, args...)
+	body.TypeName = strPtr(name)
+	return body
+}
+
+func sigParam(index uint32) *v2.OpenSignatureBody {
+	body := sig(v2.OpenSignatureBody_TYPE_PARAMETER)
+	body.TypeParameter = u32Ptr(index)
+	return body
+}
+
+func byValue(body *v2.OpenSignatureBody) *v2.OpenSignature {
+	return &v2.OpenSignature{Body: body}
+}
+
+func byRef(mutable bool, body *v2.OpenSignatureBody) *v2.OpenSignature {
+	ref := v2.OpenSignature_IMMUTABLE
+	if mutable {
+		ref = v2.OpenSignature_MUTABLE
+	}
+	return &v2.OpenSignature{Reference: &ref, Body: body}
+}
+
+func fields(pairs ...any) []*v2.FieldDescriptor {
+	var out []*v2.FieldDescriptor
+	for i := 0; i < len(pairs); i += 2 {
+		out = append(out, &v2.FieldDescriptor{
+			Name:     strPtr(pairs[i].(string)),
+			Position: u32Ptr(uint32(i / 2)),
+			Type:     pairs[i+1].(*v2.OpenSignatureBody),
+		})
+	}
+	return out
+}
+
+func function(name string, visibility v2.FunctionDescriptor_Visibility, entry bool, typeParams int, params []*v2.OpenSignature, returns ...*v2.OpenSignature) *v2.FunctionDescriptor {
+	return &v2.FunctionDescriptor{
+		Name:           strPtr(name),
+		Visibility:     &visibility,
+		IsEntry:        &entry,
+		TypeParameters: make([]*v2.TypeParameter, typeParams),
+		Parameters:     params,
+		Returns:        returns,
+	}
+}
+
+// testPackage describes a pool module with a generic struct, an enum and a
+// capability, plus functions of every visibility.
+func testPackage() *v2.Package {
+	structKind, enumKind := v2.DatatypeDescriptor_STRUCT, v2.DatatypeDescriptor_ENUM
+	pool := sigDatatype(testPackageID+"::pool::Pool", sigParam(0))
+	coin := sigDatatype("0x2::coin::Coin", sigParam(0))
+	txContext := byRef(true, sigDatatype("0x2::tx_context::TxContext"))
+	return &v2.Package{
+		StorageId:  strPtr("0xdef"),
+		OriginalId: strPtr(testPackageID),
+		Modules: []*v2.Module{{
+			Name: strPtr("pool"),
+			Datatypes: []*v2.DatatypeDescriptor{
+				{
+					DefiningId:     strPtr(testPackageID),
+					Name:           strPtr("Pool"),
+					Kind:           &structKind,
+					TypeParameters: []*v2.TypeParameter{{}},
+					Fields: fields(
+						"id", sigDatatype("0x2::object::UID"),
+						"balance", sigDatatype("0x2::balance::Balance", sigParam(0)),
+						"fee_bps", sig(v2.OpenSignatureBody_U64),
+						"total", sig(v2.OpenSignatureBody_U128),
+						"owners", sig(v2.OpenSignatureBody_VECTOR, sig(v2.OpenSignatureBody_ADDRESS)),
+						"name", sigDatatype("0x1::string::String"),
+						"limit", sigDatatype("0x1::option::Option", sig(v2.OpenSignatureBody_U64)),
+						"state", sigDatatype(testPackageID+"::pool::State"),
+						"extra", sigDatatype("0x2::bag::Bag"),
+					),
+				},
+				{
+					DefiningId: strPtr(testPackageID),
+					Name:       strPtr("State"),
+					Kind:       &enumKind,
+					Variants: []*v2.VariantDescriptor{
+						{Name: strPtr("Paused"), Position: u32Ptr(1), Fields: fields("until", sig(v2.OpenSignatureBody_U64))},
+						{Name: strPtr("Active"), Position: u32Ptr(0)},
+					},
+				},
+				{
+					DefiningId: strPtr(testPackageID),
+					Name:       strPtr("AdminCap"),
+					Kind:       &structKind,
+					Fields:     fields("id", sigDatatype("0x2::object::UID")),
+				},
+			},
+			Functions: []*v2.FunctionDescriptor{
+				function("swap", v2.FunctionDescriptor_PUBLIC, false, 1,
+					[]*v2.OpenSignature{byRef(true, pool), byValue(coin), byValue(sig(v2.OpenSignatureBody_U64)), txContext},
+					byValue(coin)),
+				function("set_config", v2.FunctionDescriptor_PRIVATE, true, 1, []*v2.OpenSignature{
+					byRef(false, sigDatatype(testPackageID+"::pool::AdminCap")),
+					byRef(true, pool),
+					byValue(sig(v2.OpenSignatureBody_U128)),
+					byValue(sig(v2.OpenSignatureBody_U256)),
+					byValue(sig(v2.OpenSignatureBody_VECTOR, sig(v2.OpenSignatureBody_U8))),
+					byValue(sigDatatype("0x1::string::String")),
+					byValue(sig(v2.OpenSignatureBody_ADDRESS)),
+					byValue(sig(v2.OpenSignatureBody_VECTOR, sig(v2.OpenSignatureBody_U64))),
+					byValue(sigDatatype("0x2::object::ID")),
+					byValue(sig(v2.OpenSignatureBody_BOOL)),
+				}),
+				function("rebalance", v2.FunctionDescriptor_PRIVATE, false, 0, nil),
+				function("mint_for", v2.FunctionDescriptor_FRIEND, false, 0, nil),
+			},
+		}},
+	}
+}
+
+func TestGenerateBindings(t *testing.T) {
+	src, err := generate(testPackage(), "0xdef", "", nil)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	out := string(src)
+	for _, want := range []string{
+		"// Code generated by sui-bindgen from package 0x0000000000000000000000000000000000000000000000000000000000000def; DO NOT EDIT.",
+		"package pool\n",
+		`const PackageID = "0x0000000000000000000000000000000000000000000000000000000000000def"`,
+		`const PoolType = "0x0000000000000000000000000000000000000000000000000000000000000abc::pool::Pool"`,
+		"ID      types.Address   `move:\"id\"`",
+		"Balance uint64          `move:\"balance\"`",
+		"FeeBps  uint64          `move:\"fee_bps\"`",
+		"Total   *big.Int        `move:\"total\"`",
+		"Owners  []types.Address `move:\"owners\"`",
+		"Limit   *uint64         `move:\"limit\"`",
+		"State   State           `move:\"state\"`",
+		"Extra   movevalue.Value `move:\"extra\"`",
+		"Active *StateActive `move:\"Active\"`\n\tPaused *StatePaused `move:\"Paused\"`",
+		"type StateActive struct{}",
+		"func DecodePool(ctx context.Context, d *movevalue.Decoder, data []byte, typeArgs ...types.TypeTag) (*Pool, error)",
+		"func DecodeAdminCap(ctx context.Context, d *movevalue.Decoder, data []byte) (*AdminCap, error)",
+		"// Swap adds a call to pool::swap<T0>(&mut pool::Pool<T0>, coin::Coin<T0>, u64, &mut tx_context::TxContext) to tx.",
+		"func Swap(tx *transaction.TransactionBuilder, typeArgs [1]string, arg0 transaction.Argument, arg1 transaction.Argument, arg2 uint64) transaction.Argument {",
+		`return tx.MoveCall(PackageID+"::pool::swap", typeArgs[:], arg0, arg1, tx.PureU64(arg2))`,
+		"arg2 *big.Int, arg3 *big.Int, arg4 []byte, arg5 string, arg6 string, arg7 []uint64, arg8 string, arg9 bool)",
+		"tx.PureU128(arg2), tx.PureU256(arg3), tx.Pure(arg4), tx.PureString(arg5), tx.PureAddress(arg6), tx.Pure(arg7), tx.PureAddress(arg8), tx.PureBool(arg9))",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("generated code lacks %q:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"Rebalance", "MintFor"} {
+		if strings.Contains(out, unwanted) {
+			t.Fatalf("generated code binds non-callable function %s", unwanted)
+		}
+	}
+
+	if _, err := generate(testPackage(), "0xdef", "", []string{"vault"}); err == nil {
+		t.Fatalf("expected error for unknown module")
+	}
+}
+
+func TestGenerateQualifiesNamesAcrossModules(t *testing.T) {
+	pkg := testPackage()
+	pkg.Modules = append(pkg.Modules, &v2.Module{
+		Name:      strPtr("pool_math"),
+		Functions: []*v2.FunctionDescriptor{function("swap", v2.FunctionDescriptor_PUBLIC, false, 0, nil)},
+	})
+	src, err := generate(pkg, "0xdef", "", nil)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	out := string(src)
+	for _, want := range []string{"package bindings\n", "type PoolPool struct", "State   PoolState", "func PoolSwap(", "func PoolMathSwap(tx *transaction.TransactionBuilder) transaction.Argument"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("generated code lacks %q:\n%s", want, out)
+		}
+	}
+}
+
+// TestGeneratedBindingsCompile type-checks the generated code together with a
+// caller using it.
+func TestGeneratedBindingsCompile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds generated code with the go command")
+	}
+	gobin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not available")
+	}
+	src, err := generate(testPackage(), "0xdef", "", nil)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	// A directory starting with an underscore is ignored by ./... patterns.
+	dir, err := os.MkdirTemp(".", "_bindgen")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	caller := `package pool
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/0xdraco/sui-go-sdk/movevalue"
+	"github.com/0xdraco/sui-go-sdk/transaction"
+	"github.com/0xdraco/sui-go-sdk/types"
+)
+
+func use(ctx context.Context, d *movevalue.Decoder, data []byte) (*Pool, error) {
+	tx := transaction.NewTransactionBuilder()
+	coin := Swap(tx, [1]string{"0x2::sui::SUI"}, tx.ObjectID("0x1"), tx.Gas(), 10)
+	SetConfig(tx, [1]string{"0x2::sui::SUI"}, tx.ObjectID("0x2"), tx.ObjectID("0x1"), big.NewInt(1), big.NewInt(2), nil, "name", "0x3", []uint64{1}, "0x4", true)
+	tx.TransferObjects([]transaction.Argument{coin}, tx.PureAddress("0x5"))
+	if _, err := DecodeAdminCap(ctx, d, data); err != nil {
+		return nil, err
+	}
+	return DecodePool(ctx, d, data, types.TypeTag{})
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, "pool.go"), src, 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "caller.go"), []byte(caller), 0o644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	cmd := exec.Command(gobin, "vet", "./"+filepath.Base(dir))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("generated code does not compile: %v\n%s\n%s", err, out, src)
+	}
+}
+
+func TestRunFromSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	snapshot := filepath.Join(dir, "package.json")
+	if err := writeSnapshot(snapshot, testPackage()); err != nil {
+		t.Fatalf("write snapshot: %v", err)
+	}
+	output := filepath.Join(dir, "pool.go")
+	if err := run(context.Background(), []string{"-snapshot", snapshot, "-pkg", "poolbind", "-o", output}, io.Discard); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	src, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	if !strings.Contains(string(src), "package poolbind\n") || !strings.Contains(string(src), `PackageID = "0x0000000000000000000000000000000000000000000000000000000000000def"`) {
+		t.Fatalf("unexpected output:\n%s", src)
+	}
+
+	if err := run(context.Background(), nil, io.Discard); err == nil {
+		t.Fatalf("expected error without -package or -snapshot")
+	}
+}
@@ -0,0 +1,132 @@
+// Command sui-bindgen generates Go bindings for a Move package.
+//
+// For every datatype of the package it emits a Go struct filled by
+// movevalue.Unmarshal and a Decode function for BCS contents; for every public
+// or entry function it emits a function adding the MoveCall to a
+// transaction.TransactionBuilder, taking pure arguments as Go values and
+// objects as transaction.Argument handles.
+//
+// Usage:
+//
+//	sui-bindgen -package 0x... [-rpc endpoint] [-modules pool,math] [-pkg name] [-o pool.go]
+//	sui-bindgen -snapshot package.json [-package 0x...] ...
+//
+// The package is fetched with GetPackage, or read from a snapshot holding the
+// protobuf JSON of a sui.rpc.v2.Package so bindings can be regenerated
+// offline; -save-snapshot writes the fetched package for that purpose.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	sui "github.com/0xdraco/sui-go-sdk/grpc"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "sui-bindgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("sui-bindgen", flag.ContinueOnError)
+	packageID := flags.String("package", "", "ID of the package to generate bindings for")
+	endpoint := flags.String("rpc", sui.MainnetFullnodeURL, "gRPC endpoint to fetch the package from")
+	snapshot := flags.String("snapshot", "", "read the package from this protobuf JSON file instead of fetching it")
+	saveSnapshot := flags.String("save-snapshot", "", "write the fetched package to this file as protobuf JSON")
+	modules := flags.String("modules", "", "comma-separated modules to generate; all modules by default")
+	goPackage := flags.String("pkg", "", "Go package name; the module name when generating a single module")
+	output := flags.String("o", "", "output file; standard output by default")
+	timeout := flags.Duration("timeout", 30*time.Second, "timeout for fetching the package")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %q", flags.Args())
+	}
+
+	var (
+		pkg *v2.Package
+		err error
+	)
+	switch {
+	case *snapshot != "":
+		pkg, err = readSnapshot(*snapshot)
+	case *packageID != "":
+		pkg, err = fetchPackage(ctx, *endpoint, *packageID, *timeout)
+	default:
+		return errors.New("either -package or -snapshot is required")
+	}
+	if err != nil {
+		return err
+	}
+	if *saveSnapshot != "" {
+		if err := writeSnapshot(*saveSnapshot, pkg); err != nil {
+			return err
+		}
+	}
+
+	id := *packageID
+	if id == "" {
+		id = pkg.GetStorageId()
+	}
+	if id == "" {
+		return errors.New("snapshot has no storage_id; pass -package")
+	}
+	var names []string
+	if *modules != "" {
+		names = strings.Split(*modules, ",")
+	}
+	src, err := generate(pkg, id, *goPackage, names)
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(*output, src, 0o644)
+}
+
+func fetchPackage(ctx context.Context, endpoint, packageID string, timeout time.Duration) (*v2.Package, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	client, err := sui.NewClient(ctx, endpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return client.GetPackage(ctx, packageID)
+}
+
+func readSnapshot(path string) (*v2.Package, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var pkg v2.Package
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, &pkg); err != nil {
+		return nil, fmt.Errorf("read snapshot %s: %w", path, err)
+	}
+	return &pkg, nil
+}
+
+func writeSnapshot(path string, pkg *v2.Package) error {
+	data, err := (protojson.MarshalOptions{Multiline: true}).Marshal(pkg)
+	if err != nil {
+		return fmt.Errorf("encode snapshot: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 
 	"github.com/0xdraco/sui-go-sdk/cryptography/personalmsg"
+	"github.com/0xdraco/sui-go-sdk/cryptography/txmsg"
 	"github.com/0xdraco/sui-go-sdk/keychain"
 )
 
@@ -81,8 +82,20 @@ func (k Keypair) verifyDigest(digest [32]byte, signature []byte) error {
 	if !bytes.Equal(signature[1+cryptoed25519.SignatureSize:], pub) {
 		return fmt.Errorf("ed25519: mismatched public key")
 	}
-	rawSig := signature[1 : 1+cryptoed25519.SignatureSize]
-	if !cryptoed25519.Verify(k.PublicKey, digest[:], rawSig) {
+
+	return VerifyDigest(pub, digest, signature[1:1+cryptoed25519.SignatureSize])
+}
+
+// VerifyDigest checks a raw 64-byte signature over an intent digest against a
+// 32-byte public key.
+func VerifyDigest(publicKey []byte, digest [32]byte, signature []byte) error {
+	if len(publicKey) != cryptoed25519.PublicKeySize {
+		return fmt.Errorf("ed25519: invalid public key length %d", len(publicKey))
+	}
+	if len(signature) != cryptoed25519.SignatureSize {
+		return fmt.Errorf("ed25519: invalid signature length %d", len(signature))
+	}
+	if !cryptoed25519.Verify(cryptoed25519.PublicKey(publicKey), digest[:], signature) {
 		return fmt.Errorf("ed25519: verification failed")
 	}
 
@@ -102,6 +115,19 @@ func (k Keypair) VerifyPersonalMessage(message []byte, signature []byte) error {
 	return personalmsg.Verify(keychain.SchemeEd25519, message, signature, k.verifyDigest)
 }
 
+func (k Keypair) SignTransaction(txBytes []byte) ([]byte, error) {
+	return txmsg.Sign(
+		keychain.SchemeEd25519,
+		txBytes,
+		k.PublicKeyBytes(),
+		k.signData,
+	)
+}
+
+func (k Keypair) VerifyTransaction(txBytes []byte, signature []byte) error {
+	return txmsg.Verify(keychain.SchemeEd25519, txBytes, signature, k.verifyDigest)
+}
+
 func Generate() (*Keypair, error) {
 	pub, priv, err := cryptoed25519.GenerateKey(rand.Reader)
 	if err != nil {
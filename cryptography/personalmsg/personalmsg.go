@@ -19,7 +19,7 @@ func Sign(
 	publicKey []byte,
 	signFunc func([]byte) ([]byte, error),
 ) ([]byte, error) {
-	digest, err := digest(message)
+	digest, err := Digest(message)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", scheme.Label(), err)
 	}
@@ -46,7 +46,7 @@ func Verify(
 	signature []byte,
 	verifyFunc func([32]byte, []byte) error,
 ) error {
-	digest, err := digest(message)
+	digest, err := Digest(message)
 	if err != nil {
 		return fmt.Errorf("%s: %w", scheme.Label(), err)
 	}
@@ -54,7 +54,8 @@ func Verify(
 	return verifyFunc(digest, signature)
 }
 
-func digest(message []byte) ([32]byte, error) {
+// Digest returns the Blake2b hash of the personal message intent wrapping message.
+func Digest(message []byte) ([32]byte, error) {
 	if len(message) == 0 {
 		return [32]byte{}, ErrEmptyPersonalMessage
 	}
@@ -8,6 +8,7 @@ import (
 	"math/big"
 
 	"github.com/0xdraco/sui-go-sdk/cryptography/personalmsg"
+	"github.com/0xdraco/sui-go-sdk/cryptography/txmsg"
 	"github.com/0xdraco/sui-go-sdk/keychain"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	secp256k1ecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
@@ -85,17 +86,40 @@ func (k Keypair) verifyDigest(digest [32]byte, signature []byte) error {
 		return fmt.Errorf("secp256k1: mismatched public key")
 	}
 
+	return verifySignature(k.PublicKey, digest, signature[1:65])
+}
+
+// VerifyDigest checks a raw 64-byte (r || s) signature over an intent digest
+// against a 33-byte compressed public key.
+func VerifyDigest(publicKey []byte, digest [32]byte, signature []byte) error {
+	pub, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil {
+		return fmt.Errorf("secp256k1: invalid public key: %w", err)
+	}
+	if len(signature) != 64 {
+		return fmt.Errorf("secp256k1: invalid signature length %d", len(signature))
+	}
+
+	return verifySignature(pub, digest, signature)
+}
+
+func verifySignature(pub *secp256k1.PublicKey, digest [32]byte, signature []byte) error {
 	var rScalar, sScalar secp256k1.ModNScalar
-	if overflow := rScalar.SetByteSlice(signature[1:33]); overflow {
+	if overflow := rScalar.SetByteSlice(signature[0:32]); overflow {
 		return fmt.Errorf("secp256k1: invalid R component")
 	}
-	if overflow := sScalar.SetByteSlice(signature[33:65]); overflow {
+	if overflow := sScalar.SetByteSlice(signature[32:64]); overflow {
 		return fmt.Errorf("secp256k1: invalid S component")
 	}
+	// Sui only accepts the low-S form, so the malleated twin of a valid
+	// signature must not verify.
+	if sScalar.IsOverHalfOrder() {
+		return fmt.Errorf("secp256k1: S component is not in low-S form")
+	}
 
 	sig := secp256k1ecdsa.NewSignature(&rScalar, &sScalar)
 	hash := sha256.Sum256(digest[:])
-	if !sig.Verify(hash[:], k.PublicKey) {
+	if !sig.Verify(hash[:], pub) {
 		return fmt.Errorf("secp256k1: verification failed")
 	}
 	return nil
@@ -114,6 +138,19 @@ func (k Keypair) VerifyPersonalMessage(message []byte, signature []byte) error {
 	return personalmsg.Verify(keychain.SchemeSecp256k1, message, signature, k.verifyDigest)
 }
 
+func (k Keypair) SignTransaction(txBytes []byte) ([]byte, error) {
+	return txmsg.Sign(
+		keychain.SchemeSecp256k1,
+		txBytes,
+		k.PublicKeyBytes(),
+		k.signData,
+	)
+}
+
+func (k Keypair) VerifyTransaction(txBytes []byte, signature []byte) error {
+	return txmsg.Verify(keychain.SchemeSecp256k1, txBytes, signature, k.verifyDigest)
+}
+
 func Generate() (*Keypair, error) {
 	priv, err := secp256k1.GeneratePrivateKey()
 	if err != nil {
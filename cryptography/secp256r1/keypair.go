@@ -13,6 +13,7 @@ import (
 	"math/big"
 
 	"github.com/0xdraco/sui-go-sdk/cryptography/personalmsg"
+	"github.com/0xdraco/sui-go-sdk/cryptography/txmsg"
 	"github.com/0xdraco/sui-go-sdk/keychain"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 )
@@ -85,10 +86,46 @@ func (k Keypair) verifyDigest(digest [32]byte, signature []byte) error {
 		return fmt.Errorf("secp256r1: mismatched public key")
 	}
 
-	r := new(big.Int).SetBytes(signature[1:33])
-	s := new(big.Int).SetBytes(signature[33:65])
+	return verifySignature(k.PublicKey, digest, signature[1:65])
+}
+
+// VerifyDigest checks a raw 64-byte (r || s) signature over an intent digest
+// against a 33-byte compressed public key.
+func VerifyDigest(publicKey []byte, digest [32]byte, signature []byte) error {
+	pub, err := ParsePublicKey(publicKey)
+	if err != nil {
+		return err
+	}
+	if len(signature) != 64 {
+		return fmt.Errorf("secp256r1: invalid signature length %d", len(signature))
+	}
+
+	return verifySignature(pub, digest, signature)
+}
+
+// ParsePublicKey decodes a 33-byte compressed P-256 public key.
+func ParsePublicKey(publicKey []byte) (*ecdsa.PublicKey, error) {
+	if len(publicKey) != 33 {
+		return nil, fmt.Errorf("secp256r1: invalid public key length %d", len(publicKey))
+	}
+	curve := elliptic.P256()
+	x, y := elliptic.UnmarshalCompressed(curve, publicKey)
+	if x == nil {
+		return nil, fmt.Errorf("secp256r1: invalid public key")
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+func verifySignature(pub *ecdsa.PublicKey, digest [32]byte, signature []byte) error {
+	r := new(big.Int).SetBytes(signature[0:32])
+	s := new(big.Int).SetBytes(signature[32:64])
+	// Sui only accepts the low-S form, so the malleated twin of a valid
+	// signature must not verify.
+	if s.Cmp(new(big.Int).Rsh(pub.Curve.Params().N, 1)) > 0 {
+		return fmt.Errorf("secp256r1: S component is not in low-S form")
+	}
 	msgHash := sha256.Sum256(digest[:])
-	if !ecdsa.Verify(k.PublicKey, msgHash[:], r, s) {
+	if !ecdsa.Verify(pub, msgHash[:], r, s) {
 		return fmt.Errorf("secp256r1: verification failed")
 	}
 
@@ -108,6 +145,19 @@ func (k Keypair) VerifyPersonalMessage(message []byte, signature []byte) error {
 	return personalmsg.Verify(keychain.SchemeSecp256r1, message, signature, k.verifyDigest)
 }
 
+func (k Keypair) SignTransaction(txBytes []byte) ([]byte, error) {
+	return txmsg.Sign(
+		keychain.SchemeSecp256r1,
+		txBytes,
+		k.PublicKeyBytes(),
+		k.signData,
+	)
+}
+
+func (k Keypair) VerifyTransaction(txBytes []byte, signature []byte) error {
+	return txmsg.Verify(keychain.SchemeSecp256r1, txBytes, signature, k.verifyDigest)
+}
+
 func Generate() (*Keypair, error) {
 	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	if err != nil {
@@ -115,6 +165,7 @@ func Generate() (*Keypair, error) {
 	}
 	return &Keypair{
 		PrivateKey: priv,
+		PublicKey:  &priv.PublicKey,
 	}, nil
 }
 
@@ -0,0 +1,85 @@
+package txmsg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/0xdraco/sui-go-sdk/cryptography/intent"
+	"github.com/0xdraco/sui-go-sdk/keychain"
+	"github.com/iotaledger/bcs-go"
+)
+
+var ErrEmptyTransaction = errors.New("transaction: empty transaction bytes")
+
+// Sign wraps BCS-encoded TransactionData in a transaction intent, hashes it per
+// Sui rules, and returns the serialized signature bytes `flag || sig || pubkey`.
+func Sign(
+	scheme keychain.Scheme,
+	txBytes []byte,
+	publicKey []byte,
+	signFunc func([]byte) ([]byte, error),
+) ([]byte, error) {
+	digest, err := Digest(txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", scheme.Label(), err)
+	}
+
+	sig, err := signFunc(digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sig) != 64 {
+		return nil, fmt.Errorf("%s: unexpected signature length %d", scheme.Label(), len(sig))
+	}
+
+	serialized := make([]byte, 0, 1+len(sig)+len(publicKey))
+	serialized = append(serialized, scheme.AddressFlag())
+	serialized = append(serialized, sig...)
+	serialized = append(serialized, publicKey...)
+	return serialized, nil
+}
+
+func Verify(
+	scheme keychain.Scheme,
+	txBytes []byte,
+	signature []byte,
+	verifyFunc func([32]byte, []byte) error,
+) error {
+	digest, err := Digest(txBytes)
+	if err != nil {
+		return fmt.Errorf("%s: %w", scheme.Label(), err)
+	}
+
+	return verifyFunc(digest, signature)
+}
+
+// Digest returns the Blake2b hash of the transaction intent wrapping the
+// BCS-encoded TransactionData in txBytes.
+func Digest(txBytes []byte) ([32]byte, error) {
+	if len(txBytes) == 0 {
+		return [32]byte{}, ErrEmptyTransaction
+	}
+
+	payload := append([]byte(nil), txBytes...)
+	intentMsg := intent.NewIntentMessage(
+		intent.DefaultIntent().WithScope(intent.IntentScopeTransactionData),
+		encodedTransactionData(payload),
+	)
+
+	digest, err := intent.HashIntentMessage(intentMsg)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("hash intent message: %w", err)
+	}
+
+	return digest, nil
+}
+
+// encodedTransactionData carries TransactionData that is already BCS-encoded,
+// so it is written verbatim rather than as a length-prefixed byte vector.
+type encodedTransactionData []byte
+
+func (d encodedTransactionData) MarshalBCS(e *bcs.Encoder) error {
+	_, _ = e.Write(d)
+	return nil
+}
@@ -0,0 +1,415 @@
+package grpc
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/0xdraco/sui-go-sdk/types"
+	"google.golang.org/grpc"
+)
+
+// abortCodesLoadTimeout bounds the GetPackage call made on the error path of
+// a client configured with WithAbortCodes.
+const abortCodesLoadTimeout = 3 * time.Second
+
+// AbortCodeTable names the abort codes of one package by module and code,
+// e.g. {"pool": {1: "EZeroAmount"}}.
+type AbortCodeTable map[string]map[uint64]string
+
+// AbortCodes names the abort codes of packages, so that execution failures
+// report EZeroAmount rather than abort code 1. It is safe for concurrent use.
+//
+// Names come from two sources. Register adds app-defined tables for plain
+// abort codes. LoadPackage reads the error constants of a package's compiled
+// modules, which name clever abort codes ("#[error]" constants) even when the
+// node did not decode them. Move bytecode does not record the names of other
+// constants, so plain codes can only be named with Register.
+type AbortCodes struct {
+	mu      sync.RWMutex
+	tables  map[string]AbortCodeTable
+	modules map[string]map[string]*moveErrorConstants
+}
+
+// NewAbortCodes returns an empty AbortCodes.
+func NewAbortCodes() *AbortCodes {
+	return &AbortCodes{
+		tables:  make(map[string]AbortCodeTable),
+		modules: make(map[string]map[string]*moveErrorConstants),
+	}
+}
+
+// WithAbortCodes names abort codes in the execution failures reported by the
+// client using codes. The first failure in a package that has not been loaded
+// loads it with LoadPackage, so SimulateTransaction, the execute helpers and
+// SignAndExecute may make an extra GetPackage call before returning the
+// failure. That call keeps the caller's context values but not its deadline
+// and is bounded to a few seconds instead. A package that cannot be loaded
+// leaves the code unnamed and is retried on the next failure.
+func WithAbortCodes(codes *AbortCodes) Option {
+	return func(cfg *config) {
+		cfg.abortCodes = codes
+	}
+}
+
+// Register adds table to the codes known for packageID, replacing existing
+// names for the same module and code.
+func (a *AbortCodes) Register(packageID string, table AbortCodeTable) error {
+	key, err := types.NormalizeAddress(packageID)
+	if err != nil {
+		return fmt.Errorf("abort codes: package ID: %w", err)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	existing := a.tables[key]
+	if existing == nil {
+		existing = make(AbortCodeTable, len(table))
+		a.tables[key] = existing
+	}
+	for module, names := range table {
+		if existing[module] == nil {
+			existing[module] = make(map[uint64]string, len(names))
+		}
+		for code, name := range names {
+			existing[module][code] = name
+		}
+	}
+	return nil
+}
+
+// Lookup returns the name registered for code in packageID's module, or the
+// constant name encoded in a clever abort code of a loaded package.
+func (a *AbortCodes) Lookup(packageID, module string, code uint64) (string, bool) {
+	name, _, _, ok := a.describe(packageID, module, code)
+	return name, ok
+}
+
+// Loaded reports whether LoadPackage has cached packageID.
+func (a *AbortCodes) Loaded(packageID string) bool {
+	if a == nil {
+		return false
+	}
+	key, err := types.NormalizeAddress(packageID)
+	if err != nil {
+		return false
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	_, ok := a.modules[key]
+	return ok
+}
+
+// LoadPackage fetches packageID with GetPackage and caches the error
+// constants of its modules under both its storage and original IDs. Packages
+// that are already cached are not fetched again.
+func (a *AbortCodes) LoadPackage(ctx context.Context, client *GRPCClient, packageID string, opts ...grpc.CallOption) error {
+	if client == nil {
+		return errors.New("nil client")
+	}
+	if ctx == nil {
+		return errors.New("nil context")
+	}
+	if a.Loaded(packageID) {
+		return nil
+	}
+
+	pkg, err := client.GetPackage(ctx, packageID, opts...)
+	if err != nil {
+		return err
+	}
+
+	modules := make(map[string]*moveErrorConstants, len(pkg.GetModules()))
+	for _, module := range pkg.GetModules() {
+		constants, err := parseMoveErrorConstants(module.GetContents())
+		if err != nil {
+			return fmt.Errorf("abort codes: module %s::%s: %w", packageID, module.GetName(), err)
+		}
+		modules[module.GetName()] = constants
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, id := range []string{packageID, pkg.GetStorageId(), pkg.GetOriginalId()} {
+		if key, err := types.NormalizeAddress(id); err == nil {
+			a.modules[key] = modules
+		}
+	}
+	return nil
+}
+
+// describe names code and, for clever abort codes of loaded packages, renders
+// the error constant's value and source line.
+func (a *AbortCodes) describe(packageID, module string, code uint64) (name, value string, line uint64, ok bool) {
+	if a == nil {
+		return "", "", 0, false
+	}
+	key, err := types.NormalizeAddress(packageID)
+	if err != nil {
+		return "", "", 0, false
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if name, ok := a.tables[key][module][code]; ok {
+		return name, "", 0, true
+	}
+	if constants := a.modules[key][module]; constants != nil {
+		return constants.decodeClever(code)
+	}
+	return "", "", 0, false
+}
+
+// Clever abort codes set the top bit and pack the source line, the identifier
+// index of the constant's name and the constant's index:
+// tag(1) | reserved(15) | line(16) | identifier(16) | constant(16).
+const (
+	cleverAbortTag = uint64(1) << 63
+	cleverNoIndex  = 0xffff
+)
+
+// moveErrorConstants holds the tables of a compiled module needed to decode
+// clever abort codes.
+type moveErrorConstants struct {
+	identifiers []string
+	constants   []moveConstant
+}
+
+type moveConstant struct {
+	typ  []byte
+	data []byte
+}
+
+func (m *moveErrorConstants) decodeClever(code uint64) (name, value string, line uint64, ok bool) {
+	if code&cleverAbortTag == 0 {
+		return "", "", 0, false
+	}
+	line = (code >> 32) & 0xffff
+	if i := (code >> 16) & 0xffff; i != cleverNoIndex && int(i) < len(m.identifiers) {
+		name = m.identifiers[i]
+	}
+	if i := code & 0xffff; i != cleverNoIndex && int(i) < len(m.constants) {
+		value, _ = renderMoveConstant(m.constants[i])
+	}
+	return name, value, line, name != ""
+}
+
+const moveBytecodeMagic = 0x0BEB1CA1 // a1 1c eb 0b, little endian
+
+// Move binary format table kinds.
+const (
+	moveTableConstantPool = 0x6
+	moveTableIdentifiers  = 0x7
+)
+
+// parseMoveErrorConstants reads the identifier and constant pool tables of a
+// compiled Move module.
+func parseMoveErrorConstants(contents []byte) (*moveErrorConstants, error) {
+	if len(contents) < 8 || binary.LittleEndian.Uint32(contents) != moveBytecodeMagic {
+		return nil, errors.New("not a compiled Move module")
+	}
+	r := &byteReader{buf: contents, pos: 8}
+	count, err := r.uleb()
+	if err != nil {
+		return nil, err
+	}
+	type table struct{ kind, offset, length uint64 }
+	tables := make([]table, 0, count)
+	for range count {
+		kind, err := r.byte()
+		if err != nil {
+			return nil, err
+		}
+		offset, err := r.uleb()
+		if err != nil {
+			return nil, err
+		}
+		length, err := r.uleb()
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table{kind: uint64(kind), offset: offset, length: length})
+	}
+	content := contents[r.pos:]
+
+	m := &moveErrorConstants{}
+	for _, t := range tables {
+		if t.offset > uint64(len(content)) || t.length > uint64(len(content))-t.offset {
+			return nil, fmt.Errorf("table %#x out of bounds", t.kind)
+		}
+		tr := &byteReader{buf: content[t.offset : t.offset+t.length]}
+		switch t.kind {
+		case moveTableIdentifiers:
+			for !tr.done() {
+				identifier, err := tr.bytes()
+				if err != nil {
+					return nil, fmt.Errorf("identifiers: %w", err)
+				}
+				m.identifiers = append(m.identifiers, string(identifier))
+			}
+		case moveTableConstantPool:
+			for !tr.done() {
+				start := tr.pos
+				if err := tr.skipSignatureToken(); err != nil {
+					return nil, fmt.Errorf("constant pool: %w", err)
+				}
+				typ := tr.buf[start:tr.pos]
+				data, err := tr.bytes()
+				if err != nil {
+					return nil, fmt.Errorf("constant pool: %w", err)
+				}
+				m.constants = append(m.constants, moveConstant{typ: typ, data: data})
+			}
+		}
+	}
+	return m, nil
+}
+
+// Move signature tokens that may appear in constants.
+const (
+	moveTokenBool    = 0x1
+	moveTokenU8      = 0x2
+	moveTokenU64     = 0x3
+	moveTokenU128    = 0x4
+	moveTokenAddress = 0x5
+	moveTokenVector  = 0xa
+	moveTokenU16     = 0xd
+	moveTokenU32     = 0xe
+	moveTokenU256    = 0xf
+)
+
+// renderMoveConstant renders a constant's BCS value the way the fullnode
+// renders clever errors: integers in decimal, addresses in hex and byte
+// vectors as strings when they are valid UTF-8.
+func renderMoveConstant(c moveConstant) (string, error) {
+	r := &byteReader{buf: c.data}
+	value, err := r.renderValue(c.typ)
+	if err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+func (r *byteReader) renderValue(typ []byte) (string, error) {
+	if len(typ) == 0 {
+		return "", errors.New("empty type")
+	}
+	switch typ[0] {
+	case moveTokenBool:
+		b, err := r.byte()
+		return strconv.FormatBool(b != 0), err
+	case moveTokenU8:
+		b, err := r.byte()
+		return strconv.FormatUint(uint64(b), 10), err
+	case moveTokenU16, moveTokenU32, moveTokenU64, moveTokenU128, moveTokenU256:
+		size := map[byte]int{moveTokenU16: 2, moveTokenU32: 4, moveTokenU64: 8, moveTokenU128: 16, moveTokenU256: 32}[typ[0]]
+		raw, err := r.next(size)
+		if err != nil {
+			return "", err
+		}
+		be := make([]byte, size)
+		for i, b := range raw {
+			be[size-1-i] = b
+		}
+		return new(big.Int).SetBytes(be).String(), nil
+	case moveTokenAddress:
+		raw, err := r.next(32)
+		return "0x" + hex.EncodeToString(raw), err
+	case moveTokenVector:
+		n, err := r.uleb()
+		if err != nil {
+			return "", err
+		}
+		elem := typ[1:]
+		if len(elem) > 0 && elem[0] == moveTokenU8 {
+			raw, err := r.next(int(n))
+			if err != nil {
+				return "", err
+			}
+			if utf8.Valid(raw) {
+				return string(raw), nil
+			}
+			return "0x" + hex.EncodeToString(raw), nil
+		}
+		items := make([]string, 0, n)
+		for range n {
+			item, err := r.renderValue(elem)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		return "[" + strings.Join(items, ", ") + "]", nil
+	default:
+		return "", fmt.Errorf("unsupported constant type %#x", typ[0])
+	}
+}
+
+// byteReader decodes the ULEB128-prefixed tables of the Move binary format.
+type byteReader struct {
+	buf []byte
+	pos int
+}
+
+func (r *byteReader) done() bool { return r.pos >= len(r.buf) }
+
+func (r *byteReader) byte() (byte, error) {
+	if r.done() {
+		return 0, errors.New("unexpected end of data")
+	}
+	b := r.buf[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *byteReader) next(n int) ([]byte, error) {
+	if n < 0 || n > len(r.buf)-r.pos {
+		return nil, errors.New("unexpected end of data")
+	}
+	b := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *byteReader) uleb() (uint64, error) {
+	v, n := binary.Uvarint(r.buf[r.pos:])
+	if n <= 0 {
+		return 0, errors.New("invalid ULEB128 value")
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *byteReader) bytes() ([]byte, error) {
+	n, err := r.uleb()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(r.buf)-r.pos) {
+		return nil, errors.New("unexpected end of data")
+	}
+	return r.next(int(n))
+}
+
+// skipSignatureToken advances past a constant's type, which is a primitive
+// or a vector of one.
+func (r *byteReader) skipSignatureToken() error {
+	token, err := r.byte()
+	if err != nil {
+		return err
+	}
+	switch token {
+	case moveTokenBool, moveTokenU8, moveTokenU16, moveTokenU32, moveTokenU64, moveTokenU128, moveTokenU256, moveTokenAddress:
+		return nil
+	case moveTokenVector:
+		return r.skipSignatureToken()
+	default:
+		return fmt.Errorf("unsupported constant type %#x", token)
+	}
+}
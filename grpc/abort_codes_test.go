@@ -0,0 +1,135 @@
+package grpc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+)
+
+// testModuleBytes assembles a compiled module containing only an identifier
+// table and a constant pool.
+func testModuleBytes(identifiers []string, constants []moveConstant) []byte {
+	var idents, pool []byte
+	for _, identifier := range identifiers {
+		idents = binary.AppendUvarint(idents, uint64(len(identifier)))
+		idents = append(idents, identifier...)
+	}
+	for _, c := range constants {
+		pool = append(pool, c.typ...)
+		pool = binary.AppendUvarint(pool, uint64(len(c.data)))
+		pool = append(pool, c.data...)
+	}
+
+	module := binary.LittleEndian.AppendUint32(nil, moveBytecodeMagic)
+	module = binary.LittleEndian.AppendUint32(module, 6)
+	module = binary.AppendUvarint(module, 2)
+	module = append(module, moveTableIdentifiers)
+	module = binary.AppendUvarint(module, 0)
+	module = binary.AppendUvarint(module, uint64(len(idents)))
+	module = append(module, moveTableConstantPool)
+	module = binary.AppendUvarint(module, uint64(len(idents)))
+	module = binary.AppendUvarint(module, uint64(len(pool)))
+	module = append(module, idents...)
+	return append(module, pool...)
+}
+
+func testCleverCode(line, identifier, constant uint64) uint64 {
+	return cleverAbortTag | line<<32 | identifier<<16 | constant
+}
+
+func testPoolPackage() *v2.Package {
+	message := "pool is empty"
+	limit := binary.LittleEndian.AppendUint64(nil, 1_000)
+	contents := testModuleBytes(
+		[]string{"pool", "EEmpty", "ETooLarge"},
+		[]moveConstant{
+			{typ: []byte{moveTokenVector, moveTokenU8}, data: append(binary.AppendUvarint(nil, uint64(len(message))), message...)},
+			{typ: []byte{moveTokenU64}, data: limit},
+		},
+	)
+	return &v2.Package{
+		StorageId:  stringPtr("0xdef"),
+		OriginalId: stringPtr(testAbortPackage),
+		Modules:    []*v2.Module{{Name: stringPtr("pool"), Contents: contents}},
+	}
+}
+
+func TestAbortCodesLoadPackageNamesCleverCodes(t *testing.T) {
+	node, client := newFakeExecutionNode(t)
+	node.packages = map[string]*v2.Package{"0xdef": testPoolPackage()}
+
+	codes := NewAbortCodes()
+	if err := codes.LoadPackage(context.Background(), client, "0xdef"); err != nil {
+		t.Fatalf("load package: %v", err)
+	}
+	if !codes.Loaded(testAbortPackage) {
+		t.Fatalf("package not cached under its original ID")
+	}
+
+	failure := DecodeExecutionError(testMoveAbort(testCleverCode(42, 1, 0), nil), codes)
+	if got, want := failure.Error(), "command 1: 0xabc::pool::swap aborted with EEmpty at line 42: pool is empty"; got != want {
+		t.Fatalf("unexpected message\n got: %s\nwant: %s", got, want)
+	}
+	failure = DecodeExecutionError(testMoveAbort(testCleverCode(7, 2, 1), nil), codes)
+	if failure.ErrorName != "ETooLarge" || failure.ErrorValue != "1000" || failure.Line != 7 {
+		t.Fatalf("unexpected failure %+v", failure)
+	}
+	if _, ok := codes.Lookup(testAbortPackage, "pool", 3); ok {
+		t.Fatalf("plain abort codes must not be named without Register")
+	}
+
+	err := codes.LoadPackage(context.Background(), client, "0x123")
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for missing package, got %v", err)
+	}
+}
+
+func TestParseMoveErrorConstantsRejectsInvalidModules(t *testing.T) {
+	if _, err := parseMoveErrorConstants([]byte("not a module")); err == nil {
+		t.Fatalf("expected error for bad magic")
+	}
+	module := testModuleBytes([]string{"pool"}, nil)
+	if _, err := parseMoveErrorConstants(module[:len(module)-1]); err == nil {
+		t.Fatalf("expected error for truncated module")
+	}
+}
+
+func TestSignAndExecuteLoadsAbortCodes(t *testing.T) {
+	signer := testSigner(t)
+	address, _ := signer.SuiAddress()
+	codes := NewAbortCodes()
+	node, client := newFakeExecutionNode(t, WithAbortCodes(codes))
+	node.packages = map[string]*v2.Package{testAbortPackage: testPoolPackage()}
+	node.simulateError = testMoveAbort(testCleverCode(42, 1, 0), nil)
+
+	tx := testTransferTransaction(t, address)
+	tx.GasPayment = &v2.GasPayment{Budget: uint64Ptr(5_000_000)}
+	_, err := client.SignAndExecute(context.Background(), signer, tx, &SignAndExecuteOptions{DryRun: true})
+
+	var failure *ExecutionFailure
+	if !errors.As(err, &failure) {
+		t.Fatalf("expected ExecutionFailure, got %v", err)
+	}
+	if failure.ErrorName != "EEmpty" || failure.ErrorValue != "pool is empty" {
+		t.Fatalf("unexpected failure %+v", failure)
+	}
+}
+
+func TestExecutionFailureLoadsAbortCodesPastCallerDeadline(t *testing.T) {
+	codes := NewAbortCodes()
+	node, client := newFakeExecutionNode(t, WithAbortCodes(codes))
+	node.packages = map[string]*v2.Package{testAbortPackage: testPoolPackage()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	success := false
+	failed := client.executionFailure(ctx, &v2.ExecutedTransaction{Effects: &v2.TransactionEffects{
+		Status: &v2.ExecutionStatus{Success: &success, Error: testMoveAbort(testCleverCode(42, 1, 0), nil)},
+	}}, false)
+	if failed == nil || failed.Failure == nil || failed.Failure.ErrorName != "EEmpty" {
+		t.Fatalf("abort code not named: %v", failed)
+	}
+}
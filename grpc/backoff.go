@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+const (
+	defaultInitialBackoff = 250 * time.Millisecond
+	defaultMaxBackoff     = 30 * time.Second
+	backoffJitter         = 0.2
+)
+
+// backoff produces exponentially growing, jittered delays between initial and max.
+type backoff struct {
+	initial time.Duration
+	max     time.Duration
+	next    time.Duration
+}
+
+func newBackoff(initial, max time.Duration) *backoff {
+	if initial <= 0 {
+		initial = defaultInitialBackoff
+	}
+	if max <= 0 {
+		max = defaultMaxBackoff
+	}
+	if max < initial {
+		max = initial
+	}
+	return &backoff{initial: initial, max: max, next: initial}
+}
+
+// delay returns the next delay and doubles the following one up to max.
+func (b *backoff) delay() time.Duration {
+	d := b.next
+	if b.next < b.max {
+		b.next *= 2
+		if b.next > b.max {
+			b.next = b.max
+		}
+	}
+	jitter := 1 + backoffJitter*(2*rand.Float64()-1)
+	return time.Duration(float64(d) * jitter)
+}
+
+func (b *backoff) reset() {
+	b.next = b.initial
+}
+
+// wait sleeps for the next delay or until ctx is done.
+func (b *backoff) wait(ctx context.Context) error {
+	timer := time.NewTimer(b.delay())
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
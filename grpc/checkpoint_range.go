@@ -0,0 +1,212 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// DefaultCheckpointRangeConcurrency is the number of checkpoints fetched in parallel by default.
+const DefaultCheckpointRangeConcurrency = 8
+
+// ErrCheckpointPruned indicates a checkpoint is below the fullnode's lowest available
+// checkpoint and no archive client was configured. It wraps ErrPruned.
+var ErrCheckpointPruned = fmt.Errorf("checkpoint has been %w", ErrPruned)
+
+// CheckpointRangeOptions customises CheckpointRangeIterator.
+type CheckpointRangeOptions struct {
+	// Concurrency bounds the number of checkpoints fetched ahead of the consumer.
+	// Zero uses DefaultCheckpointRangeConcurrency.
+	Concurrency int
+	// Archive serves checkpoints the fullnode has pruned, e.g. a client from
+	// NewMainnetArchiveClient or NewTestnetArchiveClient. The iterator does not close it.
+	Archive *GRPCClient
+	// CallOptions are passed to every GetCheckpoint and GetServiceInfo call.
+	CallOptions []grpc.CallOption
+}
+
+// CheckpointRangeIterator yields the checkpoints in [start, end) strictly in sequence
+// order while fetching up to Concurrency checkpoints ahead in parallel. It is not
+// safe for concurrent use.
+type CheckpointRangeIterator struct {
+	client      *GRPCClient
+	archive     *GRPCClient
+	readMask    *fieldmaskpb.FieldMask
+	concurrency int
+	callOptions []grpc.CallOption
+	end         uint64
+
+	next     uint64
+	lowest   uint64
+	started  bool
+	fetchCtx context.Context
+	cancel   context.CancelFunc
+	inflight map[uint64]*checkpointFetch
+}
+
+type checkpointFetch struct {
+	done       chan struct{}
+	checkpoint *v2.Checkpoint
+	err        error
+}
+
+// CheckpointRangeIterator returns an iterator over the checkpoints in [start, end).
+// Checkpoints below the fullnode's LowestAvailableCheckpoint, or reported as not
+// found by it, are read from options.Archive when set. Call Close when abandoning
+// the iterator early to stop outstanding fetches.
+func (c *GRPCClient) CheckpointRangeIterator(start, end uint64, readMask *fieldmaskpb.FieldMask, options *CheckpointRangeOptions) (*CheckpointRangeIterator, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if end < start {
+		return nil, fmt.Errorf("invalid checkpoint range [%d, %d)", start, end)
+	}
+
+	it := &CheckpointRangeIterator{
+		client:      c,
+		readMask:    ensureFieldMaskPaths(readMask, "sequence_number"),
+		concurrency: DefaultCheckpointRangeConcurrency,
+		end:         end,
+		next:        start,
+		inflight:    make(map[uint64]*checkpointFetch),
+	}
+	if options != nil {
+		if options.Concurrency > 0 {
+			it.concurrency = options.Concurrency
+		}
+		it.archive = options.Archive
+		it.callOptions = append([]grpc.CallOption(nil), options.CallOptions...)
+	}
+	return it, nil
+}
+
+// Next returns the next checkpoint in the range, or io.EOF once the range is
+// exhausted. A failed fetch is returned once and retried by the following call.
+func (it *CheckpointRangeIterator) Next(ctx context.Context) (*v2.Checkpoint, error) {
+	if it == nil {
+		return nil, errors.New("nil iterator")
+	}
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	if it.next >= it.end {
+		it.Close()
+		return nil, io.EOF
+	}
+	if err := it.start(ctx); err != nil {
+		return nil, err
+	}
+
+	it.schedule()
+	fetch := it.inflight[it.next]
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-fetch.done:
+	}
+	delete(it.inflight, it.next)
+	if fetch.err != nil {
+		return nil, fetch.err
+	}
+	it.next++
+	it.schedule()
+	return fetch.checkpoint, nil
+}
+
+// ForEach visits each remaining checkpoint until exhaustion or callback error.
+func (it *CheckpointRangeIterator) ForEach(ctx context.Context, fn func(*v2.Checkpoint) error) error {
+	if it == nil {
+		return errors.New("nil iterator")
+	}
+	if fn == nil {
+		return errors.New("nil function")
+	}
+	defer it.Close()
+	for {
+		checkpoint, err := it.Next(ctx)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := fn(checkpoint); err != nil {
+			return err
+		}
+	}
+}
+
+// Close stops outstanding prefetches. The iterator cannot be used afterwards.
+func (it *CheckpointRangeIterator) Close() {
+	if it == nil {
+		return
+	}
+	if it.cancel != nil {
+		it.cancel()
+	}
+	it.next = it.end
+}
+
+// start looks up the fullnode's retention on first use. Prefetches keep the
+// values of the first caller's context but not its deadline, since they outlive
+// individual Next calls.
+func (it *CheckpointRangeIterator) start(ctx context.Context) error {
+	if it.started {
+		return nil
+	}
+	info, err := it.client.GetServiceInfo(ctx, it.callOptions...)
+	if err != nil {
+		return fmt.Errorf("service info: %w", err)
+	}
+	it.lowest = info.GetLowestAvailableCheckpoint()
+	if it.next < it.lowest && it.archive == nil {
+		return fmt.Errorf("%w: checkpoint %d, lowest available %d", ErrCheckpointPruned, it.next, it.lowest)
+	}
+
+	it.fetchCtx, it.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	it.started = true
+	return nil
+}
+
+// schedule keeps up to concurrency fetches in flight ahead of the consumer.
+func (it *CheckpointRangeIterator) schedule() {
+	limit := it.next + uint64(it.concurrency)
+	if limit > it.end || limit < it.next {
+		limit = it.end
+	}
+	for seq := it.next; seq < limit; seq++ {
+		if _, ok := it.inflight[seq]; ok {
+			continue
+		}
+		fetch := &checkpointFetch{done: make(chan struct{})}
+		it.inflight[seq] = fetch
+		go func(seq uint64) {
+			defer close(fetch.done)
+			fetch.checkpoint, fetch.err = it.fetch(it.fetchCtx, seq)
+		}(seq)
+	}
+}
+
+func (it *CheckpointRangeIterator) fetch(ctx context.Context, seq uint64) (*v2.Checkpoint, error) {
+	if seq >= it.lowest {
+		checkpoint, err := it.client.GetCheckpointBySequence(ctx, seq, it.readMask, it.callOptions...)
+		if err == nil || it.archive == nil || grpcstatus.Code(err) != codes.NotFound {
+			return checkpoint, err
+		}
+	} else if it.archive == nil {
+		return nil, fmt.Errorf("%w: checkpoint %d, lowest available %d", ErrCheckpointPruned, seq, it.lowest)
+	}
+
+	checkpoint, err := it.archive.GetCheckpointBySequence(ctx, seq, it.readMask, it.callOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("archive: %w", err)
+	}
+	return checkpoint, nil
+}
@@ -0,0 +1,164 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeCheckpointStore serves GetCheckpoint for sequences at or above lowest.
+// Lower sequences take longer so that responses complete out of order.
+type fakeCheckpointStore struct {
+	v2.UnimplementedLedgerServiceServer
+
+	lowest uint64
+
+	mu       sync.Mutex
+	active   int
+	peak     int
+	requests []uint64
+}
+
+func (s *fakeCheckpointStore) GetServiceInfo(context.Context, *v2.GetServiceInfoRequest) (*v2.GetServiceInfoResponse, error) {
+	return &v2.GetServiceInfoResponse{LowestAvailableCheckpoint: &s.lowest}, nil
+}
+
+func (s *fakeCheckpointStore) GetCheckpoint(ctx context.Context, req *v2.GetCheckpointRequest) (*v2.GetCheckpointResponse, error) {
+	seq := req.GetSequenceNumber()
+	s.mu.Lock()
+	s.requests = append(s.requests, seq)
+	s.active++
+	if s.active > s.peak {
+		s.peak = s.active
+	}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.active--
+		s.mu.Unlock()
+	}()
+
+	if seq < s.lowest {
+		return nil, status.Error(codes.NotFound, "checkpoint pruned")
+	}
+	select {
+	case <-time.After(time.Duration(10-seq%10) * time.Millisecond):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &v2.GetCheckpointResponse{Checkpoint: testCheckpoint(seq)}, nil
+}
+
+func (s *fakeCheckpointStore) stats() (requests []uint64, peak int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]uint64(nil), s.requests...), s.peak
+}
+
+func newFakeCheckpointStore(t *testing.T, lowest uint64) (*fakeCheckpointStore, *GRPCClient) {
+	t.Helper()
+	store := &fakeCheckpointStore{lowest: lowest}
+	client := newTestClient(t, func(s *grpc.Server) {
+		v2.RegisterLedgerServiceServer(s, store)
+	})
+	return store, client
+}
+
+func drainRange(t *testing.T, it *CheckpointRangeIterator) []uint64 {
+	t.Helper()
+	var got []uint64
+	err := it.ForEach(context.Background(), func(cp *v2.Checkpoint) error {
+		got = append(got, cp.GetSequenceNumber())
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("iterate: %v", err)
+	}
+	return got
+}
+
+func TestCheckpointRangeIteratorYieldsInOrder(t *testing.T) {
+	store, client := newFakeCheckpointStore(t, 0)
+	it, err := client.CheckpointRangeIterator(100, 130, nil, &CheckpointRangeOptions{Concurrency: 4})
+	if err != nil {
+		t.Fatalf("iterator: %v", err)
+	}
+
+	got := drainRange(t, it)
+	if len(got) != 30 {
+		t.Fatalf("got %d checkpoints", len(got))
+	}
+	for i, seq := range got {
+		if seq != uint64(100+i) {
+			t.Fatalf("checkpoint %d has sequence %d", i, seq)
+		}
+	}
+	requests, peak := store.stats()
+	if len(requests) != 30 {
+		t.Fatalf("fetched %d checkpoints, want 30", len(requests))
+	}
+	if peak < 2 || peak > 4 {
+		t.Fatalf("peak concurrency %d, want 2..4", peak)
+	}
+	if _, err := it.Next(context.Background()); !errors.Is(err, io.EOF) {
+		t.Fatalf("Next after exhaustion returned %v", err)
+	}
+}
+
+func TestCheckpointRangeIteratorFallsBackToArchive(t *testing.T) {
+	fullnode, client := newFakeCheckpointStore(t, 10)
+	archiveStore, archive := newFakeCheckpointStore(t, 0)
+
+	it, err := client.CheckpointRangeIterator(5, 15, nil, &CheckpointRangeOptions{Archive: archive})
+	if err != nil {
+		t.Fatalf("iterator: %v", err)
+	}
+	got := drainRange(t, it)
+	if want := []uint64{5, 6, 7, 8, 9, 10, 11, 12, 13, 14}; !equalSequences(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	fromArchive, _ := archiveStore.stats()
+	if len(fromArchive) != 5 {
+		t.Fatalf("archive served %v, want 5..9", fromArchive)
+	}
+	fromFullnode, _ := fullnode.stats()
+	for _, seq := range fromFullnode {
+		if seq < 10 {
+			t.Fatalf("fullnode asked for pruned checkpoint %d", seq)
+		}
+	}
+}
+
+func TestCheckpointRangeIteratorReportsPrunedRange(t *testing.T) {
+	_, client := newFakeCheckpointStore(t, 10)
+	it, err := client.CheckpointRangeIterator(5, 15, nil, nil)
+	if err != nil {
+		t.Fatalf("iterator: %v", err)
+	}
+	if _, err := it.Next(context.Background()); !errors.Is(err, ErrCheckpointPruned) {
+		t.Fatalf("expected ErrCheckpointPruned, got %v", err)
+	}
+}
+
+func TestCheckpointRangeIteratorEmptyRange(t *testing.T) {
+	_, client := newFakeCheckpointStore(t, 0)
+	it, err := client.CheckpointRangeIterator(7, 7, nil, nil)
+	if err != nil {
+		t.Fatalf("iterator: %v", err)
+	}
+	if _, err := it.Next(context.Background()); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected io.EOF, got %v", err)
+	}
+	if _, err := client.CheckpointRangeIterator(8, 7, nil, nil); err == nil {
+		t.Fatalf("expected error for inverted range")
+	}
+}
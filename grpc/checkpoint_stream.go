@@ -0,0 +1,339 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// ErrStreamRunning indicates Run was called on a CheckpointStream that is already running.
+var ErrStreamRunning = errors.New("checkpoint stream already running")
+
+// CheckpointHandler receives checkpoints from a CheckpointStream. Returning an
+// error stops the stream and is returned from Run; the checkpoint is not
+// counted as delivered, so the next Run starts with it again. ctx carries the
+// cursor saved after a successful return; see CursorFromContext.
+type CheckpointHandler func(ctx context.Context, checkpoint *v2.Checkpoint) error
+
+// CheckpointStreamOptions configures CheckpointStream.
+type CheckpointStreamOptions struct {
+	// ReadMask selects the checkpoint fields to deliver, relative to Checkpoint
+	// (e.g. "transactions.digest"). sequence_number and summary.timestamp are
+	// always included.
+	ReadMask *fieldmaskpb.FieldMask
+	// Start is the first checkpoint to deliver. Checkpoints between Start and
+	// the subscription's first cursor are backfilled. When nil, delivery starts
+	// at the first checkpoint the subscription returns.
+	Start *uint64
+	// InitialBackoff and MaxBackoff bound the delay between reconnect attempts.
+	// They default to 250ms and 30s.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// SubscriptionCallOptions are passed to SubscribeCheckpoints.
+	SubscriptionCallOptions []grpc.CallOption
+	// BackfillCallOptions are passed to GetCheckpoint when filling gaps.
+	BackfillCallOptions []grpc.CallOption
+	// Archive serves missed checkpoints the fullnode no longer has, e.g. a
+	// client from NewMainnetArchiveClient. The stream does not close it.
+	Archive *GRPCClient
+	// CursorStore, when set, records the next checkpoint to deliver after each
+	// delivery. A saved cursor takes precedence over Start when Run begins.
+	CursorStore CursorStore
+}
+
+// CheckpointStreamStats is a snapshot of a CheckpointStream's progress.
+type CheckpointStreamStats struct {
+	// LastDelivered is the sequence number of the last checkpoint passed to the handler.
+	LastDelivered uint64
+	// LatestSeen is the highest cursor reported by the subscription.
+	LatestSeen uint64
+	// CheckpointLag is LatestSeen - LastDelivered.
+	CheckpointLag uint64
+	// TimeLag is how long after its timestamp the last checkpoint was delivered.
+	TimeLag time.Duration
+	// Delivered counts checkpoints passed to the handler, including backfilled ones.
+	Delivered uint64
+	// Backfilled counts checkpoints fetched with GetCheckpoint to close gaps.
+	Backfilled uint64
+	// Reconnects counts subscription restarts after the stream failed.
+	Reconnects uint64
+}
+
+// CheckpointStream delivers checkpoints in order and without duplicates,
+// reconnecting with backoff when the subscription fails and backfilling any
+// checkpoints missed while disconnected.
+type CheckpointStream struct {
+	client  *GRPCClient
+	options CheckpointStreamOptions
+	running atomic.Bool
+
+	mu      sync.Mutex
+	next    uint64
+	started bool
+	loaded  bool
+	stats   CheckpointStreamStats
+}
+
+// CheckpointStream returns a stream of checkpoints. Call Run or Checkpoints to start it.
+func (c *GRPCClient) CheckpointStream(options *CheckpointStreamOptions) (*CheckpointStream, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	s := &CheckpointStream{client: c}
+	if options != nil {
+		s.options = *options
+		s.options.ReadMask = cloneFieldMask(options.ReadMask)
+		s.options.SubscriptionCallOptions = append([]grpc.CallOption(nil), options.SubscriptionCallOptions...)
+		s.options.BackfillCallOptions = append([]grpc.CallOption(nil), options.BackfillCallOptions...)
+	}
+	if s.options.Start != nil {
+		s.next = *s.options.Start
+		s.started = true
+	}
+	s.options.ReadMask = ensureFieldMaskPaths(s.options.ReadMask, "sequence_number", "summary.timestamp")
+	return s, nil
+}
+
+// Cursor returns the sequence number of the next checkpoint to deliver. ok is
+// false until the stream has a starting point.
+func (s *CheckpointStream) Cursor() (next uint64, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.next, s.started
+}
+
+// Stats returns a snapshot of the stream's delivery and lag metrics.
+func (s *CheckpointStream) Stats() CheckpointStreamStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := s.stats
+	if stats.LatestSeen > stats.LastDelivered {
+		stats.CheckpointLag = stats.LatestSeen - stats.LastDelivered
+	}
+	return stats
+}
+
+// Run delivers checkpoints to handler until ctx is done or handler returns an
+// error. Subscription and backfill failures are retried with backoff, except
+// a missed checkpoint below the node's lowest available checkpoint when no
+// Archive is set: Run then returns an error wrapping ErrCheckpointPruned. Run returns ctx.Err() on
+// cancellation and may be called again afterwards to resume from the current
+// cursor.
+func (s *CheckpointStream) Run(ctx context.Context, handler CheckpointHandler) error {
+	if ctx == nil {
+		return errors.New("nil context")
+	}
+	if handler == nil {
+		return errors.New("nil checkpoint handler")
+	}
+	if !s.running.CompareAndSwap(false, true) {
+		return ErrStreamRunning
+	}
+	defer s.running.Store(false)
+	if err := s.loadCursor(ctx); err != nil {
+		return err
+	}
+
+	retry := newBackoff(s.options.InitialBackoff, s.options.MaxBackoff)
+	for {
+		err := s.subscribe(ctx, handler, retry)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var handlerErr *handlerError
+		if errors.As(err, &handlerErr) {
+			return handlerErr.err
+		}
+		if errors.Is(err, ErrCheckpointPruned) {
+			return err
+		}
+
+		s.mu.Lock()
+		s.stats.Reconnects++
+		s.mu.Unlock()
+		if err := retry.wait(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// Checkpoints runs the stream in the background and delivers checkpoints on
+// the returned channel, which is closed when the stream stops. The error
+// channel then yields the reason, as returned by Run.
+func (s *CheckpointStream) Checkpoints(ctx context.Context, buffer int) (<-chan *v2.Checkpoint, <-chan error) {
+	out := make(chan *v2.Checkpoint, buffer)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer close(out)
+		errc <- s.Run(ctx, func(ctx context.Context, checkpoint *v2.Checkpoint) error {
+			select {
+			case out <- checkpoint:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return out, errc
+}
+
+// handlerError marks errors returned by the caller's handler, which stop the stream.
+type handlerError struct {
+	err error
+}
+
+func (e *handlerError) Error() string { return e.err.Error() }
+
+// subscribe runs one subscription until it fails.
+func (s *CheckpointStream) subscribe(ctx context.Context, handler CheckpointHandler, retry *backoff) error {
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	paths := make([]string, 0, len(s.options.ReadMask.GetPaths())+1)
+	paths = append(paths, "cursor")
+	for _, path := range s.options.ReadMask.GetPaths() {
+		paths = append(paths, "checkpoint."+path)
+	}
+	stream, err := s.client.SubscriptionClient().SubscribeCheckpoints(subCtx, &v2.SubscribeCheckpointsRequest{
+		ReadMask: &fieldmaskpb.FieldMask{Paths: paths},
+	}, s.options.SubscriptionCallOptions...)
+	if err != nil {
+		return fmt.Errorf("subscribe checkpoints: %w", err)
+	}
+
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+		checkpoint := msg.GetCheckpoint()
+		if checkpoint == nil {
+			continue
+		}
+		sequence := checkpoint.GetSequenceNumber()
+		if msg.Cursor != nil {
+			sequence = msg.GetCursor()
+		}
+		checkpoint.SequenceNumber = &sequence
+		retry.reset()
+
+		s.mu.Lock()
+		if sequence > s.stats.LatestSeen {
+			s.stats.LatestSeen = sequence
+		}
+		if !s.started {
+			s.next = sequence
+			s.started = true
+		}
+		next := s.next
+		s.mu.Unlock()
+
+		if sequence < next {
+			continue
+		}
+		for ; next < sequence; next++ {
+			missing, err := s.backfill(ctx, next)
+			if err != nil {
+				return fmt.Errorf("backfill checkpoint %d: %w", next, err)
+			}
+			if err := s.deliver(ctx, handler, next, missing, true); err != nil {
+				return err
+			}
+		}
+		if err := s.deliver(ctx, handler, sequence, checkpoint, false); err != nil {
+			return err
+		}
+	}
+}
+
+// backfill fetches a checkpoint the subscription skipped. When the fullnode
+// does not have it, the checkpoint is read from the archive, or reported as
+// pruned if it is below the fullnode's lowest available checkpoint.
+func (s *CheckpointStream) backfill(ctx context.Context, sequence uint64) (*v2.Checkpoint, error) {
+	checkpoint, err := s.client.GetCheckpointBySequence(ctx, sequence, s.options.ReadMask, s.options.BackfillCallOptions...)
+	if err == nil || !errors.Is(err, ErrNotFound) {
+		return checkpoint, err
+	}
+	if s.options.Archive != nil {
+		checkpoint, err = s.options.Archive.GetCheckpointBySequence(ctx, sequence, s.options.ReadMask, s.options.BackfillCallOptions...)
+		if err != nil {
+			return nil, fmt.Errorf("archive: %w", err)
+		}
+		return checkpoint, nil
+	}
+	info, infoErr := s.client.GetServiceInfo(ctx, s.options.BackfillCallOptions...)
+	if infoErr != nil {
+		return nil, err
+	}
+	if lowest := info.GetLowestAvailableCheckpoint(); sequence < lowest {
+		return nil, fmt.Errorf("%w: checkpoint %d, lowest available %d", ErrCheckpointPruned, sequence, lowest)
+	}
+	return nil, err
+}
+
+// loadCursor resumes from the cursor store once, before the first delivery.
+func (s *CheckpointStream) loadCursor(ctx context.Context) error {
+	s.mu.Lock()
+	loaded := s.loaded
+	s.loaded = true
+	s.mu.Unlock()
+	if loaded || s.options.CursorStore == nil {
+		return nil
+	}
+
+	cursor, ok, err := s.options.CursorStore.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("load checkpoint cursor: %w", err)
+	}
+	if !ok {
+		return nil
+	}
+	next, err := strconv.ParseUint(cursor, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid checkpoint cursor %q: %w", cursor, err)
+	}
+	s.resume(next)
+	return nil
+}
+
+// resume sets the next checkpoint to deliver.
+func (s *CheckpointStream) resume(next uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next = next
+	s.started = true
+}
+
+func (s *CheckpointStream) deliver(ctx context.Context, handler CheckpointHandler, sequence uint64, checkpoint *v2.Checkpoint, backfilled bool) error {
+	cursor := strconv.FormatUint(sequence+1, 10)
+	if err := handler(withPendingCursor(ctx, cursor), checkpoint); err != nil {
+		return &handlerError{err: err}
+	}
+	if store := s.options.CursorStore; store != nil {
+		if err := store.Save(ctx, cursor); err != nil {
+			return &handlerError{err: fmt.Errorf("save checkpoint cursor: %w", err)}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next = sequence + 1
+	s.stats.LastDelivered = sequence
+	s.stats.Delivered++
+	if backfilled {
+		s.stats.Backfilled++
+	}
+	if ts := checkpoint.GetSummary().GetTimestamp(); ts != nil {
+		s.stats.TimeLag = time.Since(ts.AsTime())
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeCheckpointNode serves scripted checkpoint subscriptions. Each call to
+// SubscribeCheckpoints plays the next session and then fails with Unavailable;
+// once the sessions run out the stream stays open until cancelled.
+type fakeCheckpointNode struct {
+	v2.UnimplementedLedgerServiceServer
+	v2.UnimplementedSubscriptionServiceServer
+
+	mu            sync.Mutex
+	sessions      [][]uint64
+	subscriptions int
+	fetched       []uint64
+	// lowest is the lowest available checkpoint; GetCheckpoint reports
+	// anything below it as not found.
+	lowest uint64
+	// build, when set, replaces testCheckpoint.
+	build func(seq uint64) *v2.Checkpoint
+}
+
+func (n *fakeCheckpointNode) SubscribeCheckpoints(_ *v2.SubscribeCheckpointsRequest, stream grpc.ServerStreamingServer[v2.SubscribeCheckpointsResponse]) error {
+	n.mu.Lock()
+	n.subscriptions++
+	var session []uint64
+	last := len(n.sessions) == 0
+	if !last {
+		session, n.sessions = n.sessions[0], n.sessions[1:]
+	}
+	n.mu.Unlock()
+
+	if last {
+		<-stream.Context().Done()
+		return nil
+	}
+	for _, seq := range session {
+		cursor := seq
+		if err := stream.Send(&v2.SubscribeCheckpointsResponse{Cursor: &cursor, Checkpoint: n.checkpoint(seq)}); err != nil {
+			return err
+		}
+	}
+	return status.Error(codes.Unavailable, "stream reset")
+}
+
+func (n *fakeCheckpointNode) GetCheckpoint(_ context.Context, req *v2.GetCheckpointRequest) (*v2.GetCheckpointResponse, error) {
+	seq := req.GetSequenceNumber()
+	n.mu.Lock()
+	n.fetched = append(n.fetched, seq)
+	n.mu.Unlock()
+	if seq < n.lowest {
+		return nil, status.Errorf(codes.NotFound, "checkpoint %d not found", seq)
+	}
+	return &v2.GetCheckpointResponse{Checkpoint: n.checkpoint(seq)}, nil
+}
+
+func (n *fakeCheckpointNode) GetServiceInfo(context.Context, *v2.GetServiceInfoRequest) (*v2.GetServiceInfoResponse, error) {
+	return &v2.GetServiceInfoResponse{LowestAvailableCheckpoint: &n.lowest}, nil
+}
+
+func (n *fakeCheckpointNode) checkpoint(seq uint64) *v2.Checkpoint {
+	if n.build != nil {
+		return n.build(seq)
+	}
+	return testCheckpoint(seq)
+}
+
+func (n *fakeCheckpointNode) backfilled() []uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]uint64(nil), n.fetched...)
+}
+
+func testCheckpoint(seq uint64) *v2.Checkpoint {
+	digest := "checkpoint"
+	return &v2.Checkpoint{
+		SequenceNumber: &seq,
+		Digest:         &digest,
+		Summary:        &v2.CheckpointSummary{Timestamp: timestamppb.New(time.Now().Add(-time.Second))},
+	}
+}
+
+func newFakeCheckpointNode(t *testing.T, sessions ...[]uint64) (*fakeCheckpointNode, *GRPCClient) {
+	t.Helper()
+	node := &fakeCheckpointNode{sessions: sessions}
+	client := newTestClient(t, func(s *grpc.Server) {
+		v2.RegisterLedgerServiceServer(s, node)
+		v2.RegisterSubscriptionServiceServer(s, node)
+	})
+	return node, client
+}
+
+// collectCheckpoints runs stream until want checkpoints were delivered.
+func collectCheckpoints(t *testing.T, stream *CheckpointStream, want int) []uint64 {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []uint64
+	errDone := errors.New("done")
+	err := stream.Run(ctx, func(_ context.Context, checkpoint *v2.Checkpoint) error {
+		got = append(got, checkpoint.GetSequenceNumber())
+		if len(got) == want {
+			return errDone
+		}
+		return nil
+	})
+	if !errors.Is(err, errDone) {
+		t.Fatalf("run: %v (delivered %v)", err, got)
+	}
+	return got
+}
+
+func equalSequences(a, b []uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCheckpointStreamReconnectsAndBackfills(t *testing.T) {
+	node, client := newFakeCheckpointNode(t,
+		[]uint64{10, 11, 11},
+		[]uint64{11, 14, 15},
+		[]uint64{16},
+	)
+	stream, err := client.CheckpointStream(&CheckpointStreamOptions{InitialBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+
+	got := collectCheckpoints(t, stream, 7)
+	if want := []uint64{10, 11, 12, 13, 14, 15, 16}; !equalSequences(got, want) {
+		t.Fatalf("delivered %v, want %v", got, want)
+	}
+	if fetched := node.backfilled(); !equalSequences(fetched, []uint64{12, 13}) {
+		t.Fatalf("backfilled %v", fetched)
+	}
+
+	// The handler failed on checkpoint 16, so it does not count as delivered
+	// and remains the stream's cursor.
+	stats := stream.Stats()
+	if stats.Delivered != 6 || stats.Backfilled != 2 || stats.Reconnects != 2 || stats.LastDelivered != 15 || stats.CheckpointLag != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if stats.TimeLag < time.Second {
+		t.Fatalf("time lag = %s", stats.TimeLag)
+	}
+	if next, ok := stream.Cursor(); !ok || next != 16 {
+		t.Fatalf("cursor = %d, %v", next, ok)
+	}
+}
+
+func TestCheckpointStreamStartBackfillsToSubscription(t *testing.T) {
+	node, client := newFakeCheckpointNode(t, []uint64{5, 6})
+	start := uint64(3)
+	stream, err := client.CheckpointStream(&CheckpointStreamOptions{Start: &start, InitialBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+
+	got := collectCheckpoints(t, stream, 4)
+	if want := []uint64{3, 4, 5, 6}; !equalSequences(got, want) {
+		t.Fatalf("delivered %v, want %v", got, want)
+	}
+	if fetched := node.backfilled(); !equalSequences(fetched, []uint64{3, 4}) {
+		t.Fatalf("backfilled %v", fetched)
+	}
+}
+
+func TestCheckpointStreamStopsOnPrunedBackfill(t *testing.T) {
+	node, client := newFakeCheckpointNode(t, []uint64{5, 6})
+	node.lowest = 5
+	start := uint64(4)
+	stream, err := client.CheckpointStream(&CheckpointStreamOptions{Start: &start, InitialBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var got []uint64
+	err = stream.Run(ctx, func(_ context.Context, checkpoint *v2.Checkpoint) error {
+		got = append(got, checkpoint.GetSequenceNumber())
+		return nil
+	})
+	if !errors.Is(err, ErrCheckpointPruned) {
+		t.Fatalf("expected ErrCheckpointPruned, got %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("delivered %v", got)
+	}
+	if next, _ := stream.Cursor(); next != 4 {
+		t.Fatalf("cursor = %d", next)
+	}
+	if stats := stream.Stats(); stats.Reconnects != 0 {
+		t.Fatalf("retried a pruned checkpoint %d times", stats.Reconnects)
+	}
+}
+
+func TestCheckpointStreamBackfillsFromArchive(t *testing.T) {
+	node, client := newFakeCheckpointNode(t, []uint64{5, 6})
+	node.lowest = 5
+	archiveNode, archive := newFakeCheckpointNode(t)
+	start := uint64(3)
+	stream, err := client.CheckpointStream(&CheckpointStreamOptions{Start: &start, Archive: archive, InitialBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+
+	got := collectCheckpoints(t, stream, 4)
+	if want := []uint64{3, 4, 5, 6}; !equalSequences(got, want) {
+		t.Fatalf("delivered %v, want %v", got, want)
+	}
+	if fetched := archiveNode.backfilled(); !equalSequences(fetched, []uint64{3, 4}) {
+		t.Fatalf("archive served %v", fetched)
+	}
+}
+
+func TestCheckpointStreamChannelStopsOnCancel(t *testing.T) {
+	_, client := newFakeCheckpointNode(t, []uint64{1, 2})
+	stream, err := client.CheckpointStream(&CheckpointStreamOptions{InitialBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	checkpoints, errc := stream.Checkpoints(ctx, 0)
+	for _, want := range []uint64{1, 2} {
+		select {
+		case cp := <-checkpoints:
+			if cp.GetSequenceNumber() != want {
+				t.Fatalf("got checkpoint %d, want %d", cp.GetSequenceNumber(), want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for checkpoint %d", want)
+		}
+	}
+	cancel()
+	for range checkpoints {
+	}
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Fatalf("stream stopped with %v", err)
+	}
+}
+
+func TestCheckpointStreamRejectsConcurrentRun(t *testing.T) {
+	_, client := newFakeCheckpointNode(t)
+	stream, err := client.CheckpointStream(nil)
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, errc := stream.Checkpoints(ctx, 0)
+	deadline := time.Now().Add(5 * time.Second)
+	for !stream.running.Load() {
+		if time.Now().After(deadline) {
+			t.Fatalf("stream did not start")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if err := stream.Run(ctx, func(context.Context, *v2.Checkpoint) error { return nil }); !errors.Is(err, ErrStreamRunning) {
+		t.Fatalf("second Run returned %v", err)
+	}
+	cancel()
+	<-errc
+}
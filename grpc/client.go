@@ -17,8 +17,10 @@ import (
 
 // GRPCClient aggregates strongly-typed gRPC clients for the Sui RPC services.
 type GRPCClient struct {
-	endpoint string
-	conn     *grpc.ClientConn
+	endpoint   string
+	conn       *grpc.ClientConn
+	telemetry  *telemetry
+	abortCodes *AbortCodes
 
 	ledgerClient                v2.LedgerServiceClient
 	movePackageClient           v2.MovePackageServiceClient
@@ -46,13 +48,15 @@ func NewClient(ctx context.Context, endpoint string, opts ...Option) (*GRPCClien
 		opt(cfg)
 	}
 
-	dialOpts := make([]grpc.DialOption, 0, len(cfg.dialOptions)+2)
+	interceptors := cfg.interceptorOptions()
+	dialOpts := make([]grpc.DialOption, 0, len(cfg.dialOptions)+len(interceptors)+1)
 	creds, err := selectCredentials(cfg, secure, serverName)
 	if err != nil {
 		return nil, err
 	}
 	dialOpts = append(dialOpts, grpc.WithTransportCredentials(creds))
 	dialOpts = append(dialOpts, cfg.dialOptions...)
+	dialOpts = append(dialOpts, interceptors...)
 
 	conn, err := grpc.NewClient(target, dialOpts...)
 	if err != nil {
@@ -62,6 +66,8 @@ func NewClient(ctx context.Context, endpoint string, opts ...Option) (*GRPCClien
 	c := &GRPCClient{
 		conn:                        conn,
 		endpoint:                    endpoint,
+		telemetry:                   cfg.telemetry,
+		abortCodes:                  cfg.abortCodes,
 		ledgerClient:                v2.NewLedgerServiceClient(conn),
 		movePackageClient:           v2.NewMovePackageServiceClient(conn),
 		nameServiceClient:           v2.NewNameServiceClient(conn),
@@ -89,6 +95,17 @@ func NewDevnetClient(ctx context.Context, opts ...Option) (*GRPCClient, error) {
 	return NewClient(ctx, DevnetFullnodeURL, opts...)
 }
 
+// NewMainnetArchiveClient constructs a GRPCClient that targets the public Sui mainnet archive,
+// which serves checkpoints pruned from fullnodes.
+func NewMainnetArchiveClient(ctx context.Context, opts ...Option) (*GRPCClient, error) {
+	return NewClient(ctx, MainnetArchiveURL, opts...)
+}
+
+// NewTestnetArchiveClient constructs a GRPCClient that targets the public Sui testnet archive.
+func NewTestnetArchiveClient(ctx context.Context, opts ...Option) (*GRPCClient, error) {
+	return NewClient(ctx, TestnetArchiveURL, opts...)
+}
+
 // Endpoint reports the remote endpoint the client was created for.
 func (c *GRPCClient) Endpoint() string {
 	if c == nil {
@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CursorStore persists the progress of a checkpoint or event consumer so that
+// it can resume after a restart. Cursors are opaque text: CheckpointStream
+// stores the next checkpoint sequence number and EventSubscriber an
+// EventCursor.
+//
+// Streams save the cursor after the handler returns, so a crash in between
+// redelivers the last checkpoint (at-least-once). Handlers that need
+// exactly-once effects can read the pending cursor with CursorFromContext and
+// commit it atomically with their own writes, backed by a store whose Load
+// reads it from the same place.
+type CursorStore interface {
+	// Load returns the saved cursor; ok is false when none has been saved.
+	Load(ctx context.Context) (cursor string, ok bool, err error)
+	// Save durably records cursor.
+	Save(ctx context.Context, cursor string) error
+}
+
+type pendingCursorKey struct{}
+
+// CursorFromContext returns the cursor that will be saved once the handler
+// receiving ctx returns successfully.
+func CursorFromContext(ctx context.Context) (string, bool) {
+	cursor, ok := ctx.Value(pendingCursorKey{}).(string)
+	return cursor, ok
+}
+
+func withPendingCursor(ctx context.Context, cursor string) context.Context {
+	return context.WithValue(ctx, pendingCursorKey{}, cursor)
+}
+
+// MemoryCursorStore keeps the cursor in memory. It is useful in tests and for
+// consumers that only need to survive reconnects.
+type MemoryCursorStore struct {
+	mu     sync.Mutex
+	cursor string
+	ok     bool
+}
+
+// NewMemoryCursorStore returns an empty in-memory cursor store.
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{}
+}
+
+// Load implements CursorStore.
+func (s *MemoryCursorStore) Load(context.Context) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursor, s.ok, nil
+}
+
+// Save implements CursorStore.
+func (s *MemoryCursorStore) Save(_ context.Context, cursor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cursor, s.ok = cursor, true
+	return nil
+}
+
+// FileCursorStore keeps the cursor in a file. Saves write a temporary file,
+// fsync it and rename it over the previous cursor, so a crash leaves either
+// the old or the new cursor on disk.
+type FileCursorStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileCursorStore returns a store backed by the file at path. The file's
+// directory must exist.
+func NewFileCursorStore(path string) (*FileCursorStore, error) {
+	if path == "" {
+		return nil, errors.New("empty cursor path")
+	}
+	return &FileCursorStore{path: path}, nil
+}
+
+// Load implements CursorStore.
+func (s *FileCursorStore) Load(context.Context) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("load cursor: %w", err)
+	}
+	return strings.TrimSpace(string(data)), true, nil
+}
+
+// Save implements CursorStore.
+func (s *FileCursorStore) Save(_ context.Context, cursor string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := writeFileAtomic(s.path, []byte(cursor+"\n")); err != nil {
+		return fmt.Errorf("save cursor: %w", err)
+	}
+	return nil
+}
+
+func writeFileAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	// Persist the rename itself.
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
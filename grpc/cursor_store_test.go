@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+)
+
+func TestFileCursorStore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cursor")
+	store, err := NewFileCursorStore(path)
+	if err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, ok, err := store.Load(ctx); err != nil || ok {
+		t.Fatalf("empty store: ok=%v err=%v", ok, err)
+	}
+	for _, cursor := range []string{"10", "11:2:0"} {
+		if err := store.Save(ctx, cursor); err != nil {
+			t.Fatalf("save %q: %v", cursor, err)
+		}
+	}
+
+	reopened, _ := NewFileCursorStore(path)
+	cursor, ok, err := reopened.Load(ctx)
+	if err != nil || !ok || cursor != "11:2:0" {
+		t.Fatalf("load: %q ok=%v err=%v", cursor, ok, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("read dir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected only the cursor file, found %d entries", len(entries))
+	}
+
+	if _, err := NewFileCursorStore(""); err == nil {
+		t.Fatalf("expected error for empty path")
+	}
+}
+
+func TestCheckpointStreamResumesFromCursorStore(t *testing.T) {
+	_, client := newFakeCheckpointNode(t, []uint64{10, 11, 12, 13, 14})
+	store := NewMemoryCursorStore()
+	if err := store.Save(context.Background(), "12"); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	start := uint64(0)
+	stream, err := client.CheckpointStream(&CheckpointStreamOptions{Start: &start, CursorStore: store})
+	if err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var got []uint64
+	err = stream.Run(ctx, func(ctx context.Context, checkpoint *v2.Checkpoint) error {
+		pending, ok := CursorFromContext(ctx)
+		if want := strconv.FormatUint(checkpoint.GetSequenceNumber()+1, 10); !ok || pending != want {
+			t.Errorf("pending cursor %q, want %q", pending, want)
+		}
+		got = append(got, checkpoint.GetSequenceNumber())
+		if len(got) == 3 {
+			cancel()
+		}
+		return nil
+	})
+	if err != context.Canceled {
+		t.Fatalf("run: %v", err)
+	}
+	if !equalSequences(got, []uint64{12, 13, 14}) {
+		t.Fatalf("delivered %v", got)
+	}
+	if cursor, _, _ := store.Load(context.Background()); cursor != "15" {
+		t.Fatalf("stored cursor %q", cursor)
+	}
+}
+
+func TestEventSubscriberResumesFromCursorStore(t *testing.T) {
+	client := newFakeEventNode(t, []uint64{20, 21})
+	store, err := NewFileCursorStore(filepath.Join(t.TempDir(), "events"))
+	if err != nil {
+		t.Fatalf("store: %v", err)
+	}
+	if err := store.Save(context.Background(), "20:1:0"); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	subscriber, err := client.EventSubscriber(&EventSubscriberOptions{
+		Filters:     []EventFilter{{Module: "pool"}},
+		CursorStore: store,
+	})
+	if err != nil {
+		t.Fatalf("subscriber: %v", err)
+	}
+
+	got := collectEvents(t, subscriber, 4)
+	if want := []string{"20:1:0", "20:1:1", "21:1:0", "21:1:1"}; !equalStrings(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if cursor, _, _ := store.Load(context.Background()); cursor != "21:0:0" {
+		t.Fatalf("stored cursor %q", cursor)
+	}
+}
@@ -0,0 +1,184 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// Error kinds reported by helpers. Match them with errors.Is; use errors.As
+// with *Error for the status code, ErrorReason and offending field.
+var (
+	// ErrNotFound indicates the requested object, transaction, checkpoint or
+	// epoch does not exist on the node.
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidArgument indicates a request field was missing or invalid.
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrPruned indicates the requested data existed but the node no longer
+	// retains it. ErrCheckpointPruned, derived from the node's lowest available
+	// checkpoint, wraps it. A NotFound status also matches it when its message
+	// mentions pruning; that match is a heuristic, since the node reports no
+	// structured detail for pruned data.
+	ErrPruned = errors.New("pruned")
+	// ErrUnavailable indicates the node could not serve the request, for
+	// example because it is overloaded or unreachable.
+	ErrUnavailable = errors.New("unavailable")
+	// ErrMalformedResponse indicates the node answered without the data the
+	// helper asked for.
+	ErrMalformedResponse = errors.New("malformed response")
+)
+
+// Error is a failed helper call. It carries the gRPC status code and, when
+// the node attached google.rpc.BadRequest or google.rpc.ErrorInfo details, the
+// ErrorReason and path of the offending request field.
+//
+// Error implements GRPCStatus, so status.Code and status.FromError keep
+// working on it.
+type Error struct {
+	Code    codes.Code
+	Message string
+	// Reason is FIELD_INVALID or FIELD_MISSING when the node rejected a
+	// request field, and ERROR_REASON_UNKNOWN otherwise.
+	Reason v2.ErrorReason
+	// Field is the path of the offending request field, e.g. "object_id".
+	Field string
+
+	status *grpcstatus.Status
+	kinds  []error
+	cause  error
+}
+
+// ParseError converts err into an *Error, decoding the google.rpc.Status
+// details of gRPC errors. It returns nil for a nil err and err itself when it
+// already is an *Error.
+func ParseError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+
+	st, ok := grpcstatus.FromError(err)
+	if !ok {
+		st = grpcstatus.FromContextError(err)
+	}
+	e = &Error{Code: st.Code(), Message: st.Message(), status: st, cause: err}
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			if violations := d.GetFieldViolations(); len(violations) > 0 && e.Field == "" {
+				e.Field = violations[0].GetField()
+				e.Reason = parseErrorReason(violations[0].GetReason())
+			}
+		case *errdetails.ErrorInfo:
+			if e.Reason == v2.ErrorReason_ERROR_REASON_UNKNOWN {
+				e.Reason = parseErrorReason(d.GetReason())
+			}
+		}
+	}
+	e.kinds = errorKinds(e.Code, e.Message)
+	return e
+}
+
+func parseErrorReason(reason string) v2.ErrorReason {
+	return v2.ErrorReason(v2.ErrorReason_value[reason])
+}
+
+// errorKinds maps a status to the sentinel errors it matches.
+func errorKinds(code codes.Code, message string) []error {
+	var kinds []error
+	switch code {
+	case codes.NotFound:
+		if strings.Contains(strings.ToLower(message), "pruned") {
+			kinds = append(kinds, ErrPruned)
+		}
+		kinds = append(kinds, ErrNotFound)
+	case codes.InvalidArgument:
+		kinds = append(kinds, ErrInvalidArgument)
+	case codes.Unavailable, codes.ResourceExhausted:
+		kinds = append(kinds, ErrUnavailable)
+	case codes.Internal, codes.DataLoss:
+		kinds = append(kinds, ErrMalformedResponse)
+	case codes.Canceled:
+		kinds = append(kinds, context.Canceled)
+	case codes.DeadlineExceeded:
+		kinds = append(kinds, context.DeadlineExceeded)
+	}
+	return kinds
+}
+
+// newError returns an *Error for a failure detected by the client itself.
+func newError(code codes.Code, field string, reason v2.ErrorReason, format string, args ...any) *Error {
+	message := fmt.Sprintf(format, args...)
+	return &Error{
+		Code:    code,
+		Message: message,
+		Reason:  reason,
+		Field:   field,
+		status:  grpcstatus.New(code, message),
+		kinds:   errorKinds(code, message),
+	}
+}
+
+func missingFieldError(field, format string, args ...any) error {
+	return newError(codes.InvalidArgument, field, v2.ErrorReason_FIELD_MISSING, format, args...)
+}
+
+func invalidFieldError(field, format string, args ...any) error {
+	return newError(codes.InvalidArgument, field, v2.ErrorReason_FIELD_INVALID, format, args...)
+}
+
+func notFoundError(format string, args ...any) error {
+	return newError(codes.NotFound, "", v2.ErrorReason_ERROR_REASON_UNKNOWN, format, args...)
+}
+
+func malformedResponseError(format string, args ...any) error {
+	return newError(codes.Internal, "", v2.ErrorReason_ERROR_REASON_UNKNOWN, format, args...)
+}
+
+// rpcError converts a failed RPC's error into an *Error.
+func rpcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return ParseError(err)
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Code.String())
+	b.WriteString(": ")
+	b.WriteString(e.Message)
+	if e.Field != "" {
+		fmt.Fprintf(&b, " (field %s", e.Field)
+		if e.Reason != v2.ErrorReason_ERROR_REASON_UNKNOWN {
+			fmt.Fprintf(&b, ": %s", e.Reason)
+		}
+		b.WriteString(")")
+	}
+	return b.String()
+}
+
+// Unwrap returns the matching error kinds and the underlying error, if any.
+func (e *Error) Unwrap() []error {
+	if e.cause == nil {
+		return e.kinds
+	}
+	return append(append([]error(nil), e.kinds...), e.cause)
+}
+
+// GRPCStatus returns the status the error was decoded from.
+func (e *Error) GRPCStatus() *grpcstatus.Status {
+	if e.status == nil {
+		return grpcstatus.New(e.Code, e.Message)
+	}
+	return e.status
+}
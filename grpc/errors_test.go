@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type errorNode struct {
+	v2.UnimplementedLedgerServiceServer
+}
+
+func (errorNode) GetObject(context.Context, *v2.GetObjectRequest) (*v2.GetObjectResponse, error) {
+	st, err := status.New(codes.InvalidArgument, "invalid object_id").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+			Field:       "object_id",
+			Description: "invalid object_id",
+			Reason:      v2.ErrorReason_FIELD_INVALID.String(),
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return nil, st.Err()
+}
+
+func (errorNode) GetTransaction(context.Context, *v2.GetTransactionRequest) (*v2.GetTransactionResponse, error) {
+	return &v2.GetTransactionResponse{}, nil
+}
+
+func (errorNode) GetCheckpoint(context.Context, *v2.GetCheckpointRequest) (*v2.GetCheckpointResponse, error) {
+	return nil, status.Error(codes.NotFound, "checkpoint 3 has been pruned")
+}
+
+func (errorNode) BatchGetObjects(_ context.Context, req *v2.BatchGetObjectsRequest) (*v2.BatchGetObjectsResponse, error) {
+	code := int32(codes.NotFound)
+	message := "object not found"
+	results := make([]*v2.GetObjectResult, len(req.GetRequests()))
+	for i := range results {
+		results[i] = &v2.GetObjectResult{Result: &v2.GetObjectResult_Error{Error: &rpcstatus.Status{Code: code, Message: message}}}
+	}
+	return &v2.BatchGetObjectsResponse{Objects: results}, nil
+}
+
+func newErrorClient(t *testing.T) *GRPCClient {
+	t.Helper()
+	return newTestClient(t, func(s *grpc.Server) {
+		v2.RegisterLedgerServiceServer(s, errorNode{})
+	})
+}
+
+func TestErrorDecodesFieldViolations(t *testing.T) {
+	client := newErrorClient(t)
+
+	_, err := client.GetObject(context.Background(), "0xzz", nil)
+	if !errors.Is(err, ErrInvalidArgument) || errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrInvalidArgument, got %v", err)
+	}
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatalf("expected *Error, got %T", err)
+	}
+	if e.Reason != v2.ErrorReason_FIELD_INVALID || e.Field != "object_id" {
+		t.Fatalf("unexpected reason %v field %q", e.Reason, e.Field)
+	}
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("status code not preserved: %v", status.Code(err))
+	}
+	if got := err.Error(); got != "InvalidArgument: invalid object_id (field object_id: FIELD_INVALID)" {
+		t.Fatalf("unexpected message %q", got)
+	}
+}
+
+func TestErrorKindsFromHelpers(t *testing.T) {
+	client := newErrorClient(t)
+	ctx := context.Background()
+
+	if _, err := client.GetObject(ctx, "", nil); !errors.Is(err, ErrInvalidArgument) {
+		t.Fatalf("expected ErrInvalidArgument for empty ID, got %v", err)
+	} else if e := ParseError(err); e.Reason != v2.ErrorReason_FIELD_MISSING || e.Field != "object_id" {
+		t.Fatalf("unexpected reason %v field %q", e.Reason, e.Field)
+	}
+
+	if _, err := client.GetTransaction(ctx, "digest", nil); !errors.Is(err, ErrNotFound) || status.Code(err) != codes.NotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+
+	_, err := client.GetCheckpointBySequence(ctx, 3, nil)
+	if !errors.Is(err, ErrPruned) || !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrPruned and ErrNotFound, got %v", err)
+	}
+	if !errors.Is(ErrCheckpointPruned, ErrPruned) || ErrCheckpointPruned.Error() != "checkpoint has been pruned" {
+		t.Fatalf("ErrCheckpointPruned should wrap ErrPruned: %v", ErrCheckpointPruned)
+	}
+
+	results, err := client.BatchGetObjects(ctx, []ObjectRequest{{ObjectID: "0x1"}}, nil)
+	if err != nil {
+		t.Fatalf("batch get objects: %v", err)
+	}
+	if !errors.Is(results[0].Err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound result, got %v", results[0].Err)
+	}
+}
+
+func TestParseErrorContextErrors(t *testing.T) {
+	if ParseError(nil) != nil {
+		t.Fatalf("expected nil for nil error")
+	}
+	err := ParseError(context.DeadlineExceeded)
+	if err.Code != codes.DeadlineExceeded || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("unexpected parse of deadline error: %v", err)
+	}
+}
+
+func TestErrPrunedOnlyMatchesNotFound(t *testing.T) {
+	err := ParseError(status.Error(codes.Internal, "failed to read pruned object store"))
+	if errors.Is(err, ErrPruned) || !errors.Is(err, ErrMalformedResponse) {
+		t.Fatalf("internal error classified as %v", err.kinds)
+	}
+}
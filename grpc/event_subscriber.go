@@ -0,0 +1,458 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/0xdraco/sui-go-sdk/types"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// EventFilter selects events. All non-empty fields must match.
+type EventFilter struct {
+	// Package matches the package of the Move call that emitted the event.
+	Package string
+	// Module matches the module of the Move call that emitted the event.
+	Module string
+	// EventType matches the event's struct type. A type without type
+	// parameters, e.g. "0x2::coin::CoinEvent", matches every instantiation;
+	// type parameters are matched recursively by the same rule.
+	EventType string
+	// Sender matches the sender of the emitting transaction.
+	Sender string
+	// TransactionDigest matches the digest of the emitting transaction.
+	TransactionDigest string
+}
+
+// Event is an event delivered by an EventSubscriber together with its position.
+type Event struct {
+	Checkpoint        uint64
+	Timestamp         time.Time
+	TransactionDigest string
+	// TransactionIndex and EventIndex locate the event within its checkpoint.
+	TransactionIndex uint64
+	EventIndex       uint64
+	Event            *v2.Event
+}
+
+// EventHandler receives events from an EventSubscriber. Returning an error
+// stops the subscriber; the event is delivered again by the next Run. ctx
+// carries the cursor following the event; see CursorFromContext.
+type EventHandler func(ctx context.Context, event *Event) error
+
+// EventCursor is the position of the next event to deliver. Its text form,
+// "checkpoint:transaction:event", is suitable for persisting between runs.
+type EventCursor struct {
+	Checkpoint  uint64
+	Transaction uint64
+	Event       uint64
+}
+
+// String renders the cursor as "checkpoint:transaction:event".
+func (c EventCursor) String() string {
+	return fmt.Sprintf("%d:%d:%d", c.Checkpoint, c.Transaction, c.Event)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c EventCursor) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *EventCursor) UnmarshalText(text []byte) error {
+	cursor, err := ParseEventCursor(string(text))
+	if err != nil {
+		return err
+	}
+	*c = cursor
+	return nil
+}
+
+// ParseEventCursor parses a cursor produced by EventCursor.String. A bare
+// checkpoint number is accepted and starts at its first event.
+func ParseEventCursor(raw string) (EventCursor, error) {
+	parts := strings.Split(strings.TrimSpace(raw), ":")
+	if len(parts) != 1 && len(parts) != 3 {
+		return EventCursor{}, fmt.Errorf("invalid event cursor %q", raw)
+	}
+	var values [3]uint64
+	for i, part := range parts {
+		value, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return EventCursor{}, fmt.Errorf("invalid event cursor %q: %w", raw, err)
+		}
+		values[i] = value
+	}
+	return EventCursor{Checkpoint: values[0], Transaction: values[1], Event: values[2]}, nil
+}
+
+func (c EventCursor) before(transaction, event uint64) bool {
+	return transaction < c.Transaction || transaction == c.Transaction && event < c.Event
+}
+
+// EventSubscriberOptions configures EventSubscriber.
+type EventSubscriberOptions struct {
+	// Filters selects the events to deliver; an event matching any filter is
+	// delivered. No filters deliver every event.
+	Filters []EventFilter
+	// Cursor resumes delivery from a previously saved position. When nil,
+	// delivery starts at the latest checkpoint.
+	Cursor *EventCursor
+	// SaveCursor, when set, is called with the cursor after each checkpoint
+	// has been fully delivered. An error stops the subscriber. Events of a
+	// checkpoint interrupted by a restart are delivered again, so handlers
+	// should tolerate duplicates.
+	SaveCursor func(ctx context.Context, cursor EventCursor) error
+	// CursorStore, when set, is loaded when Run begins, taking precedence over
+	// Cursor, and saved after each checkpoint like SaveCursor.
+	CursorStore CursorStore
+	// IncludeJSON requests the JSON rendering of each event.
+	IncludeJSON bool
+	// InitialBackoff and MaxBackoff bound the delay between reconnect attempts.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// SubscriptionCallOptions are passed to SubscribeCheckpoints.
+	SubscriptionCallOptions []grpc.CallOption
+	// BackfillCallOptions are passed to GetCheckpoint when filling gaps.
+	BackfillCallOptions []grpc.CallOption
+}
+
+// EventSubscriber delivers the events matching its filters in checkpoint
+// order, on top of a CheckpointStream.
+type EventSubscriber struct {
+	stream     *CheckpointStream
+	filters    []eventMatcher
+	eventTypes eventTypeCache
+	saveCursor func(context.Context, EventCursor) error
+	store      CursorStore
+
+	mu      sync.Mutex
+	cursor  EventCursor
+	started bool
+	loaded  bool
+}
+
+// EventSubscriber returns a subscriber for the events matching options.Filters.
+// Call Run or Events to start it.
+func (c *GRPCClient) EventSubscriber(options *EventSubscriberOptions) (*EventSubscriber, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if options == nil {
+		options = &EventSubscriberOptions{}
+	}
+
+	filters := make([]eventMatcher, 0, len(options.Filters))
+	for i, filter := range options.Filters {
+		matcher, err := newEventMatcher(filter)
+		if err != nil {
+			return nil, fmt.Errorf("event filter %d: %w", i, err)
+		}
+		filters = append(filters, matcher)
+	}
+
+	eventPaths := []string{"package_id", "module", "sender", "event_type", "contents"}
+	if options.IncludeJSON {
+		eventPaths = append(eventPaths, "json")
+	}
+	paths := []string{"transactions.digest"}
+	for _, path := range eventPaths {
+		paths = append(paths, "transactions.events.events."+path)
+	}
+	streamOptions := &CheckpointStreamOptions{
+		ReadMask:                &fieldmaskpb.FieldMask{Paths: paths},
+		InitialBackoff:          options.InitialBackoff,
+		MaxBackoff:              options.MaxBackoff,
+		SubscriptionCallOptions: options.SubscriptionCallOptions,
+		BackfillCallOptions:     options.BackfillCallOptions,
+	}
+
+	s := &EventSubscriber{filters: filters, eventTypes: eventTypeCache{}, saveCursor: options.SaveCursor, store: options.CursorStore}
+	if options.Cursor != nil {
+		s.cursor = *options.Cursor
+		s.started = true
+		start := options.Cursor.Checkpoint
+		streamOptions.Start = &start
+	}
+	stream, err := c.CheckpointStream(streamOptions)
+	if err != nil {
+		return nil, err
+	}
+	s.stream = stream
+	return s, nil
+}
+
+// Cursor returns the position of the next event to deliver. ok is false until
+// the subscriber has a starting point.
+func (s *EventSubscriber) Cursor() (cursor EventCursor, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cursor, s.started
+}
+
+// Stats returns the underlying checkpoint stream's delivery and lag metrics.
+func (s *EventSubscriber) Stats() CheckpointStreamStats {
+	return s.stream.Stats()
+}
+
+// Run delivers matching events to handler until ctx is done or handler
+// returns an error, with the same reconnect behaviour as CheckpointStream.Run.
+func (s *EventSubscriber) Run(ctx context.Context, handler EventHandler) error {
+	if ctx == nil {
+		return errors.New("nil context")
+	}
+	if handler == nil {
+		return errors.New("nil event handler")
+	}
+	if err := s.loadCursor(ctx); err != nil {
+		return err
+	}
+	return s.stream.Run(ctx, func(ctx context.Context, checkpoint *v2.Checkpoint) error {
+		return s.handleCheckpoint(ctx, handler, checkpoint)
+	})
+}
+
+// Events runs the subscriber in the background and delivers events on the
+// returned channel, which is closed when the subscriber stops. The error
+// channel then yields the reason, as returned by Run.
+func (s *EventSubscriber) Events(ctx context.Context, buffer int) (<-chan *Event, <-chan error) {
+	out := make(chan *Event, buffer)
+	errc := make(chan error, 1)
+	go func() {
+		defer close(errc)
+		defer close(out)
+		errc <- s.Run(ctx, func(ctx context.Context, event *Event) error {
+			select {
+			case out <- event:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+	}()
+	return out, errc
+}
+
+// loadCursor resumes from the cursor store once, before the first delivery.
+func (s *EventSubscriber) loadCursor(ctx context.Context) error {
+	s.mu.Lock()
+	loaded := s.loaded
+	s.loaded = true
+	s.mu.Unlock()
+	if loaded || s.store == nil {
+		return nil
+	}
+
+	raw, ok, err := s.store.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("load event cursor: %w", err)
+	}
+	if !ok {
+		return nil
+	}
+	cursor, err := ParseEventCursor(raw)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.cursor = cursor
+	s.started = true
+	s.mu.Unlock()
+	s.stream.resume(cursor.Checkpoint)
+	return nil
+}
+
+func (s *EventSubscriber) handleCheckpoint(ctx context.Context, handler EventHandler, checkpoint *v2.Checkpoint) error {
+	sequence := checkpoint.GetSequenceNumber()
+	s.mu.Lock()
+	if !s.started || s.cursor.Checkpoint != sequence {
+		s.cursor = EventCursor{Checkpoint: sequence}
+		s.started = true
+	}
+	cursor := s.cursor
+	s.mu.Unlock()
+
+	var timestamp time.Time
+	if ts := checkpoint.GetSummary().GetTimestamp(); ts != nil {
+		timestamp = ts.AsTime()
+	}
+	for txIndex, tx := range checkpoint.GetTransactions() {
+		for eventIndex, event := range tx.GetEvents().GetEvents() {
+			position := EventCursor{Checkpoint: sequence, Transaction: uint64(txIndex), Event: uint64(eventIndex)}
+			if cursor.before(position.Transaction, position.Event) || !s.matches(tx.GetDigest(), event) {
+				continue
+			}
+			position.Event++
+			err := handler(withPendingCursor(ctx, position.String()), &Event{
+				Checkpoint:        sequence,
+				Timestamp:         timestamp,
+				TransactionDigest: tx.GetDigest(),
+				TransactionIndex:  position.Transaction,
+				EventIndex:        position.Event - 1,
+				Event:             event,
+			})
+			if err != nil {
+				return err
+			}
+			s.mu.Lock()
+			s.cursor = position
+			s.mu.Unlock()
+		}
+	}
+
+	next := EventCursor{Checkpoint: sequence + 1}
+	if s.saveCursor != nil {
+		if err := s.saveCursor(ctx, next); err != nil {
+			return fmt.Errorf("save event cursor: %w", err)
+		}
+	}
+	if s.store != nil {
+		if err := s.store.Save(ctx, next.String()); err != nil {
+			return fmt.Errorf("save event cursor: %w", err)
+		}
+	}
+	s.mu.Lock()
+	s.cursor = next
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *EventSubscriber) matches(digest string, event *v2.Event) bool {
+	if len(s.filters) == 0 {
+		return true
+	}
+	for _, filter := range s.filters {
+		if filter.matches(digest, event, s.eventTypes) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxCachedEventTypes bounds eventTypeCache; the cache is cleared when full.
+const maxCachedEventTypes = 4096
+
+// eventTypeCache memoizes parsed event types, which repeat across checkpoints.
+// Unparseable types are cached as nil. A nil cache parses without caching.
+type eventTypeCache map[string]*types.StructTag
+
+func (c eventTypeCache) parse(raw string) (types.StructTag, bool) {
+	if tag, ok := c[raw]; ok {
+		if tag == nil {
+			return types.StructTag{}, false
+		}
+		return *tag, true
+	}
+	tag, err := types.ParseStructTag(raw)
+	if c != nil {
+		if len(c) >= maxCachedEventTypes {
+			clear(c)
+		}
+		if err != nil {
+			c[raw] = nil
+		} else {
+			c[raw] = &tag
+		}
+	}
+	return tag, err == nil
+}
+
+// eventMatcher is an EventFilter with its addresses and type parsed.
+type eventMatcher struct {
+	pkg       *types.Address
+	module    string
+	eventType *types.StructTag
+	sender    *types.Address
+	digest    string
+}
+
+func newEventMatcher(filter EventFilter) (eventMatcher, error) {
+	m := eventMatcher{module: filter.Module, digest: filter.TransactionDigest}
+	if filter.Package != "" {
+		pkg, err := types.ParseAddress(filter.Package)
+		if err != nil {
+			return m, fmt.Errorf("package: %w", err)
+		}
+		m.pkg = &pkg
+	}
+	if filter.Sender != "" {
+		sender, err := types.ParseAddress(filter.Sender)
+		if err != nil {
+			return m, fmt.Errorf("sender: %w", err)
+		}
+		m.sender = &sender
+	}
+	if filter.EventType != "" {
+		tag, err := types.ParseStructTag(filter.EventType)
+		if err != nil {
+			return m, fmt.Errorf("event type: %w", err)
+		}
+		m.eventType = &tag
+	}
+	return m, nil
+}
+
+func (m eventMatcher) matches(digest string, event *v2.Event, eventTypes eventTypeCache) bool {
+	if m.digest != "" && m.digest != digest {
+		return false
+	}
+	if m.module != "" && m.module != event.GetModule() {
+		return false
+	}
+	if m.pkg != nil && !addressEquals(*m.pkg, event.GetPackageId()) {
+		return false
+	}
+	if m.sender != nil && !addressEquals(*m.sender, event.GetSender()) {
+		return false
+	}
+	if m.eventType != nil {
+		tag, ok := eventTypes.parse(event.GetEventType())
+		if !ok || !structTagMatches(*m.eventType, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func addressEquals(want types.Address, raw string) bool {
+	got, err := types.ParseAddress(raw)
+	return err == nil && got == want
+}
+
+// structTagMatches reports whether tag is an instantiation of pattern. A
+// pattern without type parameters matches any instantiation.
+func structTagMatches(pattern, tag types.StructTag) bool {
+	if pattern.Address != tag.Address || pattern.Module != tag.Module || pattern.Name != tag.Name {
+		return false
+	}
+	if len(pattern.TypeParams) == 0 {
+		return true
+	}
+	if len(pattern.TypeParams) != len(tag.TypeParams) {
+		return false
+	}
+	for i, param := range pattern.TypeParams {
+		if !typeTagMatches(param, tag.TypeParams[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func typeTagMatches(pattern, tag types.TypeTag) bool {
+	switch {
+	case pattern.Struct != nil:
+		return tag.Struct != nil && structTagMatches(*pattern.Struct, *tag.Struct)
+	case pattern.Vector != nil:
+		return tag.Vector != nil && typeTagMatches(*pattern.Vector, *tag.Vector)
+	default:
+		return pattern.String() == tag.String()
+	}
+}
@@ -0,0 +1,185 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/grpc"
+)
+
+// eventCheckpoint builds a checkpoint with two transactions: a SUI coin event
+// sent by 0xa and a DeepBook order event sent by 0xb.
+func eventCheckpoint(seq uint64) *v2.Checkpoint {
+	checkpoint := testCheckpoint(seq)
+	newEvent := func(pkg, module, sender, eventType string) *v2.Event {
+		return &v2.Event{PackageId: &pkg, Module: &module, Sender: &sender, EventType: &eventType}
+	}
+	for i, event := range []*v2.Event{
+		newEvent("0x2", "pay", "0xa", "0x2::coin::CoinEvent<0x2::sui::SUI>"),
+		newEvent("0xdee9", "pool", "0xb", "0xdee9::pool::OrderPlaced<0x2::sui::SUI, 0x5::usdc::USDC>"),
+	} {
+		digest := fmt.Sprintf("tx-%d-%d", seq, i)
+		checkpoint.Transactions = append(checkpoint.Transactions, &v2.ExecutedTransaction{
+			Digest: &digest,
+			Events: &v2.TransactionEvents{Events: []*v2.Event{event, event}},
+		})
+	}
+	return checkpoint
+}
+
+func newFakeEventNode(t *testing.T, sessions ...[]uint64) *GRPCClient {
+	t.Helper()
+	node := &fakeCheckpointNode{sessions: sessions, build: eventCheckpoint}
+	return newTestClient(t, func(s *grpc.Server) {
+		v2.RegisterLedgerServiceServer(s, node)
+		v2.RegisterSubscriptionServiceServer(s, node)
+	})
+}
+
+// collectEvents runs subscriber until want events were delivered and returns their positions.
+func collectEvents(t *testing.T, subscriber *EventSubscriber, want int) []string {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var got []string
+	errDone := errors.New("done")
+	err := subscriber.Run(ctx, func(_ context.Context, event *Event) error {
+		got = append(got, fmt.Sprintf("%d:%d:%d", event.Checkpoint, event.TransactionIndex, event.EventIndex))
+		if len(got) == want {
+			return errDone
+		}
+		return nil
+	})
+	if !errors.Is(err, errDone) {
+		t.Fatalf("run: %v (delivered %v)", err, got)
+	}
+	return got
+}
+
+func equalStrings(a, b []string) bool {
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func TestEventFilterMatching(t *testing.T) {
+	checkpoint := eventCheckpoint(1)
+	coin := checkpoint.Transactions[0].Events.Events[0]
+	order := checkpoint.Transactions[1].Events.Events[0]
+
+	cases := []struct {
+		filter EventFilter
+		coin   bool
+		order  bool
+	}{
+		{EventFilter{}, true, true},
+		{EventFilter{Package: "0x0000000000000000000000000000000000000000000000000000000000000002"}, true, false},
+		{EventFilter{Module: "pool"}, false, true},
+		{EventFilter{Sender: "0xb"}, false, true},
+		{EventFilter{TransactionDigest: "tx-1-0"}, true, false},
+		{EventFilter{EventType: "0x2::coin::CoinEvent"}, true, false},
+		{EventFilter{EventType: "0x2::coin::CoinEvent<0x2::sui::SUI>"}, true, false},
+		{EventFilter{EventType: "0x2::coin::CoinEvent<0x5::usdc::USDC>"}, false, false},
+		{EventFilter{EventType: "0xdee9::pool::OrderPlaced<0x2::sui::SUI, 0x5::usdc::USDC>"}, false, true},
+		{EventFilter{EventType: "0xdee9::pool::OrderPlaced<0x2::sui::SUI>"}, false, false},
+		{EventFilter{Package: "0x2", Sender: "0xb"}, false, false},
+	}
+	eventTypes := eventTypeCache{}
+	for _, tc := range cases {
+		matcher, err := newEventMatcher(tc.filter)
+		if err != nil {
+			t.Fatalf("%+v: %v", tc.filter, err)
+		}
+		if got := matcher.matches("tx-1-0", coin, eventTypes); got != tc.coin {
+			t.Fatalf("%+v: coin event matched=%v, want %v", tc.filter, got, tc.coin)
+		}
+		if got := matcher.matches("tx-1-1", order, eventTypes); got != tc.order {
+			t.Fatalf("%+v: order event matched=%v, want %v", tc.filter, got, tc.order)
+		}
+	}
+
+	if len(eventTypes) != 2 {
+		t.Fatalf("expected the two event types to be parsed once, cached %v", eventTypes)
+	}
+
+	if _, err := newEventMatcher(EventFilter{EventType: "u64"}); err == nil {
+		t.Fatalf("expected error for non-struct event type")
+	}
+}
+
+func TestEventSubscriberResumesFromCursor(t *testing.T) {
+	client := newFakeEventNode(t, []uint64{10, 11})
+
+	var mu sync.Mutex
+	var saved []EventCursor
+	subscriber, err := client.EventSubscriber(&EventSubscriberOptions{
+		Filters: []EventFilter{{EventType: "0xdee9::pool::OrderPlaced"}},
+		Cursor:  &EventCursor{Checkpoint: 10, Transaction: 1, Event: 1},
+		SaveCursor: func(_ context.Context, cursor EventCursor) error {
+			mu.Lock()
+			defer mu.Unlock()
+			saved = append(saved, cursor)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("subscriber: %v", err)
+	}
+
+	got := collectEvents(t, subscriber, 3)
+	if want := []string{"10:1:1", "11:1:0", "11:1:1"}; !equalStrings(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(saved) != 1 || saved[0] != (EventCursor{Checkpoint: 11}) {
+		t.Fatalf("saved cursors %v", saved)
+	}
+	if cursor, ok := subscriber.Cursor(); !ok || cursor != (EventCursor{Checkpoint: 11, Transaction: 1, Event: 1}) {
+		t.Fatalf("cursor %v, %v", cursor, ok)
+	}
+}
+
+func TestEventSubscriberRedeliversAfterHandlerError(t *testing.T) {
+	client := newFakeEventNode(t, []uint64{5, 6}, []uint64{6})
+	start := EventCursor{Checkpoint: 5}
+	subscriber, err := client.EventSubscriber(&EventSubscriberOptions{
+		Filters: []EventFilter{{Sender: "0xa"}},
+		Cursor:  &start,
+	})
+	if err != nil {
+		t.Fatalf("subscriber: %v", err)
+	}
+
+	if got := collectEvents(t, subscriber, 3); !equalStrings(got, []string{"5:0:0", "5:0:1", "6:0:0"}) {
+		t.Fatalf("first run delivered %v", got)
+	}
+	// The failed delivery of 6:0:0 is retried; 5's events are not repeated.
+	if got := collectEvents(t, subscriber, 2); !equalStrings(got, []string{"6:0:0", "6:0:1"}) {
+		t.Fatalf("second run delivered %v", got)
+	}
+}
+
+func TestEventCursorText(t *testing.T) {
+	cursor := EventCursor{Checkpoint: 42, Transaction: 3, Event: 7}
+	text, err := cursor.MarshalText()
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded EventCursor
+	if err := decoded.UnmarshalText(text); err != nil || decoded != cursor {
+		t.Fatalf("round trip %q: %v, %v", text, decoded, err)
+	}
+	if parsed, err := ParseEventCursor("42"); err != nil || parsed != (EventCursor{Checkpoint: 42}) {
+		t.Fatalf("bare checkpoint: %v, %v", parsed, err)
+	}
+	for _, bad := range []string{"", "1:2", "a:b:c", "1:2:3:4"} {
+		if _, err := ParseEventCursor(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package grpc
+
+import (
+	"fmt"
+	"strings"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+)
+
+// ExecutionFailure is a decoded ExecutionError. TransactionFailedError wraps
+// one, so errors.As(err, &failure) works on the errors returned by
+// SignAndExecute and EstimateGasBudget.
+type ExecutionFailure struct {
+	Kind        v2.ExecutionError_ExecutionErrorKind
+	Description string
+	// Command is the index of the failing command, when known.
+	Command *uint64
+
+	// The fields below describe a Move abort. AbortCode is nil otherwise.
+	AbortCode   *uint64
+	Package     string
+	Module      string
+	Function    string
+	Instruction uint32
+	// ErrorName is the clever error's constant name or the name found in
+	// AbortCodes.
+	ErrorName string
+	// ErrorValue is the clever error's rendered constant value.
+	ErrorValue string
+	// Line is the source line of a clever error, or zero.
+	Line uint64
+
+	// Argument and ArgumentError describe a COMMAND_ARGUMENT_ERROR.
+	Argument      *uint32
+	ArgumentError v2.CommandArgumentError_CommandArgumentErrorKind
+
+	// Err is the ExecutionError the failure was decoded from.
+	Err *v2.ExecutionError
+}
+
+// DecodeExecutionError decodes execErr, naming abort codes the node did not
+// decode with codes when it is non-nil. It returns nil for a nil execErr.
+func DecodeExecutionError(execErr *v2.ExecutionError, codes *AbortCodes) *ExecutionFailure {
+	if execErr == nil {
+		return nil
+	}
+	f := &ExecutionFailure{
+		Kind:        execErr.GetKind(),
+		Description: execErr.GetDescription(),
+		Command:     execErr.Command,
+		Err:         execErr,
+	}
+
+	if abort := execErr.GetAbort(); abort != nil {
+		location := abort.GetLocation()
+		f.AbortCode = abort.AbortCode
+		f.Package = location.GetPackage()
+		f.Module = location.GetModule()
+		f.Function = location.GetFunctionName()
+		if f.Function == "" && location.Function != nil {
+			f.Function = fmt.Sprintf("function#%d", location.GetFunction())
+		}
+		f.Instruction = location.GetInstruction()
+		if clever := abort.GetCleverError(); clever != nil {
+			f.ErrorName = clever.GetConstantName()
+			f.ErrorValue = clever.GetRendered()
+			f.Line = clever.GetLineNumber()
+		} else if abort.AbortCode != nil {
+			f.ErrorName, f.ErrorValue, f.Line, _ = codes.describe(f.Package, f.Module, abort.GetAbortCode())
+		}
+	}
+
+	if argErr := execErr.GetCommandArgumentError(); argErr != nil {
+		f.Argument = argErr.Argument
+		f.ArgumentError = argErr.GetKind()
+	}
+	return f
+}
+
+func (f *ExecutionFailure) Error() string {
+	if f == nil {
+		return "<nil>"
+	}
+	var b strings.Builder
+	if f.Command != nil {
+		fmt.Fprintf(&b, "command %d: ", *f.Command)
+	}
+
+	if f.AbortCode == nil {
+		if f.Description != "" {
+			b.WriteString(f.Description)
+		} else {
+			b.WriteString(f.Kind.String())
+		}
+		if f.Argument != nil {
+			fmt.Fprintf(&b, " (argument %d: %s)", *f.Argument, f.ArgumentError)
+		}
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "%s aborted", f.location())
+	switch {
+	case f.ErrorName != "" && f.Line > 0:
+		fmt.Fprintf(&b, " with %s at line %d", f.ErrorName, f.Line)
+	case f.ErrorName != "":
+		fmt.Fprintf(&b, " with %s (code %d)", f.ErrorName, *f.AbortCode)
+	default:
+		fmt.Fprintf(&b, " with code %d", *f.AbortCode)
+	}
+	if f.ErrorValue != "" {
+		fmt.Fprintf(&b, ": %s", f.ErrorValue)
+	}
+	return b.String()
+}
+
+// location renders the aborting function as package::module::function.
+func (f *ExecutionFailure) location() string {
+	parts := make([]string, 0, 3)
+	for _, part := range []string{f.Package, f.Module, f.Function} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return "move call"
+	}
+	return strings.Join(parts, "::")
+}
@@ -0,0 +1,150 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+)
+
+const testAbortPackage = "0xabc"
+
+func testMoveAbort(code uint64, clever *v2.CleverError) *v2.ExecutionError {
+	function := uint32(3)
+	return &v2.ExecutionError{
+		Command: uint64Ptr(1),
+		Kind:    v2.ExecutionError_MOVE_ABORT.Enum(),
+		ErrorDetails: &v2.ExecutionError_Abort{Abort: &v2.MoveAbort{
+			AbortCode: &code,
+			Location: &v2.MoveLocation{
+				Package:      stringPtr(testAbortPackage),
+				Module:       stringPtr("pool"),
+				Function:     &function,
+				FunctionName: stringPtr("swap"),
+			},
+			CleverError: clever,
+		}},
+	}
+}
+
+func TestDecodeExecutionErrorCleverAbort(t *testing.T) {
+	failure := DecodeExecutionError(testMoveAbort(0x8000_0000_0001_0002, &v2.CleverError{
+		ErrorCode:    uint64Ptr(2),
+		LineNumber:   uint64Ptr(42),
+		ConstantName: stringPtr("EInsufficientLiquidity"),
+		Value:        &v2.CleverError_Rendered{Rendered: `"pool is empty"`},
+	}), nil)
+
+	if failure.ErrorName != "EInsufficientLiquidity" || failure.Line != 42 {
+		t.Fatalf("unexpected failure %+v", failure)
+	}
+	want := `command 1: 0xabc::pool::swap aborted with EInsufficientLiquidity at line 42: "pool is empty"`
+	if got := failure.Error(); got != want {
+		t.Fatalf("unexpected message\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestDecodeExecutionErrorAbortCodes(t *testing.T) {
+	codes := NewAbortCodes()
+	if err := codes.Register("0x0000000000000000000000000000000000000000000000000000000000000abc", AbortCodeTable{
+		"pool": {7: "EZeroAmount"},
+	}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	failure := DecodeExecutionError(testMoveAbort(7, nil), codes)
+	if got, want := failure.Error(), "command 1: 0xabc::pool::swap aborted with EZeroAmount (code 7)"; got != want {
+		t.Fatalf("unexpected message\n got: %s\nwant: %s", got, want)
+	}
+	if got, want := DecodeExecutionError(testMoveAbort(8, nil), codes).Error(), "command 1: 0xabc::pool::swap aborted with code 8"; got != want {
+		t.Fatalf("unexpected message\n got: %s\nwant: %s", got, want)
+	}
+
+	argument := uint32(2)
+	argFailure := DecodeExecutionError(&v2.ExecutionError{
+		Command:      uint64Ptr(0),
+		Kind:         v2.ExecutionError_COMMAND_ARGUMENT_ERROR.Enum(),
+		ErrorDetails: &v2.ExecutionError_CommandArgumentError{CommandArgumentError: &v2.CommandArgumentError{Argument: &argument, Kind: v2.CommandArgumentError_TYPE_MISMATCH.Enum()}},
+	}, codes)
+	if got, want := argFailure.Error(), "command 0: COMMAND_ARGUMENT_ERROR (argument 2: TYPE_MISMATCH)"; got != want {
+		t.Fatalf("unexpected message\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestSignAndExecuteReportsExecutionFailure(t *testing.T) {
+	codes := NewAbortCodes()
+	if err := codes.Register(testAbortPackage, AbortCodeTable{"pool": {7: "EZeroAmount"}}); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	signer := testSigner(t)
+	address, _ := signer.SuiAddress()
+	node, client := newFakeExecutionNode(t, WithAbortCodes(codes))
+	node.simulateError = testMoveAbort(7, nil)
+
+	tx := testTransferTransaction(t, address)
+	tx.GasPayment = &v2.GasPayment{Budget: uint64Ptr(5_000_000)}
+	_, err := client.SignAndExecute(context.Background(), signer, tx, &SignAndExecuteOptions{DryRun: true})
+
+	var failure *ExecutionFailure
+	if !errors.As(err, &failure) {
+		t.Fatalf("expected ExecutionFailure, got %v", err)
+	}
+	if failure.ErrorName != "EZeroAmount" || failure.Module != "pool" || *failure.AbortCode != 7 {
+		t.Fatalf("unexpected failure %+v", failure)
+	}
+	if got, want := err.Error(), "dry run failed in command 1: 0xabc::pool::swap aborted with EZeroAmount (code 7)"; got != want {
+		t.Fatalf("unexpected message\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestSimulateTransactionReportsExecutionFailure(t *testing.T) {
+	signer := testSigner(t)
+	address, _ := signer.SuiAddress()
+	node, client := newFakeExecutionNode(t)
+	node.simulateError = testMoveAbort(7, nil)
+
+	resp, err := client.SimulateTransaction(context.Background(), testTransferTransaction(t, address), nil)
+	var failed *TransactionFailedError
+	if !errors.As(err, &failed) || !failed.DryRun {
+		t.Fatalf("expected dry-run TransactionFailedError, got %v", err)
+	}
+	var failure *ExecutionFailure
+	if !errors.As(err, &failure) || *failure.AbortCode != 7 {
+		t.Fatalf("expected ExecutionFailure with code 7, got %v", err)
+	}
+	if resp.GetTransaction().GetEffects() == nil {
+		t.Fatalf("expected simulated effects alongside the error")
+	}
+}
+
+func TestExecuteSignedTransactionAndWaitReportsExecutionFailure(t *testing.T) {
+	signer := testSigner(t)
+	address, _ := signer.SuiAddress()
+	node, client := newFakeExecutionNode(t)
+	node.executeError = testMoveAbort(8, nil)
+
+	tx := testTransferTransaction(t, address)
+	tx.Sender = stringPtr(address)
+	tx.GasPayment = &v2.GasPayment{
+		Owner:  stringPtr(address),
+		Price:  uint64Ptr(1000),
+		Budget: uint64Ptr(5_000_000),
+		Objects: []*v2.ObjectReference{{
+			ObjectId: stringPtr(testGasCoinID),
+			Version:  uint64Ptr(9),
+			Digest:   stringPtr(testCoinDigest),
+		}},
+	}
+	executed, err := client.ExecuteSignedTransactionAndWait(context.Background(), &ExecuteAndWaitRequest{Transaction: tx}, nil)
+	var failed *TransactionFailedError
+	if !errors.As(err, &failed) || failed.DryRun {
+		t.Fatalf("expected on-chain TransactionFailedError, got %v", err)
+	}
+	if executed == nil || executed.GetDigest() != failed.Transaction.GetDigest() {
+		t.Fatalf("expected executed transaction alongside the error, got %v", executed)
+	}
+	if got, want := err.Error(), "transaction "+executed.GetDigest()+" failed in command 1: 0xabc::pool::swap aborted with code 8"; got != want {
+		t.Fatalf("unexpected message\n got: %s\nwant: %s", got, want)
+	}
+}
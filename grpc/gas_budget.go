@@ -0,0 +1,192 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const (
+	// DefaultGasBudgetMultiplier is the safety margin applied to simulated gas costs.
+	DefaultGasBudgetMultiplier = 1.2
+
+	// gasSafeOverhead is the number of gas units, priced at the gas price, used as
+	// the default budget floor.
+	gasSafeOverhead = uint64(1000)
+
+	// maxTxGasAttribute is the protocol config attribute holding the maximum gas budget.
+	maxTxGasAttribute = "max_tx_gas"
+)
+
+// ErrGasBudgetExceedsMax indicates the simulated gas cost cannot fit within the maximum budget.
+var ErrGasBudgetExceedsMax = errors.New("estimated gas cost exceeds maximum gas budget")
+
+// GasBudgetOptions customises EstimateGasBudget.
+type GasBudgetOptions struct {
+	// Multiplier scales the simulated net cost to leave headroom for state changes
+	// between simulation and execution. Values below 1 use DefaultGasBudgetMultiplier.
+	Multiplier float64
+	// Floor is the minimum budget returned. Zero uses 1000 gas units at the gas price.
+	Floor uint64
+	// MaxBudget caps the budget. Zero uses the current protocol config's max_tx_gas.
+	MaxBudget uint64
+}
+
+// EstimateGasBudget simulates tx and writes a gas budget into tx.GasPayment.Budget. The
+// budget is computation + storage - rebate from the simulated GasCostSummary, scaled by the
+// configured multiplier, raised to the floor and capped by the maximum budget allowed by the
+// protocol. An unset gas price is simulated at the reference gas price but is not written back.
+// A failed simulation is reported as a *TransactionFailedError.
+func (c *GRPCClient) EstimateGasBudget(ctx context.Context, tx *v2.Transaction, options *GasBudgetOptions, opts ...grpc.CallOption) (uint64, error) {
+	if c == nil {
+		return 0, errors.New("nil client")
+	}
+	if ctx == nil {
+		return 0, errors.New("nil context")
+	}
+	if tx == nil {
+		return 0, errors.New("nil transaction")
+	}
+
+	budget, _, err := c.estimateGasBudget(ctx, tx, options, opts...)
+	if err != nil {
+		return 0, err
+	}
+	if tx.GasPayment == nil {
+		tx.GasPayment = &v2.GasPayment{}
+	}
+	tx.GasPayment.Budget = &budget
+	return budget, nil
+}
+
+// estimateGasBudget returns the estimated budget together with the simulated transaction.
+func (c *GRPCClient) estimateGasBudget(ctx context.Context, tx *v2.Transaction, options *GasBudgetOptions, opts ...grpc.CallOption) (uint64, *v2.ExecutedTransaction, error) {
+	cfg := GasBudgetOptions{}
+	if options != nil {
+		cfg = *options
+	}
+	if cfg.Multiplier < 1 {
+		cfg.Multiplier = DefaultGasBudgetMultiplier
+	}
+
+	epoch, err := c.GetCurrentEpoch(ctx, &fieldmaskpb.FieldMask{
+		Paths: []string{"reference_gas_price", "protocol_config.attributes"},
+	}, opts...)
+	if err != nil {
+		return 0, nil, fmt.Errorf("gas budget: %w", err)
+	}
+	if cfg.MaxBudget == 0 {
+		cfg.MaxBudget, err = maxGasBudget(epoch.GetProtocolConfig())
+		if err != nil {
+			return 0, nil, err
+		}
+	}
+
+	cloned := proto.Clone(tx)
+	if cloned == nil {
+		return 0, nil, errors.New("failed to clone transaction")
+	}
+	simTx := cloned.(*v2.Transaction)
+	if simTx.GasPayment == nil {
+		simTx.GasPayment = &v2.GasPayment{}
+	}
+	gasPrice := simTx.GasPayment.GetPrice()
+	if gasPrice == 0 {
+		gasPrice = epoch.GetReferenceGasPrice()
+		simTx.GasPayment.Price = &gasPrice
+	}
+	// Let the fullnode size the simulation budget and pick coins when none are set;
+	// only the reported usage and gas payment are kept.
+	simTx.GasPayment.Budget = nil
+
+	resp, err := c.SimulateTransaction(ctx, simTx, &SimulateTransactionOptions{
+		ReadMask:       &fieldmaskpb.FieldMask{Paths: []string{"transaction.effects", "transaction.transaction.kind", "transaction.transaction.gas_payment"}},
+		DoGasSelection: boolPtr(true),
+	}, opts...)
+	var failed *TransactionFailedError
+	if errors.As(err, &failed) {
+		return 0, resp.GetTransaction(), failed
+	}
+	if err != nil {
+		return 0, nil, fmt.Errorf("gas budget: simulate: %w", err)
+	}
+	simulated := resp.GetTransaction()
+	used := simulated.GetEffects().GetGasUsed()
+	if used == nil {
+		return 0, simulated, errors.New("gas budget: simulation response missing gas usage")
+	}
+
+	cost := netGasCost(used)
+	if cfg.MaxBudget > 0 && cost > cfg.MaxBudget {
+		return 0, simulated, fmt.Errorf("%w: cost %d, max %d", ErrGasBudgetExceedsMax, cost, cfg.MaxBudget)
+	}
+
+	budget := scaleGas(cost, cfg.Multiplier)
+	floor := cfg.Floor
+	if floor == 0 {
+		floor = saturatingMul(gasSafeOverhead, gasPrice)
+	}
+	if budget < floor {
+		budget = floor
+	}
+	if cfg.MaxBudget > 0 && budget > cfg.MaxBudget {
+		budget = cfg.MaxBudget
+	}
+	return budget, simulated, nil
+}
+
+// netGasCost is computation + storage - rebate, never less than the computation cost.
+func netGasCost(used *v2.GasCostSummary) uint64 {
+	computation := used.GetComputationCost()
+	cost := saturatingAdd(computation, used.GetStorageCost())
+	if rebate := used.GetStorageRebate(); rebate < cost {
+		cost -= rebate
+	} else {
+		cost = 0
+	}
+	if cost < computation {
+		cost = computation
+	}
+	return cost
+}
+
+func maxGasBudget(cfg *v2.ProtocolConfig) (uint64, error) {
+	raw, ok := cfg.GetAttributes()[maxTxGasAttribute]
+	if !ok || raw == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("gas budget: parse %s %q: %w", maxTxGasAttribute, raw, err)
+	}
+	return value, nil
+}
+
+func scaleGas(value uint64, multiplier float64) uint64 {
+	scaled := math.Ceil(float64(value) * multiplier)
+	if scaled >= math.MaxUint64 {
+		return math.MaxUint64
+	}
+	return uint64(scaled)
+}
+
+func saturatingAdd(a, b uint64) uint64 {
+	if a+b < a {
+		return math.MaxUint64
+	}
+	return a + b
+}
+
+func saturatingMul(a, b uint64) uint64 {
+	if a != 0 && b > math.MaxUint64/a {
+		return math.MaxUint64
+	}
+	return a * b
+}
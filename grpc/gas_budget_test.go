@@ -0,0 +1,98 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+)
+
+func TestEstimateGasBudget(t *testing.T) {
+	node, client := newFakeExecutionNode(t)
+	ctx := context.Background()
+
+	cases := []struct {
+		name    string
+		gasUsed *v2.GasCostSummary
+		options *GasBudgetOptions
+		want    uint64
+	}{
+		{
+			name:    "default multiplier",
+			gasUsed: gasCostSummary(1_000_000, 2_000_000, 500_000),
+			want:    3_000_000,
+		},
+		{
+			name:    "custom multiplier",
+			gasUsed: gasCostSummary(1_000_000, 2_000_000, 500_000),
+			options: &GasBudgetOptions{Multiplier: 2},
+			want:    5_000_000,
+		},
+		{
+			name:    "rebate never undercuts computation",
+			gasUsed: gasCostSummary(2_000_000, 0, 9_000_000),
+			options: &GasBudgetOptions{Multiplier: 1},
+			want:    2_000_000,
+		},
+		{
+			name:    "default floor",
+			gasUsed: gasCostSummary(1_000, 0, 0),
+			want:    1_000_000,
+		},
+		{
+			name:    "custom floor",
+			gasUsed: gasCostSummary(1_000_000, 0, 0),
+			options: &GasBudgetOptions{Floor: 7_000_000},
+			want:    7_000_000,
+		},
+		{
+			name:    "capped by max budget",
+			gasUsed: gasCostSummary(4_000_000, 0, 0),
+			options: &GasBudgetOptions{MaxBudget: 4_500_000},
+			want:    4_500_000,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			node.gasUsed = tc.gasUsed
+			tx := testTransferTransaction(t, testGasCoinID)
+			budget, err := client.EstimateGasBudget(ctx, tx, tc.options)
+			if err != nil {
+				t.Fatalf("estimate: %v", err)
+			}
+			if budget != tc.want {
+				t.Fatalf("budget mismatch: got %d want %d", budget, tc.want)
+			}
+			if tx.GetGasPayment().GetBudget() != tc.want {
+				t.Fatalf("budget not written to transaction: %d", tx.GetGasPayment().GetBudget())
+			}
+			if tx.GetGasPayment().Price != nil {
+				t.Fatalf("gas price must not be written back")
+			}
+		})
+	}
+}
+
+func TestEstimateGasBudgetExceedsProtocolMax(t *testing.T) {
+	node, client := newFakeExecutionNode(t)
+	node.maxTxGas = "1000000"
+	node.gasUsed = gasCostSummary(2_000_000, 0, 0)
+
+	tx := testTransferTransaction(t, testGasCoinID)
+	if _, err := client.EstimateGasBudget(context.Background(), tx, nil); !errors.Is(err, ErrGasBudgetExceedsMax) {
+		t.Fatalf("expected ErrGasBudgetExceedsMax, got %v", err)
+	}
+	if tx.GetGasPayment().GetBudget() != 0 {
+		t.Fatalf("budget must not be written on failure")
+	}
+}
+
+func gasCostSummary(computation, storage, rebate uint64) *v2.GasCostSummary {
+	return &v2.GasCostSummary{
+		ComputationCost: &computation,
+		StorageCost:     &storage,
+		StorageRebate:   &rebate,
+	}
+}
@@ -28,7 +28,7 @@ func (c *GRPCClient) GetObject(ctx context.Context, objectID string, options *Ge
 		return nil, errors.New("nil context")
 	}
 	if objectID == "" {
-		return nil, errors.New("object ID is empty")
+		return nil, missingFieldError("object_id", "object ID is empty")
 	}
 
 	req := &v2.GetObjectRequest{ObjectId: stringPtr(objectID)}
@@ -44,11 +44,11 @@ func (c *GRPCClient) GetObject(ctx context.Context, objectID string, options *Ge
 
 	resp, err := c.LedgerClient().GetObject(ctx, req, opts...)
 	if err != nil {
-		return nil, err
+		return nil, rpcError(err)
 	}
 	obj := resp.GetObject()
 	if obj == nil {
-		return nil, fmt.Errorf("object %q not found", objectID)
+		return nil, notFoundError("object %q not found", objectID)
 	}
 	return obj, nil
 }
@@ -67,7 +67,7 @@ func (c *GRPCClient) GetTransaction(ctx context.Context, digest string, options
 		return nil, errors.New("nil context")
 	}
 	if digest == "" {
-		return nil, errors.New("transaction digest is empty")
+		return nil, missingFieldError("digest", "transaction digest is empty")
 	}
 
 	req := &v2.GetTransactionRequest{Digest: stringPtr(digest)}
@@ -77,11 +77,11 @@ func (c *GRPCClient) GetTransaction(ctx context.Context, digest string, options
 
 	resp, err := c.LedgerClient().GetTransaction(ctx, req, opts...)
 	if err != nil {
-		return nil, err
+		return nil, rpcError(err)
 	}
 	tx := resp.GetTransaction()
 	if tx == nil {
-		return nil, fmt.Errorf("transaction %q not found", digest)
+		return nil, notFoundError("transaction %q not found", digest)
 	}
 	return tx, nil
 }
@@ -104,11 +104,11 @@ func (c *GRPCClient) GetCheckpointBySequence(ctx context.Context, sequence uint6
 
 	resp, err := c.LedgerClient().GetCheckpoint(ctx, req, opts...)
 	if err != nil {
-		return nil, err
+		return nil, rpcError(err)
 	}
 	checkpoint := resp.GetCheckpoint()
 	if checkpoint == nil {
-		return nil, fmt.Errorf("checkpoint %d not found", sequence)
+		return nil, notFoundError("checkpoint %d not found", sequence)
 	}
 	return checkpoint, nil
 }
@@ -122,7 +122,7 @@ func (c *GRPCClient) GetCheckpointByDigest(ctx context.Context, digest string, r
 		return nil, errors.New("nil context")
 	}
 	if digest == "" {
-		return nil, errors.New("checkpoint digest is empty")
+		return nil, missingFieldError("digest", "checkpoint digest is empty")
 	}
 
 	req := &v2.GetCheckpointRequest{
@@ -134,11 +134,11 @@ func (c *GRPCClient) GetCheckpointByDigest(ctx context.Context, digest string, r
 
 	resp, err := c.LedgerClient().GetCheckpoint(ctx, req, opts...)
 	if err != nil {
-		return nil, err
+		return nil, rpcError(err)
 	}
 	checkpoint := resp.GetCheckpoint()
 	if checkpoint == nil {
-		return nil, fmt.Errorf("checkpoint %q not found", digest)
+		return nil, notFoundError("checkpoint %q not found", digest)
 	}
 	return checkpoint, nil
 }
@@ -159,11 +159,11 @@ func (c *GRPCClient) GetCurrentEpoch(ctx context.Context, readMask *fieldmaskpb.
 
 	resp, err := c.LedgerClient().GetEpoch(ctx, req, opts...)
 	if err != nil {
-		return nil, err
+		return nil, rpcError(err)
 	}
 	epoch := resp.GetEpoch()
 	if epoch == nil {
-		return nil, errors.New("epoch response missing epoch data")
+		return nil, malformedResponseError("epoch response missing epoch data")
 	}
 	return epoch, nil
 }
@@ -178,6 +178,77 @@ func (c *GRPCClient) ReferenceGasPrice(ctx context.Context, opts ...grpc.CallOpt
 	return epoch.GetReferenceGasPrice(), nil
 }
 
+// GetServiceInfo reports the chain, current checkpoint height and the lowest checkpoint the node still serves.
+func (c *GRPCClient) GetServiceInfo(ctx context.Context, opts ...grpc.CallOption) (*v2.GetServiceInfoResponse, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	info, err := c.LedgerClient().GetServiceInfo(ctx, &v2.GetServiceInfoRequest{}, opts...)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	return info, nil
+}
+
+// GetPackage fetches the Move package packageID, including the layouts of its
+// datatypes and the signatures of its functions.
+func (c *GRPCClient) GetPackage(ctx context.Context, packageID string, opts ...grpc.CallOption) (*v2.Package, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	if packageID == "" {
+		return nil, missingFieldError("package_id", "package ID is empty")
+	}
+
+	resp, err := c.MovePackageClient().GetPackage(ctx, &v2.GetPackageRequest{PackageId: stringPtr(packageID)}, opts...)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	pkg := resp.GetPackage()
+	if pkg == nil {
+		return nil, notFoundError("package %q not found", packageID)
+	}
+	return pkg, nil
+}
+
+// GetDatatype fetches the layout of the datatype module::name defined in packageID.
+func (c *GRPCClient) GetDatatype(ctx context.Context, packageID, module, name string, opts ...grpc.CallOption) (*v2.DatatypeDescriptor, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	switch {
+	case packageID == "":
+		return nil, missingFieldError("package_id", "package ID is empty")
+	case module == "":
+		return nil, missingFieldError("module_name", "module name is empty")
+	case name == "":
+		return nil, missingFieldError("name", "datatype name is empty")
+	}
+
+	resp, err := c.MovePackageClient().GetDatatype(ctx, &v2.GetDatatypeRequest{
+		PackageId:  stringPtr(packageID),
+		ModuleName: stringPtr(module),
+		Name:       stringPtr(name),
+	}, opts...)
+	if err != nil {
+		return nil, rpcError(err)
+	}
+	datatype := resp.GetDatatype()
+	if datatype == nil {
+		return nil, notFoundError("datatype %s::%s::%s not found", packageID, module, name)
+	}
+	return datatype, nil
+}
+
 // ObjectRequest describes a single object fetch to include in BatchGetObjects.
 type ObjectRequest struct {
 	ObjectID string
@@ -199,7 +270,7 @@ func (c *GRPCClient) BatchGetObjects(ctx context.Context, requests []ObjectReque
 		return nil, errors.New("nil context")
 	}
 	if len(requests) == 0 {
-		return nil, errors.New("no object requests provided")
+		return nil, missingFieldError("requests", "no object requests provided")
 	}
 
 	batch := &v2.BatchGetObjectsRequest{Requests: make([]*v2.GetObjectRequest, 0, len(requests))}
@@ -209,7 +280,7 @@ func (c *GRPCClient) BatchGetObjects(ctx context.Context, requests []ObjectReque
 
 	for i, req := range requests {
 		if strings.TrimSpace(req.ObjectID) == "" {
-			return nil, fmt.Errorf("request %d has empty object ID", i)
+			return nil, missingFieldError(fmt.Sprintf("requests[%d].object_id", i), "request %d has empty object ID", i)
 		}
 		objReq := &v2.GetObjectRequest{ObjectId: stringPtr(req.ObjectID)}
 		if req.Version != nil {
@@ -221,18 +292,18 @@ func (c *GRPCClient) BatchGetObjects(ctx context.Context, requests []ObjectReque
 
 	resp, err := c.LedgerClient().BatchGetObjects(ctx, batch, opts...)
 	if err != nil {
-		return nil, err
+		return nil, rpcError(err)
 	}
 
 	objects := resp.GetObjects()
 	if len(objects) != len(requests) {
-		return nil, fmt.Errorf("unexpected response length %d (expected %d)", len(objects), len(requests))
+		return nil, malformedResponseError("unexpected response length %d (expected %d)", len(objects), len(requests))
 	}
 
 	results := make([]ObjectResult, len(objects))
 	for i, res := range objects {
 		if res == nil {
-			results[i] = ObjectResult{Err: errors.New("empty object result")}
+			results[i] = ObjectResult{Err: malformedResponseError("empty object result")}
 			continue
 		}
 		if obj := res.GetObject(); obj != nil {
@@ -240,10 +311,10 @@ func (c *GRPCClient) BatchGetObjects(ctx context.Context, requests []ObjectReque
 			continue
 		}
 		if errStatus := res.GetError(); errStatus != nil {
-			results[i] = ObjectResult{Err: grpcstatus.ErrorProto(errStatus)}
+			results[i] = ObjectResult{Err: ParseError(grpcstatus.ErrorProto(errStatus))}
 			continue
 		}
-		results[i] = ObjectResult{Err: errors.New("object result missing data")}
+		results[i] = ObjectResult{Err: malformedResponseError("object result missing data")}
 	}
 
 	return results, nil
@@ -0,0 +1,319 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/0xdraco/sui-go-sdk/keypair"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+const (
+	defaultHealthCheckInterval = 10 * time.Second
+	defaultHealthCheckTimeout  = 5 * time.Second
+	defaultMaxCheckpointLag    = 5
+)
+
+// ErrNoEndpoints indicates NewMultiClient was called without endpoints.
+var ErrNoEndpoints = errors.New("no endpoints")
+
+// MultiClientOptions configures a MultiClient.
+type MultiClientOptions struct {
+	// HealthCheckInterval is the time between GetServiceInfo health checks.
+	// Zero uses 10s; a negative value disables background checks.
+	HealthCheckInterval time.Duration
+	// HealthCheckTimeout bounds each health check. Zero uses 5s.
+	HealthCheckTimeout time.Duration
+	// MaxCheckpointLag is how many checkpoints an endpoint may trail the most
+	// caught-up endpoint and still serve reads. Zero uses 5.
+	MaxCheckpointLag uint64
+	// ClientOptions are applied to every endpoint's client.
+	ClientOptions []Option
+}
+
+// EndpointHealth is the result of the latest health check of an endpoint.
+type EndpointHealth struct {
+	Endpoint         string
+	Healthy          bool
+	CheckpointHeight uint64
+	Latency          time.Duration
+	LastChecked      time.Time
+	// Err is the error of the last failed health check or failed-over call.
+	Err error
+}
+
+// MultiClient spreads calls over several fullnodes. Reads go to healthy
+// endpoints that are caught up with the highest CheckpointHeight, and Do fails
+// over to the next endpoint on transient errors. Transaction execution is
+// pinned to a single endpoint so that the subsequent checkpoint wait, and any
+// reads made through Pin, observe the transaction.
+type MultiClient struct {
+	clients []*GRPCClient
+	options MultiClientOptions
+
+	mu     sync.Mutex
+	health []EndpointHealth
+	next   atomic.Uint64
+
+	stop context.CancelFunc
+	done chan struct{}
+}
+
+// NewMultiClient dials every endpoint, runs an initial health check and starts
+// background health checks. Close stops them and closes all connections.
+func NewMultiClient(ctx context.Context, endpoints []string, options *MultiClientOptions) (*MultiClient, error) {
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	m := &MultiClient{done: make(chan struct{})}
+	if options != nil {
+		m.options = *options
+		m.options.ClientOptions = append([]Option(nil), options.ClientOptions...)
+	}
+	if m.options.HealthCheckInterval == 0 {
+		m.options.HealthCheckInterval = defaultHealthCheckInterval
+	}
+	if m.options.HealthCheckTimeout <= 0 {
+		m.options.HealthCheckTimeout = defaultHealthCheckTimeout
+	}
+	if m.options.MaxCheckpointLag == 0 {
+		m.options.MaxCheckpointLag = defaultMaxCheckpointLag
+	}
+
+	for _, endpoint := range endpoints {
+		client, err := NewClient(ctx, endpoint, m.options.ClientOptions...)
+		if err != nil {
+			for _, c := range m.clients {
+				c.Close()
+			}
+			return nil, err
+		}
+		m.clients = append(m.clients, client)
+		m.health = append(m.health, EndpointHealth{Endpoint: endpoint})
+	}
+
+	m.CheckHealth(ctx)
+	loopCtx, stop := context.WithCancel(context.WithoutCancel(ctx))
+	m.stop = stop
+	if m.options.HealthCheckInterval > 0 {
+		go m.healthLoop(loopCtx)
+	} else {
+		close(m.done)
+	}
+	return m, nil
+}
+
+// Close stops health checks and closes every endpoint's connection.
+func (m *MultiClient) Close() error {
+	if m == nil {
+		return nil
+	}
+	m.stop()
+	<-m.done
+	var errs []error
+	for _, c := range m.clients {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// Clients returns the per-endpoint clients in the order the endpoints were given.
+func (m *MultiClient) Clients() []*GRPCClient {
+	return append([]*GRPCClient(nil), m.clients...)
+}
+
+// Health returns the latest health of every endpoint.
+func (m *MultiClient) Health() []EndpointHealth {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]EndpointHealth(nil), m.health...)
+}
+
+// CheckHealth calls GetServiceInfo on every endpoint concurrently and records
+// the results. It reports whether any endpoint is healthy.
+func (m *MultiClient) CheckHealth(ctx context.Context) bool {
+	var wg sync.WaitGroup
+	for i, client := range m.clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, m.options.HealthCheckTimeout)
+			defer cancel()
+
+			started := time.Now()
+			info, err := client.GetServiceInfo(checkCtx)
+			if err != nil && ctx.Err() != nil {
+				// The caller gave up; that says nothing about the endpoint.
+				return
+			}
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			h := &m.health[i]
+			h.LastChecked = time.Now()
+			h.Latency = h.LastChecked.Sub(started)
+			h.Healthy = err == nil
+			h.Err = err
+			if err == nil {
+				h.CheckpointHeight = info.GetCheckpointHeight()
+			}
+		}()
+	}
+	wg.Wait()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, h := range m.health {
+		if h.Healthy {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MultiClient) healthLoop(ctx context.Context) {
+	defer close(m.done)
+	ticker := time.NewTicker(m.options.HealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.CheckHealth(ctx)
+		}
+	}
+}
+
+// candidates returns endpoint indexes in the order they should be tried:
+// caught-up healthy endpoints in round-robin order, then lagging healthy
+// endpoints, then unhealthy ones.
+func (m *MultiClient) candidates() []int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var best uint64
+	for _, h := range m.health {
+		if h.Healthy && h.CheckpointHeight > best {
+			best = h.CheckpointHeight
+		}
+	}
+	var current, lagging, unhealthy []int
+	for i, h := range m.health {
+		switch {
+		case !h.Healthy:
+			unhealthy = append(unhealthy, i)
+		case h.CheckpointHeight+m.options.MaxCheckpointLag >= best:
+			current = append(current, i)
+		default:
+			lagging = append(lagging, i)
+		}
+	}
+	if n := len(current); n > 1 {
+		offset := int(m.next.Add(1) % uint64(n))
+		current = append(current[offset:], current[:offset]...)
+	}
+	order := append(current, lagging...)
+	return append(order, unhealthy...)
+}
+
+// Client returns the client that should serve the next read.
+func (m *MultiClient) Client() *GRPCClient {
+	return m.clients[m.candidates()[0]]
+}
+
+// Pin returns the most caught-up healthy client, for a sequence of calls that
+// must observe each other's effects, such as executing a transaction and then
+// reading the objects it wrote.
+func (m *MultiClient) Pin() *GRPCClient {
+	m.mu.Lock()
+	best := -1
+	for i, h := range m.health {
+		if h.Healthy && (best < 0 || h.CheckpointHeight > m.health[best].CheckpointHeight) {
+			best = i
+		}
+	}
+	m.mu.Unlock()
+	if best < 0 {
+		return m.Client()
+	}
+	return m.clients[best]
+}
+
+// Do calls fn with the preferred client and, when it fails with Unavailable,
+// ResourceExhausted or a per-call DeadlineExceeded, marks that endpoint
+// unhealthy and retries with the next one. fn must be safe to repeat, so it
+// should not execute transactions.
+func (m *MultiClient) Do(ctx context.Context, fn func(*GRPCClient) error) error {
+	if ctx == nil {
+		return errors.New("nil context")
+	}
+	if fn == nil {
+		return errors.New("nil function")
+	}
+
+	return m.do(ctx, m.candidates(), fn)
+}
+
+// do calls fn with the clients at indexes in order until one succeeds or fails
+// with an error that does not warrant failover.
+func (m *MultiClient) do(ctx context.Context, indexes []int, fn func(*GRPCClient) error) error {
+	var errs []error
+	for _, i := range indexes {
+		err := fn(m.clients[i])
+		if err == nil || !m.shouldFailover(ctx, err) {
+			return err
+		}
+		m.markUnhealthy(i, err)
+		errs = append(errs, fmt.Errorf("%s: %w", m.clients[i].Endpoint(), err))
+	}
+	return errors.Join(errs...)
+}
+
+func (m *MultiClient) shouldFailover(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	switch grpcstatus.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// markUnhealthy takes an endpoint out of rotation until its next successful health check.
+func (m *MultiClient) markUnhealthy(i int, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.health[i].Healthy = false
+	m.health[i].Err = err
+}
+
+// ExecuteTransactionAndWait executes request and waits for its checkpoint on
+// a single pinned endpoint. It does not fail over, since the transaction may
+// already have been submitted.
+func (m *MultiClient) ExecuteTransactionAndWait(ctx context.Context, request *v2.ExecuteTransactionRequest, options *ExecuteAndWaitOptions) (*v2.ExecuteTransactionResponse, error) {
+	return m.Pin().ExecuteTransactionAndWait(ctx, request, options)
+}
+
+// ExecuteSignedTransactionAndWait is GRPCClient.ExecuteSignedTransactionAndWait on a single pinned endpoint.
+func (m *MultiClient) ExecuteSignedTransactionAndWait(ctx context.Context, req *ExecuteAndWaitRequest, options *ExecuteAndWaitOptions) (*v2.ExecutedTransaction, error) {
+	return m.Pin().ExecuteSignedTransactionAndWait(ctx, req, options)
+}
+
+// SignAndExecute is GRPCClient.SignAndExecute on a single pinned endpoint, so
+// gas selection, simulation, execution and the checkpoint wait all observe the
+// same node.
+func (m *MultiClient) SignAndExecute(ctx context.Context, signer keypair.Keypair, tx *v2.Transaction, options *SignAndExecuteOptions) (*v2.ExecutedTransaction, error) {
+	return m.Pin().SignAndExecute(ctx, signer, tx, options)
+}
@@ -0,0 +1,177 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// heightNode reports a checkpoint height and serves GetEpoch, optionally
+// failing it with Unavailable.
+type heightNode struct {
+	v2.UnimplementedLedgerServiceServer
+
+	mu     sync.Mutex
+	height uint64
+	down   bool
+	reads  int
+}
+
+func (n *heightNode) GetServiceInfo(context.Context, *v2.GetServiceInfoRequest) (*v2.GetServiceInfoResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.down {
+		return nil, status.Error(codes.Unavailable, "down")
+	}
+	height := n.height
+	return &v2.GetServiceInfoResponse{CheckpointHeight: &height}, nil
+}
+
+func (n *heightNode) GetEpoch(context.Context, *v2.GetEpochRequest) (*v2.GetEpochResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.reads++
+	if n.down {
+		return nil, status.Error(codes.Unavailable, "down")
+	}
+	return &v2.GetEpochResponse{}, nil
+}
+
+func (n *heightNode) set(height uint64, down bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.height, n.down = height, down
+}
+
+func (n *heightNode) readCount() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.reads
+}
+
+func newHeightNodes(t *testing.T, heights ...uint64) ([]*heightNode, []string) {
+	t.Helper()
+	var nodes []*heightNode
+	var endpoints []string
+	for _, height := range heights {
+		node := &heightNode{height: height}
+		client := newTestClient(t, func(s *grpc.Server) {
+			v2.RegisterLedgerServiceServer(s, node)
+		})
+		nodes = append(nodes, node)
+		endpoints = append(endpoints, client.Endpoint())
+	}
+	return nodes, endpoints
+}
+
+func newTestMultiClient(t *testing.T, endpoints []string) *MultiClient {
+	t.Helper()
+	m, err := NewMultiClient(context.Background(), endpoints, &MultiClientOptions{HealthCheckInterval: -1})
+	if err != nil {
+		t.Fatalf("multi client: %v", err)
+	}
+	t.Cleanup(func() { m.Close() })
+	return m
+}
+
+func readEpoch(ctx context.Context) func(*GRPCClient) error {
+	return func(c *GRPCClient) error {
+		_, err := c.LedgerClient().GetEpoch(ctx, &v2.GetEpochRequest{})
+		return err
+	}
+}
+
+func TestMultiClientRoutesToCaughtUpEndpoints(t *testing.T) {
+	nodes, endpoints := newHeightNodes(t, 100, 98, 50)
+	m := newTestMultiClient(t, endpoints)
+
+	for range 10 {
+		if err := m.Do(context.Background(), readEpoch(context.Background())); err != nil {
+			t.Fatalf("do: %v", err)
+		}
+	}
+	if nodes[0].readCount() == 0 || nodes[1].readCount() == 0 {
+		t.Fatalf("reads not balanced: %d, %d", nodes[0].readCount(), nodes[1].readCount())
+	}
+	if nodes[2].readCount() != 0 {
+		t.Fatalf("lagging endpoint served %d reads", nodes[2].readCount())
+	}
+	if pinned := m.Pin(); pinned.Endpoint() != endpoints[0] {
+		t.Fatalf("pinned %s, want most caught-up %s", pinned.Endpoint(), endpoints[0])
+	}
+}
+
+func TestMultiClientFailsOver(t *testing.T) {
+	nodes, endpoints := newHeightNodes(t, 100, 100)
+	m := newTestMultiClient(t, endpoints)
+	nodes[0].set(100, true)
+
+	for range 4 {
+		if err := m.Do(context.Background(), readEpoch(context.Background())); err != nil {
+			t.Fatalf("do: %v", err)
+		}
+	}
+	if got := nodes[0].readCount(); got > 1 {
+		t.Fatalf("failed endpoint tried %d times", got)
+	}
+	health := m.Health()
+	if health[0].Healthy || !health[1].Healthy {
+		t.Fatalf("health %+v", health)
+	}
+
+	nodes[1].set(100, true)
+	if err := m.Do(context.Background(), readEpoch(context.Background())); err == nil {
+		t.Fatalf("expected error with every endpoint down")
+	}
+	if m.CheckHealth(context.Background()) {
+		t.Fatalf("CheckHealth reported a healthy endpoint")
+	}
+
+	nodes[0].set(101, false)
+	if !m.CheckHealth(context.Background()) || m.Client().Endpoint() != endpoints[0] {
+		t.Fatalf("recovered endpoint not used: %+v", m.Health())
+	}
+}
+
+func TestMultiClientDoesNotFailOverPermanentErrors(t *testing.T) {
+	_, endpoints := newHeightNodes(t, 1, 1)
+	m := newTestMultiClient(t, endpoints)
+
+	calls := 0
+	err := m.Do(context.Background(), func(*GRPCClient) error {
+		calls++
+		return status.Error(codes.NotFound, "missing")
+	})
+	if status.Code(err) != codes.NotFound || calls != 1 {
+		t.Fatalf("err %v after %d calls", err, calls)
+	}
+}
+
+func TestMultiClientBackgroundHealthChecks(t *testing.T) {
+	nodes, endpoints := newHeightNodes(t, 10, 20)
+	m, err := NewMultiClient(context.Background(), endpoints, &MultiClientOptions{HealthCheckInterval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("multi client: %v", err)
+	}
+	defer m.Close()
+
+	nodes[0].set(30, false)
+	deadline := time.Now().Add(5 * time.Second)
+	for m.Pin().Endpoint() != endpoints[0] {
+		if time.Now().After(deadline) {
+			t.Fatalf("health check did not observe new height: %+v", m.Health())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if _, err := NewMultiClient(context.Background(), nil, nil); !errors.Is(err, ErrNoEndpoints) {
+		t.Fatalf("expected ErrNoEndpoints, got %v", err)
+	}
+}
@@ -15,12 +15,42 @@ type config struct {
 	dialOptions          []grpc.DialOption
 	transportCredentials credentials.TransportCredentials
 	tlsConfig            *tls.Config
+	retrier              *retrier
+	rateLimiter          *RateLimiter
+	telemetry            *telemetry
+	abortCodes           *AbortCodes
 }
 
 func defaultConfig() *config {
 	return &config{}
 }
 
+// interceptorOptions installs the telemetry, retry and rate limiting
+// interceptors in that order, so that a span covers every attempt of a call
+// and each attempt is rate limited.
+func (cfg *config) interceptorOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+	if cfg.telemetry != nil {
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(cfg.telemetry.unary),
+			grpc.WithChainStreamInterceptor(cfg.telemetry.stream),
+		)
+	}
+	if cfg.retrier != nil {
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(cfg.retrier.unary),
+			grpc.WithChainStreamInterceptor(cfg.retrier.stream),
+		)
+	}
+	if cfg.rateLimiter != nil {
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(cfg.rateLimiter.unary),
+			grpc.WithChainStreamInterceptor(cfg.rateLimiter.stream),
+		)
+	}
+	return opts
+}
+
 // WithDialOption appends a raw grpc.DialOption to the client configuration.
 func WithDialOption(opt grpc.DialOption) Option {
 	return func(cfg *config) {
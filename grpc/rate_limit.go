@@ -0,0 +1,201 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+// Rate is a token bucket: PerSecond tokens are added each second up to Burst.
+// A zero PerSecond is unlimited.
+type Rate struct {
+	PerSecond float64
+	// Burst is the bucket size. Zero uses one second's worth of tokens, at least one.
+	Burst int
+}
+
+// RateLimit configures a RateLimiter.
+type RateLimit struct {
+	// Default limits every call without a matching entry in Methods. All
+	// such calls share one bucket.
+	Default Rate
+	// Methods gives a full method name ("/sui.rpc.v2.LedgerService/GetObject")
+	// or a service ("/sui.rpc.v2.LedgerService") its own bucket. A method
+	// entry takes precedence over its service's entry.
+	Methods map[string]Rate
+	// MaxInFlight bounds the number of concurrent unary calls. Zero is
+	// unlimited. Streams are rate limited when opened but do not hold a slot.
+	MaxInFlight int
+}
+
+// RateLimitStats reports how long calls waited for a RateLimiter.
+type RateLimitStats struct {
+	// Calls counts calls admitted by the limiter.
+	Calls uint64
+	// Delayed counts calls that had to wait for a token or an in-flight slot.
+	Delayed uint64
+	// TotalWait and MaxWait summarize the time calls spent waiting.
+	TotalWait time.Duration
+	MaxWait   time.Duration
+	// InFlight is the number of unary calls currently holding a slot.
+	InFlight int
+}
+
+// RateLimiter throttles the calls of every client it is installed on with
+// WithRateLimit, including the calls made by pagers and batch helpers.
+type RateLimiter struct {
+	defaultBucket *tokenBucket
+	buckets       map[string]*tokenBucket
+	slots         chan struct{}
+
+	mu    sync.Mutex
+	stats RateLimitStats
+}
+
+// NewRateLimiter returns a RateLimiter enforcing limit.
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	l := &RateLimiter{
+		defaultBucket: newTokenBucket(limit.Default),
+		buckets:       make(map[string]*tokenBucket, len(limit.Methods)),
+	}
+	for key, rate := range limit.Methods {
+		l.buckets[key] = newTokenBucket(rate)
+	}
+	if limit.MaxInFlight > 0 {
+		l.slots = make(chan struct{}, limit.MaxInFlight)
+	}
+	return l
+}
+
+// WithRateLimit throttles the client's calls with limiter. A limiter may be
+// shared by several clients to enforce a combined limit. Retries made by
+// WithRetryPolicy are limited individually.
+func WithRateLimit(limiter *RateLimiter) Option {
+	return func(cfg *config) {
+		cfg.rateLimiter = limiter
+	}
+}
+
+// Stats returns a snapshot of the limiter's wait-time metrics.
+func (l *RateLimiter) Stats() RateLimitStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	stats := l.stats
+	stats.InFlight = len(l.slots)
+	return stats
+}
+
+func (l *RateLimiter) bucket(method string) *tokenBucket {
+	if b, ok := l.buckets[method]; ok {
+		return b
+	}
+	if i := strings.LastIndexByte(method, '/'); i > 0 {
+		if b, ok := l.buckets[method[:i]]; ok {
+			return b
+		}
+	}
+	return l.defaultBucket
+}
+
+// acquire waits for a token for method and, when hold is set, an in-flight
+// slot. The returned release function frees the slot.
+func (l *RateLimiter) acquire(ctx context.Context, method string, hold bool) (func(), error) {
+	started := time.Now()
+	if err := l.bucket(method).wait(ctx); err != nil {
+		return nil, grpcstatus.FromContextError(err).Err()
+	}
+	release := func() {}
+	if hold && l.slots != nil {
+		select {
+		case l.slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, grpcstatus.FromContextError(ctx.Err()).Err()
+		}
+		release = func() { <-l.slots }
+	}
+
+	waited := time.Since(started)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stats.Calls++
+	// Ignore scheduling noise when deciding whether a call was delayed.
+	if waited > time.Millisecond {
+		l.stats.Delayed++
+		l.stats.TotalWait += waited
+		if waited > l.stats.MaxWait {
+			l.stats.MaxWait = waited
+		}
+	}
+	return release, nil
+}
+
+func (l *RateLimiter) unary(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	release, err := l.acquire(ctx, method, true)
+	if err != nil {
+		return err
+	}
+	defer release()
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+func (l *RateLimiter) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if _, err := l.acquire(ctx, method, false); err != nil {
+		return nil, err
+	}
+	return streamer(ctx, desc, cc, method, opts...)
+}
+
+// tokenBucket hands out tokens at a fixed rate. Tokens may go negative so
+// that waiters are served in order of arrival.
+type tokenBucket struct {
+	perSecond float64
+	burst     float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate Rate) *tokenBucket {
+	if rate.PerSecond <= 0 {
+		return nil
+	}
+	burst := float64(rate.Burst)
+	if burst <= 0 {
+		burst = max(rate.PerSecond, 1)
+	}
+	return &tokenBucket{perSecond: rate.PerSecond, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait takes a token, sleeping until it is available or ctx is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.perSecond)
+	b.last = now
+	b.tokens--
+	delay := time.Duration(-b.tokens / b.perSecond * float64(time.Second))
+	b.mu.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	}
+}
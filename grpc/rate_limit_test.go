@@ -0,0 +1,91 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newRateLimitedClient(t *testing.T, limiter *RateLimiter) (*flakyNode, *GRPCClient) {
+	t.Helper()
+	node := &flakyNode{}
+	client := newTestClient(t, func(s *grpc.Server) {
+		v2.RegisterLedgerServiceServer(s, node)
+		v2.RegisterTransactionExecutionServiceServer(s, node)
+	}, WithRateLimit(limiter))
+	return node, client
+}
+
+func TestRateLimiterThrottlesCalls(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{Default: Rate{PerSecond: 20, Burst: 1}})
+	_, client := newRateLimitedClient(t, limiter)
+
+	started := time.Now()
+	for range 5 {
+		if _, err := client.GetServiceInfo(context.Background()); err != nil {
+			t.Fatalf("get service info: %v", err)
+		}
+	}
+	if elapsed := time.Since(started); elapsed < 150*time.Millisecond {
+		t.Fatalf("5 calls at 20/s took only %v", elapsed)
+	}
+	stats := limiter.Stats()
+	if stats.Calls != 5 || stats.Delayed < 3 || stats.TotalWait <= 0 || stats.MaxWait > stats.TotalWait {
+		t.Fatalf("stats %+v", stats)
+	}
+}
+
+func TestRateLimiterUsesMethodBuckets(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{Methods: map[string]Rate{
+		"/sui.rpc.v2.LedgerService": {PerSecond: 0.5, Burst: 1},
+	}})
+	_, client := newRateLimitedClient(t, limiter)
+
+	if _, err := client.GetServiceInfo(context.Background()); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.GetServiceInfo(ctx); status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded while throttled, got %v", err)
+	}
+	// Other services use the unlimited default bucket.
+	for range 3 {
+		if _, err := client.TransactionExecutionClient().ExecuteTransaction(context.Background(), &v2.ExecuteTransactionRequest{}); err != nil {
+			t.Fatalf("execute: %v", err)
+		}
+	}
+}
+
+func TestRateLimiterBoundsInFlightCalls(t *testing.T) {
+	store := &fakeCheckpointStore{}
+	limiter := NewRateLimiter(RateLimit{MaxInFlight: 2})
+	client := newTestClient(t, func(s *grpc.Server) {
+		v2.RegisterLedgerServiceServer(s, store)
+	}, WithRateLimit(limiter))
+
+	var wg sync.WaitGroup
+	for seq := range uint64(6) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetCheckpointBySequence(context.Background(), seq, nil); err != nil {
+				t.Errorf("get checkpoint %d: %v", seq, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, peak := store.stats(); peak > 2 {
+		t.Fatalf("peak concurrency %d, want at most 2", peak)
+	}
+	if stats := limiter.Stats(); stats.Calls != 6 || stats.InFlight != 0 {
+		t.Fatalf("stats %+v", stats)
+	}
+}
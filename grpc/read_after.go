@@ -0,0 +1,106 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/grpc"
+)
+
+const (
+	readAfterInitialPoll = 50 * time.Millisecond
+	readAfterMaxPoll     = time.Second
+)
+
+// ErrCheckpointNotReached indicates no endpoint reached the required
+// checkpoint before the context was done.
+var ErrCheckpointNotReached = errors.New("checkpoint not reached")
+
+// WaitForCheckpoint polls GetServiceInfo until the node's CheckpointHeight is
+// at least checkpoint, so that subsequent reads observe every transaction up
+// to it. Bound the wait with a context deadline.
+func (c *GRPCClient) WaitForCheckpoint(ctx context.Context, checkpoint uint64, opts ...grpc.CallOption) (*v2.GetServiceInfoResponse, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+
+	poll := newBackoff(readAfterInitialPoll, readAfterMaxPoll)
+	for {
+		info, err := c.GetServiceInfo(ctx, opts...)
+		if err == nil && info.GetCheckpointHeight() >= checkpoint {
+			return info, nil
+		}
+		if waitErr := poll.wait(ctx); waitErr != nil {
+			if err != nil {
+				return nil, fmt.Errorf("%w: checkpoint %d: %w", ErrCheckpointNotReached, checkpoint, err)
+			}
+			return nil, fmt.Errorf("%w: checkpoint %d, height %d: %w", ErrCheckpointNotReached, checkpoint, info.GetCheckpointHeight(), waitErr)
+		}
+	}
+}
+
+// ReadAfter returns a client whose CheckpointHeight is at least checkpoint,
+// such as the checkpoint of a transaction this process executed, refreshing
+// endpoint health until one catches up or ctx is done.
+func (m *MultiClient) ReadAfter(ctx context.Context, checkpoint uint64) (*GRPCClient, error) {
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	indexes, err := m.waitCaughtUp(ctx, checkpoint)
+	if err != nil {
+		return nil, err
+	}
+	return m.clients[indexes[0]], nil
+}
+
+// DoAfter is like Do but only uses endpoints whose CheckpointHeight is at
+// least checkpoint, waiting for one to catch up as ReadAfter does.
+func (m *MultiClient) DoAfter(ctx context.Context, checkpoint uint64, fn func(*GRPCClient) error) error {
+	if ctx == nil {
+		return errors.New("nil context")
+	}
+	if fn == nil {
+		return errors.New("nil function")
+	}
+	indexes, err := m.waitCaughtUp(ctx, checkpoint)
+	if err != nil {
+		return err
+	}
+	return m.do(ctx, indexes, fn)
+}
+
+// waitCaughtUp returns the preferred endpoints at or beyond checkpoint.
+func (m *MultiClient) waitCaughtUp(ctx context.Context, checkpoint uint64) ([]int, error) {
+	poll := newBackoff(readAfterInitialPoll, readAfterMaxPoll)
+	for {
+		if indexes := m.caughtUp(checkpoint); len(indexes) > 0 {
+			return indexes, nil
+		}
+		m.CheckHealth(ctx)
+		if indexes := m.caughtUp(checkpoint); len(indexes) > 0 {
+			return indexes, nil
+		}
+		if err := poll.wait(ctx); err != nil {
+			return nil, fmt.Errorf("%w: checkpoint %d: %w", ErrCheckpointNotReached, checkpoint, err)
+		}
+	}
+}
+
+func (m *MultiClient) caughtUp(checkpoint uint64) []int {
+	candidates := m.candidates()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var indexes []int
+	for _, i := range candidates {
+		if h := m.health[i]; h.Healthy && h.CheckpointHeight >= checkpoint {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
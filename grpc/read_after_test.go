@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMultiClientReadAfterWaitsForCheckpoint(t *testing.T) {
+	nodes, endpoints := newHeightNodes(t, 90, 90)
+	m := newTestMultiClient(t, endpoints)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		nodes[1].set(100, false)
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := m.ReadAfter(ctx, 95)
+	if err != nil {
+		t.Fatalf("read after: %v", err)
+	}
+	if client.Endpoint() != endpoints[1] {
+		t.Fatalf("read from %s, want caught-up %s", client.Endpoint(), endpoints[1])
+	}
+
+	for range 4 {
+		if err := m.DoAfter(ctx, 95, readEpoch(ctx)); err != nil {
+			t.Fatalf("do after: %v", err)
+		}
+	}
+	if nodes[0].readCount() != 0 || nodes[1].readCount() != 4 {
+		t.Fatalf("reads %d/%d, want only the caught-up endpoint", nodes[0].readCount(), nodes[1].readCount())
+	}
+}
+
+func TestMultiClientReadAfterTimesOut(t *testing.T) {
+	_, endpoints := newHeightNodes(t, 10)
+	m := newTestMultiClient(t, endpoints)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := m.ReadAfter(ctx, 1000); !errors.Is(err, ErrCheckpointNotReached) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected ErrCheckpointNotReached, got %v", err)
+	}
+	if health := m.Health(); !health[0].Healthy {
+		t.Fatalf("timed-out wait marked endpoint unhealthy: %+v", health[0])
+	}
+}
+
+func TestWaitForCheckpoint(t *testing.T) {
+	nodes, endpoints := newHeightNodes(t, 5)
+	m := newTestMultiClient(t, endpoints)
+	client := m.Clients()[0]
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		nodes[0].set(7, false)
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	info, err := client.WaitForCheckpoint(ctx, 7)
+	if err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	if info.GetCheckpointHeight() != 7 {
+		t.Fatalf("height %d", info.GetCheckpointHeight())
+	}
+}
@@ -0,0 +1,350 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 5 * time.Second
+)
+
+// DefaultRetryableCodes are the status codes retried when RetryPolicy.RetryableCodes is empty.
+var DefaultRetryableCodes = []codes.Code{codes.Unavailable, codes.ResourceExhausted}
+
+// RetryPolicy controls how failed calls are retried. Zero fields use the
+// defaults: 3 attempts, 100ms initial and 5s maximum backoff, and
+// DefaultRetryableCodes.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts per call, including the
+	// first. One disables retries.
+	MaxAttempts int
+	// InitialBackoff and MaxBackoff bound the jittered exponential delay
+	// between attempts.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// RetryableCodes lists the status codes that are retried.
+	RetryableCodes []codes.Code
+	// HedgeDelay, when positive, sends another attempt of a unary read if
+	// the previous one has not completed after HedgeDelay, returning the
+	// first success. Attempts count towards MaxAttempts.
+	HedgeDelay time.Duration
+	// Methods overrides the policy per full method name
+	// ("/sui.rpc.v2.LedgerService/GetObject") or per service
+	// ("/sui.rpc.v2.LedgerService"). Zero fields of an override inherit from
+	// the enclosing policy; nested Methods are ignored.
+	Methods map[string]RetryPolicy
+}
+
+// WithRetryPolicy retries unary calls, and server streams that fail before
+// their first message, according to policy.
+//
+// ExecuteTransaction is never hedged and is only retried when called with
+// IdempotentExecution, since a retried submission of a transaction that was
+// in fact executed can fail with a misleading error, e.g. an object version
+// conflict.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(cfg *config) {
+		cfg.retrier = &retrier{policy: policy}
+	}
+}
+
+// IdempotentExecution marks an ExecuteTransaction call as safe to retry, for
+// example because the signed transaction's digest is known and a duplicate
+// submission returns the same effects.
+func IdempotentExecution() grpc.CallOption {
+	return idempotentCallOption{}
+}
+
+type idempotentCallOption struct {
+	grpc.EmptyCallOption
+}
+
+// nonIdempotentMethods are never retried unless marked with IdempotentExecution.
+var nonIdempotentMethods = map[string]bool{
+	v2.TransactionExecutionService_ExecuteTransaction_FullMethodName: true,
+}
+
+type retrier struct {
+	policy RetryPolicy
+}
+
+// resolvedRetryPolicy is a RetryPolicy with overrides and defaults applied.
+type resolvedRetryPolicy struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	retryable      []codes.Code
+	hedgeDelay     time.Duration
+}
+
+func (r *retrier) resolve(method string, opts []grpc.CallOption) resolvedRetryPolicy {
+	policy := r.policy
+	if i := strings.LastIndexByte(method, '/'); i > 0 {
+		if override, ok := r.policy.Methods[method[:i]]; ok {
+			policy = mergeRetryPolicy(policy, override)
+		}
+	}
+	if override, ok := r.policy.Methods[method]; ok {
+		policy = mergeRetryPolicy(policy, override)
+	}
+
+	resolved := resolvedRetryPolicy{
+		maxAttempts:    policy.MaxAttempts,
+		initialBackoff: policy.InitialBackoff,
+		maxBackoff:     policy.MaxBackoff,
+		retryable:      policy.RetryableCodes,
+		hedgeDelay:     policy.HedgeDelay,
+	}
+	if resolved.maxAttempts <= 0 {
+		resolved.maxAttempts = defaultRetryMaxAttempts
+	}
+	if resolved.initialBackoff <= 0 {
+		resolved.initialBackoff = defaultRetryInitialBackoff
+	}
+	if resolved.maxBackoff <= 0 {
+		resolved.maxBackoff = defaultRetryMaxBackoff
+	}
+	if len(resolved.retryable) == 0 {
+		resolved.retryable = DefaultRetryableCodes
+	}
+	if nonIdempotentMethods[method] {
+		resolved.hedgeDelay = 0
+		if !hasIdempotentOption(opts) {
+			resolved.maxAttempts = 1
+		}
+	}
+	return resolved
+}
+
+func mergeRetryPolicy(base, override RetryPolicy) RetryPolicy {
+	if override.MaxAttempts > 0 {
+		base.MaxAttempts = override.MaxAttempts
+	}
+	if override.InitialBackoff > 0 {
+		base.InitialBackoff = override.InitialBackoff
+	}
+	if override.MaxBackoff > 0 {
+		base.MaxBackoff = override.MaxBackoff
+	}
+	if len(override.RetryableCodes) > 0 {
+		base.RetryableCodes = override.RetryableCodes
+	}
+	if override.HedgeDelay > 0 {
+		base.HedgeDelay = override.HedgeDelay
+	}
+	return base
+}
+
+func hasIdempotentOption(opts []grpc.CallOption) bool {
+	for _, opt := range opts {
+		if _, ok := opt.(idempotentCallOption); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (p resolvedRetryPolicy) shouldRetry(err error) bool {
+	return slices.Contains(p.retryable, grpcstatus.Code(err))
+}
+
+func (r *retrier) unary(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	policy := r.resolve(method, opts)
+	if policy.maxAttempts <= 1 {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	if msg, ok := reply.(proto.Message); ok && policy.hedgeDelay > 0 {
+		return policy.hedge(ctx, method, req, msg, cc, invoker, opts)
+	}
+
+	retry := newBackoff(policy.initialBackoff, policy.maxBackoff)
+	for attempt := 1; ; attempt++ {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil || attempt >= policy.maxAttempts || !policy.shouldRetry(err) {
+			return err
+		}
+		if retry.wait(ctx) != nil {
+			return err
+		}
+	}
+}
+
+type hedgeResult struct {
+	reply proto.Message
+	err   error
+}
+
+// hedge races attempts started HedgeDelay apart, or after a backoff when all
+// outstanding attempts failed, and returns the first success.
+func (p resolvedRetryPolicy) hedge(ctx context.Context, method string, req any, reply proto.Message, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts []grpc.CallOption) error {
+	hedgeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgeResult, p.maxAttempts)
+	launched, pending := 0, 0
+	launch := func() {
+		launched++
+		pending++
+		attemptReply := reply.ProtoReflect().New().Interface()
+		go func() {
+			err := invoker(hedgeCtx, method, req, attemptReply, cc, opts...)
+			results <- hedgeResult{reply: attemptReply, err: err}
+		}()
+	}
+
+	retry := newBackoff(p.initialBackoff, p.maxBackoff)
+	timer := time.NewTimer(p.hedgeDelay)
+	defer timer.Stop()
+	launch()
+
+	var lastErr error
+	for {
+		select {
+		case res := <-results:
+			pending--
+			if res.err == nil {
+				proto.Reset(reply)
+				proto.Merge(reply, res.reply)
+				return nil
+			}
+			lastErr = res.err
+			if !p.shouldRetry(res.err) || launched >= p.maxAttempts && pending == 0 {
+				return res.err
+			}
+			if pending == 0 {
+				timer.Reset(retry.delay())
+			}
+		case <-timer.C:
+			if launched < p.maxAttempts {
+				launch()
+				timer.Reset(p.hedgeDelay)
+			}
+		case <-ctx.Done():
+			if lastErr != nil {
+				return lastErr
+			}
+			return grpcstatus.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
+
+func (r *retrier) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	policy := r.resolve(method, opts)
+	if policy.maxAttempts <= 1 || desc.ClientStreams || !desc.ServerStreams {
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+
+	s := &retryingStream{ctx: ctx, desc: desc, cc: cc, method: method, streamer: streamer, opts: opts, policy: policy}
+	retry := newBackoff(policy.initialBackoff, policy.maxBackoff)
+	for attempt := 1; ; attempt++ {
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err == nil {
+			s.ClientStream = stream
+			s.attempts = attempt
+			return s, nil
+		}
+		if attempt >= policy.maxAttempts || !policy.shouldRetry(err) || retry.wait(ctx) != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryingStream re-opens a server stream and replays the request when it
+// fails before delivering its first message.
+type retryingStream struct {
+	grpc.ClientStream
+
+	ctx      context.Context
+	desc     *grpc.StreamDesc
+	cc       *grpc.ClientConn
+	method   string
+	streamer grpc.Streamer
+	opts     []grpc.CallOption
+	policy   resolvedRetryPolicy
+
+	mu        sync.Mutex
+	request   any
+	closeSent bool
+	received  bool
+	attempts  int
+}
+
+func (s *retryingStream) SendMsg(m any) error {
+	s.mu.Lock()
+	s.request = m
+	s.mu.Unlock()
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *retryingStream) CloseSend() error {
+	s.mu.Lock()
+	s.closeSent = true
+	s.mu.Unlock()
+	return s.ClientStream.CloseSend()
+}
+
+func (s *retryingStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		s.received = true
+		return nil
+	}
+	if s.received || errors.Is(err, io.EOF) {
+		return err
+	}
+
+	retry := newBackoff(s.policy.initialBackoff, s.policy.maxBackoff)
+	for s.attempts < s.policy.maxAttempts && s.policy.shouldRetry(err) {
+		if retry.wait(s.ctx) != nil {
+			return err
+		}
+		s.attempts++
+		if err = s.reopen(); err != nil {
+			continue
+		}
+		if err = s.ClientStream.RecvMsg(m); err == nil {
+			s.received = true
+			return nil
+		}
+		if errors.Is(err, io.EOF) {
+			return err
+		}
+	}
+	return err
+}
+
+func (s *retryingStream) reopen() error {
+	stream, err := s.streamer(s.ctx, s.desc, s.cc, s.method, s.opts...)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	request, closeSent := s.request, s.closeSent
+	s.mu.Unlock()
+	if request != nil {
+		if err := stream.SendMsg(request); err != nil {
+			return err
+		}
+	}
+	if closeSent {
+		if err := stream.CloseSend(); err != nil {
+			return err
+		}
+	}
+	s.ClientStream = stream
+	return nil
+}
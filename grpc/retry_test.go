@@ -0,0 +1,180 @@
+package grpc
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// flakyNode fails the first failures calls of each method with code and
+// delays the first call by firstDelay.
+type flakyNode struct {
+	v2.UnimplementedLedgerServiceServer
+	v2.UnimplementedSubscriptionServiceServer
+	v2.UnimplementedTransactionExecutionServiceServer
+
+	failures   int
+	code       codes.Code
+	firstDelay time.Duration
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (n *flakyNode) call(ctx context.Context, method string) error {
+	n.mu.Lock()
+	if n.calls == nil {
+		n.calls = make(map[string]int)
+	}
+	n.calls[method]++
+	call := n.calls[method]
+	n.mu.Unlock()
+
+	if call == 1 && n.firstDelay > 0 {
+		select {
+		case <-time.After(n.firstDelay):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	if call <= n.failures {
+		return status.Error(n.code, "flaky")
+	}
+	return nil
+}
+
+func (n *flakyNode) callCount(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.calls[method]
+}
+
+func (n *flakyNode) GetServiceInfo(ctx context.Context, _ *v2.GetServiceInfoRequest) (*v2.GetServiceInfoResponse, error) {
+	if err := n.call(ctx, "GetServiceInfo"); err != nil {
+		return nil, err
+	}
+	chainID := "test"
+	return &v2.GetServiceInfoResponse{ChainId: &chainID}, nil
+}
+
+func (n *flakyNode) ExecuteTransaction(ctx context.Context, _ *v2.ExecuteTransactionRequest) (*v2.ExecuteTransactionResponse, error) {
+	if err := n.call(ctx, "ExecuteTransaction"); err != nil {
+		return nil, err
+	}
+	return &v2.ExecuteTransactionResponse{}, nil
+}
+
+func (n *flakyNode) SubscribeCheckpoints(_ *v2.SubscribeCheckpointsRequest, stream grpc.ServerStreamingServer[v2.SubscribeCheckpointsResponse]) error {
+	if err := n.call(stream.Context(), "SubscribeCheckpoints"); err != nil {
+		return err
+	}
+	cursor := uint64(7)
+	return stream.Send(&v2.SubscribeCheckpointsResponse{Cursor: &cursor})
+}
+
+func newFlakyClient(t *testing.T, node *flakyNode, policy RetryPolicy) *GRPCClient {
+	t.Helper()
+	if policy.InitialBackoff == 0 {
+		policy.InitialBackoff = time.Millisecond
+	}
+	return newTestClient(t, func(s *grpc.Server) {
+		v2.RegisterLedgerServiceServer(s, node)
+		v2.RegisterSubscriptionServiceServer(s, node)
+		v2.RegisterTransactionExecutionServiceServer(s, node)
+	}, WithRetryPolicy(policy))
+}
+
+func TestRetryPolicyRetriesTransientErrors(t *testing.T) {
+	node := &flakyNode{failures: 2, code: codes.Unavailable}
+	client := newFlakyClient(t, node, RetryPolicy{})
+
+	info, err := client.GetServiceInfo(context.Background())
+	if err != nil {
+		t.Fatalf("get service info: %v", err)
+	}
+	if info.GetChainId() != "test" || node.callCount("GetServiceInfo") != 3 {
+		t.Fatalf("chain %q after %d calls", info.GetChainId(), node.callCount("GetServiceInfo"))
+	}
+}
+
+func TestRetryPolicyStopsOnPermanentErrorsAndOverrides(t *testing.T) {
+	node := &flakyNode{failures: 5, code: codes.NotFound}
+	client := newFlakyClient(t, node, RetryPolicy{})
+	if _, err := client.GetServiceInfo(context.Background()); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+	if calls := node.callCount("GetServiceInfo"); calls != 1 {
+		t.Fatalf("permanent error retried: %d calls", calls)
+	}
+
+	node = &flakyNode{failures: 5, code: codes.Unavailable}
+	client = newFlakyClient(t, node, RetryPolicy{
+		MaxAttempts: 4,
+		Methods: map[string]RetryPolicy{
+			"/sui.rpc.v2.LedgerService":                    {MaxAttempts: 3},
+			v2.LedgerService_GetServiceInfo_FullMethodName: {MaxAttempts: 2},
+		},
+	})
+	if _, err := client.GetServiceInfo(context.Background()); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable, got %v", err)
+	}
+	if calls := node.callCount("GetServiceInfo"); calls != 2 {
+		t.Fatalf("method override ignored: %d calls", calls)
+	}
+}
+
+func TestRetryPolicyExcludesExecuteUnlessIdempotent(t *testing.T) {
+	node := &flakyNode{failures: 1, code: codes.Unavailable}
+	client := newFlakyClient(t, node, RetryPolicy{HedgeDelay: time.Millisecond})
+
+	if _, err := client.TransactionExecutionClient().ExecuteTransaction(context.Background(), &v2.ExecuteTransactionRequest{}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable, got %v", err)
+	}
+	if _, err := client.TransactionExecutionClient().ExecuteTransaction(context.Background(), &v2.ExecuteTransactionRequest{}, IdempotentExecution()); err != nil {
+		t.Fatalf("idempotent execute: %v", err)
+	}
+	if calls := node.callCount("ExecuteTransaction"); calls != 2 {
+		t.Fatalf("execute called %d times, want 2", calls)
+	}
+}
+
+func TestRetryPolicyHedgesSlowReads(t *testing.T) {
+	node := &flakyNode{firstDelay: 2 * time.Second}
+	client := newFlakyClient(t, node, RetryPolicy{HedgeDelay: 20 * time.Millisecond})
+
+	started := time.Now()
+	if _, err := client.GetServiceInfo(context.Background()); err != nil {
+		t.Fatalf("get service info: %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > time.Second {
+		t.Fatalf("hedged call took %v", elapsed)
+	}
+	if calls := node.callCount("GetServiceInfo"); calls != 2 {
+		t.Fatalf("GetServiceInfo called %d times, want 2", calls)
+	}
+}
+
+func TestRetryPolicyReopensStreamBeforeFirstMessage(t *testing.T) {
+	node := &flakyNode{failures: 1, code: codes.Unavailable}
+	client := newFlakyClient(t, node, RetryPolicy{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.SubscriptionClient().SubscribeCheckpoints(ctx, &v2.SubscribeCheckpointsRequest{})
+	if err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	msg, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("recv: %v", err)
+	}
+	if msg.GetCursor() != 7 || node.callCount("SubscribeCheckpoints") != 2 {
+		t.Fatalf("cursor %d after %d subscriptions", msg.GetCursor(), node.callCount("SubscribeCheckpoints"))
+	}
+}
@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+// newTestClient serves the services installed by register on a loopback
+// listener and returns a client connected to it.
+func newTestClient(t *testing.T, register func(*grpc.Server), opts ...Option) *GRPCClient {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := grpc.NewServer()
+	register(srv)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+
+	client, err := NewClient(context.Background(), lis.Addr().String(), opts...)
+	if err != nil {
+		t.Fatalf("new client: %v", err)
+	}
+	t.Cleanup(func() {
+		client.Close()
+	})
+	return client
+}
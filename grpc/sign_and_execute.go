@@ -0,0 +1,332 @@
+package grpc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/0xdraco/sui-go-sdk/keypair"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/0xdraco/sui-go-sdk/transaction"
+	"github.com/0xdraco/sui-go-sdk/types"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// SuiCoinType is the fully-qualified type of the native SUI coin used to pay for gas.
+const SuiCoinType = "0x2::sui::SUI"
+
+// ErrSignerMismatch indicates the signer cannot authorize the transaction because it is not the sender.
+var ErrSignerMismatch = errors.New("signer address does not match transaction sender")
+
+// SignAndExecuteOptions customises SignAndExecute.
+type SignAndExecuteOptions struct {
+	// DryRun simulates the fully prepared transaction before signing and
+	// aborts without submitting it if the simulation fails.
+	DryRun bool
+	// ReadMask selects the ExecutedTransaction fields returned on success.
+	ReadMask *fieldmaskpb.FieldMask
+	// GasBudget configures the budget estimate used when the transaction has no budget.
+	GasBudget *GasBudgetOptions
+	// CoinSelection customises how gas coins are picked when the transaction has a gas
+	// budget but no gas payment. Without a budget the coins chosen by the fullnode while
+	// estimating it are used instead.
+	CoinSelection []CoinSelectionOption
+	// ExecuteOptions configures the checkpoint wait once the transaction is submitted.
+	ExecuteOptions *ExecuteAndWaitOptions
+}
+
+// TransactionFailedError reports a transaction whose effects indicate that execution failed,
+// either on chain or during a simulation such as SimulateTransaction or the dry run performed
+// by SignAndExecute.
+type TransactionFailedError struct {
+	// Transaction is the executed (or simulated) transaction, including its effects.
+	Transaction *v2.ExecutedTransaction
+	// Status is the execution status reported in the effects.
+	Status *v2.ExecutionStatus
+	// DryRun is true when the failure was observed during simulation and nothing was submitted.
+	DryRun bool
+	// Failure is the decoded execution error, or nil when the effects carry none.
+	Failure *ExecutionFailure
+}
+
+func (e *TransactionFailedError) Error() string {
+	if e == nil {
+		return "<nil>"
+	}
+	what := "transaction"
+	if e.DryRun {
+		what = "dry run"
+	} else if digest := e.Transaction.GetDigest(); digest != "" {
+		what = fmt.Sprintf("transaction %s", digest)
+	}
+	failure := e.Failure
+	if failure == nil {
+		failure = DecodeExecutionError(e.Status.GetError(), nil)
+	}
+	if failure == nil {
+		return what + " failed"
+	}
+	if failure.Command != nil {
+		return fmt.Sprintf("%s failed in %s", what, failure)
+	}
+	return fmt.Sprintf("%s failed: %s", what, failure)
+}
+
+// ExecutionError returns the structured execution error reported in the effects, if any.
+func (e *TransactionFailedError) ExecutionError() *v2.ExecutionError {
+	if e == nil {
+		return nil
+	}
+	return e.Status.GetError()
+}
+
+// Unwrap returns the decoded execution error, so errors.As finds an *ExecutionFailure.
+func (e *TransactionFailedError) Unwrap() error {
+	if e == nil || e.Failure == nil {
+		return nil
+	}
+	return e.Failure
+}
+
+// SignAndExecute fills in any missing sender, gas price, gas budget and gas payment on tx,
+// signs it with signer and waits for it to be included in a checkpoint.
+//
+// The sender defaults to the signer's address, the gas price to the reference gas price,
+// the budget to EstimateGasBudget's result and the gas payment to the SUI coins the fullnode
+// selected while estimating it. With an explicit budget the gas payment is selected from the
+// gas owner's coins to cover the budget plus any amounts split from the gas coin. Inputs that only carry an object ID are resolved by the fullnode during simulation.
+// When execution fails the returned error is a *TransactionFailedError and the executed
+// transaction is returned alongside it.
+func (c *GRPCClient) SignAndExecute(ctx context.Context, signer keypair.Keypair, tx *v2.Transaction, options *SignAndExecuteOptions) (*v2.ExecutedTransaction, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	if signer == nil {
+		return nil, errors.New("nil signer")
+	}
+	if tx == nil {
+		return nil, ErrMissingTransaction
+	}
+	if options == nil {
+		options = &SignAndExecuteOptions{}
+	}
+
+	prepared, err := c.prepareTransaction(ctx, signer, tx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.DryRun {
+		resp, err := c.SimulateTransaction(ctx, prepared, &SimulateTransactionOptions{
+			ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"transaction.digest", "transaction.effects"}},
+		})
+		var failed *TransactionFailedError
+		if errors.As(err, &failed) {
+			return resp.GetTransaction(), failed
+		}
+		if err != nil {
+			return nil, fmt.Errorf("dry run: %w", err)
+		}
+	}
+
+	txBytes, err := transaction.MarshalTransaction(prepared)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := keypair.SignTransactionUserSignature(signer, txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("sign transaction: %w", err)
+	}
+
+	name := transaction.TransactionDataBcsName
+	executed, err := c.ExecuteSignedTransactionAndWait(ctx, &ExecuteAndWaitRequest{
+		Transaction: &v2.Transaction{Bcs: &v2.Bcs{Name: &name, Value: txBytes}},
+		Signatures:  []*v2.UserSignature{signature},
+		ReadMask:    ensureFieldMaskPaths(options.ReadMask, "effects.status"),
+	}, options.ExecuteOptions)
+	// On error executed is only set when execution failed on chain.
+	return executed, err
+}
+
+// prepareTransaction returns a copy of tx with every field required for signing populated.
+func (c *GRPCClient) prepareTransaction(ctx context.Context, signer keypair.Keypair, tx *v2.Transaction, options *SignAndExecuteOptions) (*v2.Transaction, error) {
+	cloned := proto.Clone(tx)
+	if cloned == nil {
+		return nil, errors.New("failed to clone transaction")
+	}
+	prepared := cloned.(*v2.Transaction)
+	if prepared.Kind == nil && len(prepared.GetBcs().GetValue()) > 0 {
+		decoded, err := transaction.UnmarshalTransaction(prepared.GetBcs().GetValue())
+		if err != nil {
+			return nil, err
+		}
+		prepared = decoded
+	}
+	prepared.Bcs = nil
+	prepared.Digest = nil
+
+	signerAddress, err := signer.SuiAddress()
+	if err != nil {
+		return nil, fmt.Errorf("signer address: %w", err)
+	}
+	if prepared.GetSender() == "" {
+		prepared.Sender = stringPtr(signerAddress)
+	} else if !sameAddress(prepared.GetSender(), signerAddress) {
+		return nil, fmt.Errorf("%w: signer %s, sender %s", ErrSignerMismatch, signerAddress, prepared.GetSender())
+	}
+
+	if prepared.GasPayment == nil {
+		prepared.GasPayment = &v2.GasPayment{}
+	}
+	gas := prepared.GasPayment
+	if gas.GetOwner() == "" {
+		gas.Owner = stringPtr(prepared.GetSender())
+	}
+	if gas.GetPrice() == 0 {
+		price, err := c.ReferenceGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("reference gas price: %w", err)
+		}
+		gas.Price = &price
+	}
+
+	var (
+		resolved *v2.TransactionKind
+		selected []*v2.ObjectReference
+	)
+	if gas.GetBudget() == 0 {
+		budget, simulated, err := c.estimateGasBudget(ctx, prepared, options.GasBudget)
+		if err != nil {
+			return nil, err
+		}
+		gas.Budget = &budget
+		resolved = simulated.GetTransaction().GetKind()
+		selected = simulated.GetTransaction().GetGasPayment().GetObjects()
+	}
+
+	if len(gas.GetObjects()) == 0 && len(selected) > 0 {
+		gas.Objects = selected
+	} else if len(gas.GetObjects()) == 0 {
+		// A coin the transaction already takes as an input cannot also pay for gas.
+		selection := append([]CoinSelectionOption{WithCoinExclusions(inputObjectIDs(prepared)...)}, options.CoinSelection...)
+		amount := saturatingAdd(gas.GetBudget(), gasCoinSplitAmount(prepared))
+		coins, err := c.SelectCoins(ctx, gas.GetOwner(), SuiCoinType, amount, selection...)
+		if err != nil {
+			return nil, fmt.Errorf("select gas coins: %w", err)
+		}
+		for _, coin := range coins {
+			gas.Objects = append(gas.Objects, &v2.ObjectReference{
+				ObjectId: stringPtr(coin.GetObjectId()),
+				Version:  uint64Ptr(coin.GetVersion()),
+				Digest:   stringPtr(coin.GetDigest()),
+			})
+		}
+	}
+
+	if _, err := transaction.MarshalTransaction(prepared); err == nil {
+		return prepared, nil
+	} else if !errors.Is(err, transaction.ErrIncompleteTransaction) {
+		return nil, err
+	}
+
+	// Some inputs are missing versions or digests; let the fullnode resolve them.
+	if resolved == nil {
+		resp, err := c.SimulateTransaction(ctx, prepared, &SimulateTransactionOptions{
+			ReadMask: &fieldmaskpb.FieldMask{Paths: []string{"transaction.transaction.kind"}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("resolve transaction inputs: %w", err)
+		}
+		resolved = resp.GetTransaction().GetTransaction().GetKind()
+	}
+	if resolved == nil {
+		return nil, fmt.Errorf("%w: fullnode did not return resolved inputs", transaction.ErrIncompleteTransaction)
+	}
+	prepared.Kind = resolved
+	if _, err := transaction.MarshalTransaction(prepared); err != nil {
+		return nil, err
+	}
+	return prepared, nil
+}
+
+// gasCoinSplitAmount sums the pure u64 amounts tx splits from the gas coin.
+func gasCoinSplitAmount(tx *v2.Transaction) uint64 {
+	ptb := tx.GetKind().GetProgrammableTransaction()
+	inputs := ptb.GetInputs()
+	var total uint64
+	for _, command := range ptb.GetCommands() {
+		split := command.GetSplitCoins()
+		if split.GetCoin().GetKind() != v2.Argument_GAS {
+			continue
+		}
+		for _, amount := range split.GetAmounts() {
+			if amount.GetKind() != v2.Argument_INPUT || int(amount.GetInput()) >= len(inputs) {
+				continue
+			}
+			if pure := inputs[amount.GetInput()].GetPure(); len(pure) == 8 {
+				total = saturatingAdd(total, binary.LittleEndian.Uint64(pure))
+			}
+		}
+	}
+	return total
+}
+
+// inputObjectIDs returns the IDs of the objects tx takes as inputs.
+func inputObjectIDs(tx *v2.Transaction) []string {
+	var ids []string
+	for _, input := range tx.GetKind().GetProgrammableTransaction().GetInputs() {
+		if id := input.GetObjectId(); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (c *GRPCClient) executionFailure(ctx context.Context, executed *v2.ExecutedTransaction, dryRun bool) *TransactionFailedError {
+	status := executed.GetEffects().GetStatus()
+	if status == nil || status.GetSuccess() {
+		return nil
+	}
+	if abort := status.GetError().GetAbort(); abort != nil && abort.CleverError == nil && c.abortCodes != nil {
+		location := abort.GetLocation()
+		if _, ok := c.abortCodes.Lookup(location.GetPackage(), location.GetModule(), abort.GetAbortCode()); !ok {
+			// Best effort: a package that fails to load only leaves the code
+			// unnamed. The caller's deadline may be nearly spent, so the load
+			// gets its own.
+			loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), abortCodesLoadTimeout)
+			_ = c.abortCodes.LoadPackage(loadCtx, c, location.GetPackage())
+			cancel()
+		}
+	}
+	return &TransactionFailedError{
+		Transaction: executed,
+		Status:      status,
+		DryRun:      dryRun,
+		Failure:     DecodeExecutionError(status.GetError(), c.abortCodes),
+	}
+}
+
+func sameAddress(a, b string) bool {
+	left, err := types.ParseAddress(a)
+	if err != nil {
+		return false
+	}
+	right, err := types.ParseAddress(b)
+	if err != nil {
+		return false
+	}
+	return left == right
+}
+
+func boolPtr(v bool) *bool {
+	return &v
+}
+
+func uint64Ptr(v uint64) *uint64 {
+	return &v
+}
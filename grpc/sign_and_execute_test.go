@@ -0,0 +1,374 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/0xdraco/sui-go-sdk/keychain"
+	"github.com/0xdraco/sui-go-sdk/keypair"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/0xdraco/sui-go-sdk/transaction"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	testGasCoinID  = "0x72f5c6eef73d77de271886219a2543e7c29a33de19a6c69c5cf1899f729c3f17"
+	testCoinDigest = "4vJ9JU1bJJE96FWSJKvHsmmFADCg4gpZQff4P3bkLKi"
+)
+
+type fakeExecutionNode struct {
+	v2.UnimplementedLedgerServiceServer
+	v2.UnimplementedStateServiceServer
+	v2.UnimplementedTransactionExecutionServiceServer
+	v2.UnimplementedSubscriptionServiceServer
+	v2.UnimplementedMovePackageServiceServer
+
+	gasUsed       *v2.GasCostSummary
+	simulateError *v2.ExecutionError
+	executeError  *v2.ExecutionError
+	maxTxGas      string
+	packages      map[string]*v2.Package
+	// coins, when set, replaces the single test gas coin owned by every address.
+	coins []*v2.Object
+
+	mu       sync.Mutex
+	listed   int
+	executed []*v2.ExecuteTransactionRequest
+	digests  chan string
+}
+
+func (n *fakeExecutionNode) GetEpoch(context.Context, *v2.GetEpochRequest) (*v2.GetEpochResponse, error) {
+	price := uint64(1000)
+	return &v2.GetEpochResponse{Epoch: &v2.Epoch{
+		ReferenceGasPrice: &price,
+		ProtocolConfig:    &v2.ProtocolConfig{Attributes: map[string]string{"max_tx_gas": n.maxTxGas}},
+	}}, nil
+}
+
+func (n *fakeExecutionNode) ListOwnedObjects(_ context.Context, req *v2.ListOwnedObjectsRequest) (*v2.ListOwnedObjectsResponse, error) {
+	n.mu.Lock()
+	n.listed++
+	n.mu.Unlock()
+	return &v2.ListOwnedObjectsResponse{Objects: n.ownedCoins(req.GetOwner())}, nil
+}
+
+func (n *fakeExecutionNode) ownedCoins(owner string) []*v2.Object {
+	if n.coins != nil {
+		return n.coins
+	}
+	version := uint64(9)
+	balance := uint64(10_000_000_000)
+	return []*v2.Object{{
+		ObjectId: stringPtr(testGasCoinID),
+		Version:  &version,
+		Digest:   stringPtr(testCoinDigest),
+		Owner:    &v2.Owner{Address: &owner},
+		Balance:  &balance,
+	}}
+}
+
+// SimulateTransaction echoes the transaction; with gas selection it pays with
+// every owned coin.
+func (n *fakeExecutionNode) SimulateTransaction(_ context.Context, req *v2.SimulateTransactionRequest) (*v2.SimulateTransactionResponse, error) {
+	tx := proto.Clone(req.GetTransaction()).(*v2.Transaction)
+	if req.GetDoGasSelection() && len(tx.GetGasPayment().GetObjects()) == 0 {
+		if tx.GasPayment == nil {
+			tx.GasPayment = &v2.GasPayment{}
+		}
+		for _, coin := range n.ownedCoins(tx.GetSender()) {
+			tx.GasPayment.Objects = append(tx.GasPayment.Objects, &v2.ObjectReference{
+				ObjectId: stringPtr(coin.GetObjectId()),
+				Version:  uint64Ptr(coin.GetVersion()),
+				Digest:   stringPtr(coin.GetDigest()),
+			})
+		}
+	}
+	success := n.simulateError == nil
+	return &v2.SimulateTransactionResponse{Transaction: &v2.ExecutedTransaction{
+		Transaction: tx,
+		Effects: &v2.TransactionEffects{
+			Status:  &v2.ExecutionStatus{Success: &success, Error: n.simulateError},
+			GasUsed: n.gasUsed,
+		},
+	}}, nil
+}
+
+func (n *fakeExecutionNode) ExecuteTransaction(_ context.Context, req *v2.ExecuteTransactionRequest) (*v2.ExecuteTransactionResponse, error) {
+	n.mu.Lock()
+	n.executed = append(n.executed, req)
+	n.mu.Unlock()
+
+	digest, err := transaction.Digest(req.GetTransaction())
+	if err != nil {
+		return nil, err
+	}
+	n.digests <- digest
+
+	success := n.executeError == nil
+	return &v2.ExecuteTransactionResponse{Transaction: &v2.ExecutedTransaction{
+		Digest:  &digest,
+		Effects: &v2.TransactionEffects{Status: &v2.ExecutionStatus{Success: &success, Error: n.executeError}},
+	}}, nil
+}
+
+func (n *fakeExecutionNode) SubscribeCheckpoints(_ *v2.SubscribeCheckpointsRequest, stream grpc.ServerStreamingServer[v2.SubscribeCheckpointsResponse]) error {
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case digest := <-n.digests:
+			seq := uint64(1)
+			err := stream.Send(&v2.SubscribeCheckpointsResponse{
+				Cursor: &seq,
+				Checkpoint: &v2.Checkpoint{
+					SequenceNumber: &seq,
+					Transactions:   []*v2.ExecutedTransaction{{Digest: &digest}},
+				},
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (n *fakeExecutionNode) GetPackage(_ context.Context, req *v2.GetPackageRequest) (*v2.GetPackageResponse, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	pkg, ok := n.packages[req.GetPackageId()]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "package not found")
+	}
+	return &v2.GetPackageResponse{Package: pkg}, nil
+}
+
+func (n *fakeExecutionNode) executions() []*v2.ExecuteTransactionRequest {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]*v2.ExecuteTransactionRequest(nil), n.executed...)
+}
+
+func newFakeExecutionNode(t *testing.T, opts ...Option) (*fakeExecutionNode, *GRPCClient) {
+	t.Helper()
+	node := &fakeExecutionNode{
+		gasUsed: &v2.GasCostSummary{
+			ComputationCost: uint64Ptr(1_000_000),
+			StorageCost:     uint64Ptr(2_000_000),
+			StorageRebate:   uint64Ptr(500_000),
+		},
+		maxTxGas: "50000000000",
+		digests:  make(chan string, 1),
+	}
+	client := newTestClient(t, func(s *grpc.Server) {
+		v2.RegisterLedgerServiceServer(s, node)
+		v2.RegisterStateServiceServer(s, node)
+		v2.RegisterTransactionExecutionServiceServer(s, node)
+		v2.RegisterSubscriptionServiceServer(s, node)
+		v2.RegisterMovePackageServiceServer(s, node)
+	}, opts...)
+	return node, client
+}
+
+func testSigner(t *testing.T) keypair.Keypair {
+	t.Helper()
+	signer, err := keypair.Generate(keychain.SchemeEd25519)
+	if err != nil {
+		t.Fatalf("generate signer: %v", err)
+	}
+	return signer
+}
+
+func testTransferTransaction(t *testing.T, recipient string) *v2.Transaction {
+	t.Helper()
+	b := transaction.NewTransactionBuilder()
+	coins := b.SplitCoins(b.Gas(), b.PureU64(1_000))
+	b.TransferObjects(coins, b.PureAddress(recipient))
+	tx, err := b.Build()
+	if err != nil {
+		t.Fatalf("build transaction: %v", err)
+	}
+	return tx
+}
+
+func TestSignAndExecuteFillsGasData(t *testing.T) {
+	signer := testSigner(t)
+	address, _ := signer.SuiAddress()
+	node, client := newFakeExecutionNode(t)
+
+	executed, err := client.SignAndExecute(context.Background(), signer, testTransferTransaction(t, address), nil)
+	if err != nil {
+		t.Fatalf("sign and execute: %v", err)
+	}
+
+	executions := node.executions()
+	if len(executions) != 1 {
+		t.Fatalf("expected one execution, got %d", len(executions))
+	}
+	req := executions[0]
+	txBytes := req.GetTransaction().GetBcs().GetValue()
+	submitted, err := transaction.UnmarshalTransaction(txBytes)
+	if err != nil {
+		t.Fatalf("decode submitted transaction: %v", err)
+	}
+	if submitted.GetDigest() != executed.GetDigest() {
+		t.Fatalf("digest mismatch: submitted %s, executed %s", submitted.GetDigest(), executed.GetDigest())
+	}
+	if submitted.GetSender() != address {
+		t.Fatalf("sender not defaulted to signer: %s", submitted.GetSender())
+	}
+
+	gas := submitted.GetGasPayment()
+	if gas.GetPrice() != 1000 {
+		t.Fatalf("unexpected gas price %d", gas.GetPrice())
+	}
+	// (1_000_000 computation + 2_000_000 storage - 500_000 rebate) * 1.2.
+	if gas.GetBudget() != 3_000_000 {
+		t.Fatalf("unexpected gas budget %d", gas.GetBudget())
+	}
+	if len(gas.GetObjects()) != 1 || gas.GetObjects()[0].GetObjectId() != testGasCoinID {
+		t.Fatalf("unexpected gas payment %v", gas.GetObjects())
+	}
+
+	if len(req.GetSignatures()) != 1 {
+		t.Fatalf("expected one signature, got %d", len(req.GetSignatures()))
+	}
+	if err := signer.VerifyTransaction(txBytes, req.GetSignatures()[0].GetBcs().GetValue()); err != nil {
+		t.Fatalf("signature does not verify: %v", err)
+	}
+}
+
+func TestSignAndExecuteSkipsInputCoinsForGas(t *testing.T) {
+	signer := testSigner(t)
+	address, _ := signer.SuiAddress()
+	node, client := newFakeExecutionNode(t)
+	const otherCoinID = "0x2b1b4e4a27fa6b71a1c7e4d1e6b2bbd0f4b4dc6c42d0c0fa0b4bbeb3b0e0d5a1"
+	version, balance := uint64(9), uint64(10_000_000_000)
+	node.coins = []*v2.Object{
+		{ObjectId: stringPtr(testGasCoinID), Version: &version, Digest: stringPtr(testCoinDigest), Balance: &balance},
+		{ObjectId: stringPtr(otherCoinID), Version: &version, Digest: stringPtr(testCoinDigest), Balance: &balance},
+	}
+
+	b := transaction.NewTransactionBuilder()
+	coin := b.Object(&v2.ObjectReference{ObjectId: stringPtr(testGasCoinID), Version: &version, Digest: stringPtr(testCoinDigest)})
+	b.TransferObjects(b.SplitCoins(coin, b.PureU64(1_000)), b.PureAddress(address))
+	b.SetGasBudget(3_000_000)
+	tx, err := b.Build()
+	if err != nil {
+		t.Fatalf("build transaction: %v", err)
+	}
+
+	gas := submittedGasCoins(t, node, client, signer, tx)
+	if len(gas) != 1 || gas[0] != otherCoinID {
+		t.Fatalf("expected gas paid with %s, got %v", otherCoinID, gas)
+	}
+}
+
+// submittedGasCoins signs and executes tx and returns the IDs of the gas coins it was submitted with.
+func submittedGasCoins(t *testing.T, node *fakeExecutionNode, client *GRPCClient, signer keypair.Keypair, tx *v2.Transaction) []string {
+	t.Helper()
+	if _, err := client.SignAndExecute(context.Background(), signer, tx, nil); err != nil {
+		t.Fatalf("sign and execute: %v", err)
+	}
+	executions := node.executions()
+	if len(executions) == 0 {
+		t.Fatalf("transaction was not executed")
+	}
+	submitted, err := transaction.UnmarshalTransaction(executions[len(executions)-1].GetTransaction().GetBcs().GetValue())
+	if err != nil {
+		t.Fatalf("decode submitted transaction: %v", err)
+	}
+	var ids []string
+	for _, ref := range submitted.GetGasPayment().GetObjects() {
+		ids = append(ids, ref.GetObjectId())
+	}
+	return ids
+}
+
+func TestSignAndExecuteCoversAmountsSplitFromGas(t *testing.T) {
+	signer := testSigner(t)
+	address, _ := signer.SuiAddress()
+	node, client := newFakeExecutionNode(t)
+	const otherCoinID = "0x2b1b4e4a27fa6b71a1c7e4d1e6b2bbd0f4b4dc6c42d0c0fa0b4bbeb3b0e0d5a1"
+	version, balance := uint64(9), uint64(5_000_000)
+	node.coins = []*v2.Object{
+		{ObjectId: stringPtr(testGasCoinID), Version: &version, Digest: stringPtr(testCoinDigest), Balance: &balance},
+		{ObjectId: stringPtr(otherCoinID), Version: &version, Digest: stringPtr(testCoinDigest), Balance: &balance},
+	}
+	want := []string{testGasCoinID, otherCoinID}
+
+	// Without a budget the coins picked by the fullnode during estimation are kept.
+	b := transaction.NewTransactionBuilder()
+	b.TransferObjects(b.SplitCoins(b.Gas(), b.PureU64(4_000_000)), b.PureAddress(address))
+	tx, err := b.Build()
+	if err != nil {
+		t.Fatalf("build transaction: %v", err)
+	}
+	if gas := submittedGasCoins(t, node, client, signer, tx); !slices.Equal(gas, want) {
+		t.Fatalf("expected the simulated gas payment %v, got %v", want, gas)
+	}
+	node.mu.Lock()
+	listed := node.listed
+	node.mu.Unlock()
+	if listed != 0 {
+		t.Fatalf("listed owned coins %d times instead of reusing the simulated gas payment", listed)
+	}
+
+	// With an explicit budget the selection covers the budget plus the split,
+	// which one 5_000_000 coin does not.
+	b = transaction.NewTransactionBuilder()
+	b.TransferObjects(b.SplitCoins(b.Gas(), b.PureU64(4_000_000)), b.PureAddress(address))
+	b.SetGasBudget(3_000_000)
+	tx, err = b.Build()
+	if err != nil {
+		t.Fatalf("build transaction: %v", err)
+	}
+	if gas := submittedGasCoins(t, node, client, signer, tx); !slices.Equal(gas, want) {
+		t.Fatalf("expected gas paid with %v, got %v", want, gas)
+	}
+}
+
+func TestSignAndExecuteDryRunFailure(t *testing.T) {
+	signer := testSigner(t)
+	address, _ := signer.SuiAddress()
+	node, client := newFakeExecutionNode(t)
+	node.simulateError = &v2.ExecutionError{
+		Description: stringPtr("insufficient coin balance"),
+		Command:     uint64Ptr(0),
+		Kind:        v2.ExecutionError_INSUFFICIENT_COIN_BALANCE.Enum(),
+	}
+
+	tx := testTransferTransaction(t, address)
+	tx.GasPayment = &v2.GasPayment{Budget: uint64Ptr(5_000_000)}
+	executed, err := client.SignAndExecute(context.Background(), signer, tx, &SignAndExecuteOptions{DryRun: true})
+
+	var failure *TransactionFailedError
+	if !errors.As(err, &failure) {
+		t.Fatalf("expected TransactionFailedError, got %v", err)
+	}
+	if !failure.DryRun || failure.ExecutionError().GetCommand() != 0 {
+		t.Fatalf("unexpected failure %+v", failure)
+	}
+	if executed == nil {
+		t.Fatalf("expected simulated transaction alongside failure")
+	}
+	if len(node.executions()) != 0 {
+		t.Fatalf("transaction must not be submitted after a failed dry run")
+	}
+}
+
+func TestSignAndExecuteRejectsForeignSender(t *testing.T) {
+	signer := testSigner(t)
+	_, client := newFakeExecutionNode(t)
+
+	tx := testTransferTransaction(t, testGasCoinID)
+	tx.Sender = stringPtr(testGasCoinID)
+	if _, err := client.SignAndExecute(context.Background(), signer, tx, nil); !errors.Is(err, ErrSignerMismatch) {
+		t.Fatalf("expected ErrSignerMismatch, got %v", err)
+	}
+}
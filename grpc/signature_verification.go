@@ -0,0 +1,105 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xdraco/sui-go-sdk/keypair"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/0xdraco/sui-go-sdk/transaction"
+	"github.com/iotaledger/bcs-go"
+	"google.golang.org/grpc"
+)
+
+const personalMessageBcsName = "PersonalMessage"
+
+// ErrSignatureInvalid indicates the fullnode rejected a signature.
+var ErrSignatureInvalid = errors.New("signature is invalid")
+
+// VerifySignatureOptions customises VerifyPersonalMessageSignature and VerifyTransactionSignature.
+type VerifySignatureOptions struct {
+	// Address, when set, must match the address derived from the signature.
+	Address string
+	// Jwks overrides the on-chain JWK set used to verify zkLogin signatures.
+	Jwks []*v2.ActiveJwk
+}
+
+// SignatureVerification is the fullnode's verdict on a signature.
+type SignatureVerification struct {
+	Valid bool
+	// Reason explains why the signature was rejected; empty when Valid.
+	Reason string
+}
+
+// Err returns nil for a valid signature and an error wrapping ErrSignatureInvalid
+// with the fullnode's reason otherwise.
+func (v *SignatureVerification) Err() error {
+	if v == nil {
+		return ErrSignatureInvalid
+	}
+	if v.Valid {
+		return nil
+	}
+	if v.Reason == "" {
+		return ErrSignatureInvalid
+	}
+	return fmt.Errorf("%w: %s", ErrSignatureInvalid, v.Reason)
+}
+
+// VerifyPersonalMessageSignature asks the fullnode to verify a serialized signature
+// (`flag || ...` as produced by SignPersonalMessage, multisig, zkLogin or passkey
+// signers) over a personal message. Transport and request errors are returned as
+// errors; a rejected signature is reported through the result.
+func (c *GRPCClient) VerifyPersonalMessageSignature(ctx context.Context, message []byte, signature []byte, options *VerifySignatureOptions, opts ...grpc.CallOption) (*SignatureVerification, error) {
+	if len(message) == 0 {
+		return nil, errors.New("personal message is empty")
+	}
+	encoded, err := bcs.Marshal(&message)
+	if err != nil {
+		return nil, fmt.Errorf("encode personal message: %w", err)
+	}
+	return c.verifySignature(ctx, personalMessageBcsName, encoded, signature, options, opts...)
+}
+
+// VerifyTransactionSignature asks the fullnode to verify a serialized signature over
+// BCS-encoded TransactionData.
+func (c *GRPCClient) VerifyTransactionSignature(ctx context.Context, txBytes []byte, signature []byte, options *VerifySignatureOptions, opts ...grpc.CallOption) (*SignatureVerification, error) {
+	if len(txBytes) == 0 {
+		return nil, errors.New("transaction bytes are empty")
+	}
+	return c.verifySignature(ctx, transaction.TransactionDataBcsName, txBytes, signature, options, opts...)
+}
+
+func (c *GRPCClient) verifySignature(ctx context.Context, messageName string, message []byte, signature []byte, options *VerifySignatureOptions, opts ...grpc.CallOption) (*SignatureVerification, error) {
+	if c == nil {
+		return nil, errors.New("nil client")
+	}
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	if len(signature) == 0 {
+		return nil, errors.New("signature is empty")
+	}
+
+	name := messageName
+	sigName := keypair.UserSignatureBcsName
+	req := &v2.VerifySignatureRequest{
+		Message: &v2.Bcs{Name: &name, Value: append([]byte(nil), message...)},
+		// The fullnode decodes the serialized signature itself, which covers
+		// every scheme without mirroring each one in the typed oneof.
+		Signature: &v2.UserSignature{Bcs: &v2.Bcs{Name: &sigName, Value: append([]byte(nil), signature...)}},
+	}
+	if options != nil {
+		if options.Address != "" {
+			req.Address = stringPtr(options.Address)
+		}
+		req.Jwks = options.Jwks
+	}
+
+	resp, err := c.SignatureVerificationClient().VerifySignature(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &SignatureVerification{Valid: resp.GetIsValid(), Reason: resp.GetReason()}, nil
+}
@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"google.golang.org/grpc"
+)
+
+type fakeSignatureVerifier struct {
+	v2.UnimplementedSignatureVerificationServiceServer
+	response *v2.VerifySignatureResponse
+
+	mu       sync.Mutex
+	requests []*v2.VerifySignatureRequest
+}
+
+func (f *fakeSignatureVerifier) VerifySignature(_ context.Context, req *v2.VerifySignatureRequest) (*v2.VerifySignatureResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+	return f.response, nil
+}
+
+func (f *fakeSignatureVerifier) received() []*v2.VerifySignatureRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*v2.VerifySignatureRequest(nil), f.requests...)
+}
+
+func newFakeSignatureVerifier(t *testing.T, response *v2.VerifySignatureResponse) (*GRPCClient, *fakeSignatureVerifier) {
+	t.Helper()
+	fake := &fakeSignatureVerifier{response: response}
+	client := newTestClient(t, func(s *grpc.Server) {
+		v2.RegisterSignatureVerificationServiceServer(s, fake)
+	})
+	return client, fake
+}
+
+func TestVerifyPersonalMessageSignatureBuildsRequest(t *testing.T) {
+	client, fake := newFakeSignatureVerifier(t, &v2.VerifySignatureResponse{IsValid: boolPtr(true)})
+	signature := []byte{0x00, 0x01, 0x02}
+	kid := "key-1"
+
+	result, err := client.VerifyPersonalMessageSignature(context.Background(), []byte("hello"), signature, &VerifySignatureOptions{
+		Address: "0x1",
+		Jwks:    []*v2.ActiveJwk{{Id: &v2.JwkId{Kid: &kid}}},
+	})
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if !result.Valid || result.Err() != nil {
+		t.Fatalf("unexpected result %+v", result)
+	}
+
+	req := fake.received()[0]
+	if req.GetMessage().GetName() != "PersonalMessage" {
+		t.Fatalf("message name = %q", req.GetMessage().GetName())
+	}
+	if want := append([]byte{5}, "hello"...); !bytes.Equal(req.GetMessage().GetValue(), want) {
+		t.Fatalf("message value = %x, want %x", req.GetMessage().GetValue(), want)
+	}
+	if req.GetSignature().GetBcs().GetName() != "UserSignature" || !bytes.Equal(req.GetSignature().GetBcs().GetValue(), signature) {
+		t.Fatalf("signature = %v", req.GetSignature())
+	}
+	if req.GetAddress() != "0x1" || len(req.GetJwks()) != 1 || req.GetJwks()[0].GetId().GetKid() != kid {
+		t.Fatalf("options not forwarded: %v", req)
+	}
+}
+
+func TestVerifyTransactionSignatureReportsReason(t *testing.T) {
+	client, fake := newFakeSignatureVerifier(t, &v2.VerifySignatureResponse{
+		IsValid: boolPtr(false),
+		Reason:  stringPtr("signature does not match"),
+	})
+	txBytes := []byte{0x00, 0x00, 0x01}
+
+	result, err := client.VerifyTransactionSignature(context.Background(), txBytes, []byte{0x00}, nil)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if result.Valid || result.Reason != "signature does not match" {
+		t.Fatalf("unexpected result %+v", result)
+	}
+	if err := result.Err(); !errors.Is(err, ErrSignatureInvalid) {
+		t.Fatalf("Err() = %v", err)
+	}
+
+	req := fake.received()[0]
+	if req.GetMessage().GetName() != "TransactionData" || !bytes.Equal(req.GetMessage().GetValue(), txBytes) {
+		t.Fatalf("message = %v", req.GetMessage())
+	}
+	if req.Address != nil || len(req.GetJwks()) != 0 {
+		t.Fatalf("unexpected options in request: %v", req)
+	}
+}
+
+func TestVerifySignatureRejectsEmptyInput(t *testing.T) {
+	client, fake := newFakeSignatureVerifier(t, &v2.VerifySignatureResponse{IsValid: boolPtr(true)})
+	ctx := context.Background()
+
+	if _, err := client.VerifyTransactionSignature(ctx, nil, []byte{0x00}, nil); err == nil {
+		t.Fatalf("expected error for empty transaction")
+	}
+	if _, err := client.VerifyPersonalMessageSignature(ctx, []byte("hi"), nil, nil); err == nil {
+		t.Fatalf("expected error for empty signature")
+	}
+	if len(fake.received()) != 0 {
+		t.Fatalf("invalid input reached the server")
+	}
+}
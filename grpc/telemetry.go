@@ -0,0 +1,252 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	grpcstatus "google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const instrumentationName = "github.com/0xdraco/sui-go-sdk/grpc"
+
+// Telemetry selects the OpenTelemetry providers used by WithTelemetry. Nil
+// providers fall back to the global ones from otel.GetTracerProvider and
+// otel.GetMeterProvider.
+type Telemetry struct {
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+}
+
+// WithTelemetry records a client span and duration and size metrics for every
+// RPC, annotated with the object IDs, transaction digests and checkpoints in
+// the request and response. ExecuteTransactionAndWait additionally gets a
+// parent span and records how long the checkpoint wait took.
+//
+// Spans cover the whole call, including attempts made by WithRetryPolicy and
+// time spent waiting for WithRateLimit.
+func WithTelemetry(t Telemetry) Option {
+	return func(cfg *config) {
+		cfg.telemetry = newTelemetry(t)
+	}
+}
+
+type telemetry struct {
+	tracer         trace.Tracer
+	duration       metric.Float64Histogram
+	requestSize    metric.Int64Histogram
+	responseSize   metric.Int64Histogram
+	checkpointWait metric.Float64Histogram
+}
+
+func newTelemetry(t Telemetry) *telemetry {
+	tp, mp := t.TracerProvider, t.MeterProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+	meter := mp.Meter(instrumentationName)
+
+	// Instrument constructors return usable no-op instruments alongside any
+	// error, so errors are reported but not fatal.
+	tel := &telemetry{tracer: tp.Tracer(instrumentationName)}
+	var err error
+	tel.duration, err = meter.Float64Histogram("rpc.client.duration",
+		metric.WithDescription("Duration of client RPCs."), metric.WithUnit("ms"))
+	otel.Handle(err)
+	tel.requestSize, err = meter.Int64Histogram("rpc.client.request.size",
+		metric.WithDescription("Size of RPC request messages."), metric.WithUnit("By"))
+	otel.Handle(err)
+	tel.responseSize, err = meter.Int64Histogram("rpc.client.response.size",
+		metric.WithDescription("Size of RPC response messages."), metric.WithUnit("By"))
+	otel.Handle(err)
+	tel.checkpointWait, err = meter.Float64Histogram("sui.client.checkpoint_wait.duration",
+		metric.WithDescription("Time from transaction execution until it was observed in a checkpoint."), metric.WithUnit("ms"))
+	otel.Handle(err)
+	return tel
+}
+
+// rpcAttributes describes a full method name such as "/sui.rpc.v2.LedgerService/GetObject".
+func rpcAttributes(method string) []attribute.KeyValue {
+	service, name, _ := strings.Cut(strings.TrimPrefix(method, "/"), "/")
+	return []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", service),
+		attribute.String("rpc.method", name),
+	}
+}
+
+func (t *telemetry) startRPC(ctx context.Context, method string, req any) (context.Context, trace.Span, []attribute.KeyValue) {
+	attrs := rpcAttributes(method)
+	ctx, span := t.tracer.Start(ctx, strings.TrimPrefix(method, "/"),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(messageAttributes(req)...),
+	)
+	t.recordSize(ctx, t.requestSize, req, attrs)
+	return ctx, span, attrs
+}
+
+func (t *telemetry) endRPC(ctx context.Context, span trace.Span, attrs []attribute.KeyValue, started time.Time, err error) {
+	code := grpcstatus.Code(err)
+	attrs = append(attrs, attribute.Int("rpc.grpc.status_code", int(code)))
+	span.SetAttributes(attrs[len(attrs)-1])
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, code.String())
+	}
+	span.End()
+	t.duration.Record(ctx, float64(time.Since(started))/float64(time.Millisecond), metric.WithAttributes(attrs...))
+}
+
+func (t *telemetry) recordSize(ctx context.Context, histogram metric.Int64Histogram, msg any, attrs []attribute.KeyValue) {
+	if m, ok := msg.(proto.Message); ok {
+		histogram.Record(ctx, int64(proto.Size(m)), metric.WithAttributes(attrs...))
+	}
+}
+
+func (t *telemetry) unary(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	started := time.Now()
+	ctx, span, attrs := t.startRPC(ctx, method, req)
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err == nil {
+		t.recordSize(ctx, t.responseSize, reply, attrs)
+		span.SetAttributes(messageAttributes(reply)...)
+	}
+	t.endRPC(ctx, span, attrs, started, err)
+	return err
+}
+
+func (t *telemetry) stream(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	started := time.Now()
+	ctx, span, attrs := t.startRPC(ctx, method, nil)
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		t.endRPC(ctx, span, attrs, started, err)
+		return nil, err
+	}
+	return &telemetryStream{ClientStream: stream, telemetry: t, ctx: ctx, span: span, attrs: attrs, started: started}, nil
+}
+
+// telemetryStream ends its span when the stream finishes.
+type telemetryStream struct {
+	grpc.ClientStream
+
+	telemetry *telemetry
+	ctx       context.Context
+	span      trace.Span
+	attrs     []attribute.KeyValue
+	started   time.Time
+	once      sync.Once
+}
+
+func (s *telemetryStream) SendMsg(m any) error {
+	err := s.ClientStream.SendMsg(m)
+	if err == nil {
+		s.telemetry.recordSize(s.ctx, s.telemetry.requestSize, m, s.attrs)
+		s.span.SetAttributes(messageAttributes(m)...)
+	}
+	return err
+}
+
+func (s *telemetryStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil {
+		s.telemetry.recordSize(s.ctx, s.telemetry.responseSize, m, s.attrs)
+		return nil
+	}
+	s.once.Do(func() {
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+		s.telemetry.endRPC(s.ctx, s.span, s.attrs, s.started, err)
+	})
+	return err
+}
+
+// messageAttributes extracts object IDs, digests and checkpoints from the
+// messages of the helpers that address them.
+func messageAttributes(msg any) []attribute.KeyValue {
+	switch m := msg.(type) {
+	case *v2.GetObjectRequest:
+		return []attribute.KeyValue{attribute.String("sui.object_id", m.GetObjectId())}
+	case *v2.BatchGetObjectsRequest:
+		ids := make([]string, 0, len(m.GetRequests()))
+		for _, req := range m.GetRequests() {
+			ids = append(ids, req.GetObjectId())
+		}
+		return []attribute.KeyValue{attribute.StringSlice("sui.object_ids", ids)}
+	case *v2.GetTransactionRequest:
+		return []attribute.KeyValue{attribute.String("sui.transaction.digest", m.GetDigest())}
+	case *v2.BatchGetTransactionsRequest:
+		return []attribute.KeyValue{attribute.StringSlice("sui.transaction.digests", m.GetDigests())}
+	case *v2.GetCheckpointRequest:
+		switch id := m.GetCheckpointId().(type) {
+		case *v2.GetCheckpointRequest_SequenceNumber:
+			return []attribute.KeyValue{attribute.Int64("sui.checkpoint", int64(id.SequenceNumber))}
+		case *v2.GetCheckpointRequest_Digest:
+			return []attribute.KeyValue{attribute.String("sui.checkpoint.digest", id.Digest)}
+		}
+	case *v2.ExecuteTransactionResponse:
+		return executedTransactionAttributes(m.GetTransaction())
+	case *v2.SimulateTransactionResponse:
+		return executedTransactionAttributes(m.GetTransaction())
+	}
+	return nil
+}
+
+func executedTransactionAttributes(tx *v2.ExecutedTransaction) []attribute.KeyValue {
+	if tx == nil {
+		return nil
+	}
+	var attrs []attribute.KeyValue
+	if digest := tx.GetDigest(); digest != "" {
+		attrs = append(attrs, attribute.String("sui.transaction.digest", digest))
+	}
+	if tx.Checkpoint != nil {
+		attrs = append(attrs, attribute.Int64("sui.checkpoint", int64(tx.GetCheckpoint())))
+	}
+	return attrs
+}
+
+// startSpan starts an internal span around a multi-RPC helper. It is a no-op
+// when telemetry is disabled.
+func (t *telemetry) startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	if t == nil {
+		return ctx, noop.Span{}
+	}
+	return t.tracer.Start(ctx, name)
+}
+
+// endSpan records err on span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (t *telemetry) recordCheckpointWait(ctx context.Context, d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.checkpointWait.Record(ctx, float64(d)/float64(time.Millisecond))
+	trace.SpanFromContext(ctx).AddEvent("checkpoint observed", trace.WithAttributes(
+		attribute.Int64("sui.checkpoint_wait.ms", d.Milliseconds()),
+	))
+}
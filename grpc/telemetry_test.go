@@ -0,0 +1,166 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+func newTestTelemetry() (Telemetry, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	return Telemetry{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	}, spans, reader
+}
+
+func findSpan(spans []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+	for _, span := range spans {
+		if span.Name() == name {
+			return span
+		}
+	}
+	return nil
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key string) (attribute.Value, bool) {
+	for _, kv := range span.Attributes() {
+		if string(kv.Key) == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+// histogramCount returns the number of recordings of the named histogram.
+func histogramCount(t *testing.T, reader *sdkmetric.ManualReader, name string) uint64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect metrics: %v", err)
+	}
+	var count uint64
+	for _, scope := range rm.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			if m.Name != name {
+				continue
+			}
+			switch data := m.Data.(type) {
+			case metricdata.Histogram[float64]:
+				for _, point := range data.DataPoints {
+					count += point.Count
+				}
+			case metricdata.Histogram[int64]:
+				for _, point := range data.DataPoints {
+					count += point.Count
+				}
+			}
+		}
+	}
+	return count
+}
+
+func TestTelemetryRecordsRPCSpansAndMetrics(t *testing.T) {
+	tel, spans, reader := newTestTelemetry()
+	node := &flakyNode{failures: 1, code: codes.NotFound}
+	client := newTestClient(t, func(s *grpc.Server) {
+		v2.RegisterLedgerServiceServer(s, node)
+	}, WithTelemetry(tel))
+
+	if _, err := client.GetServiceInfo(context.Background()); err == nil {
+		t.Fatalf("expected first call to fail")
+	}
+	if _, err := client.GetServiceInfo(context.Background()); err != nil {
+		t.Fatalf("get service info: %v", err)
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("expected two spans, got %d", len(ended))
+	}
+	failed := ended[0]
+	if failed.Name() != "sui.rpc.v2.LedgerService/GetServiceInfo" || failed.SpanKind() != trace.SpanKindClient {
+		t.Fatalf("unexpected span %q of kind %v", failed.Name(), failed.SpanKind())
+	}
+	if failed.Status().Code != otelcodes.Error {
+		t.Fatalf("expected error status, got %v", failed.Status())
+	}
+	if v, ok := spanAttribute(failed, "rpc.grpc.status_code"); !ok || v.AsInt64() != int64(codes.NotFound) {
+		t.Fatalf("unexpected status code attribute %v", v)
+	}
+	if v, _ := spanAttribute(ended[1], "rpc.grpc.status_code"); v.AsInt64() != int64(codes.OK) || ended[1].Status().Code == otelcodes.Error {
+		t.Fatalf("expected OK span, got %v %v", v, ended[1].Status())
+	}
+	if v, ok := spanAttribute(ended[1], "rpc.service"); !ok || v.AsString() != "sui.rpc.v2.LedgerService" {
+		t.Fatalf("unexpected service attribute %v", v)
+	}
+
+	if n := histogramCount(t, reader, "rpc.client.duration"); n != 2 {
+		t.Fatalf("expected two duration recordings, got %d", n)
+	}
+	if n := histogramCount(t, reader, "rpc.client.response.size"); n != 1 {
+		t.Fatalf("expected one response size recording, got %d", n)
+	}
+}
+
+func TestTelemetryCoversRetries(t *testing.T) {
+	tel, spans, _ := newTestTelemetry()
+	node := &flakyNode{failures: 2, code: codes.Unavailable}
+	client := newTestClient(t, func(s *grpc.Server) {
+		v2.RegisterLedgerServiceServer(s, node)
+	}, WithTelemetry(tel), WithRetryPolicy(RetryPolicy{InitialBackoff: 1}))
+
+	if _, err := client.GetServiceInfo(context.Background()); err != nil {
+		t.Fatalf("get service info: %v", err)
+	}
+	if n := len(spans.Ended()); n != 1 || node.callCount("GetServiceInfo") != 3 {
+		t.Fatalf("expected one span for three attempts, got %d spans", n)
+	}
+}
+
+func TestTelemetryExecuteTransactionAndWait(t *testing.T) {
+	tel, spans, reader := newTestTelemetry()
+	signer := testSigner(t)
+	address, _ := signer.SuiAddress()
+	_, client := newFakeExecutionNode(t, WithTelemetry(tel))
+
+	executed, err := client.SignAndExecute(context.Background(), signer, testTransferTransaction(t, address), nil)
+	if err != nil {
+		t.Fatalf("sign and execute: %v", err)
+	}
+
+	ended := spans.Ended()
+	parent := findSpan(ended, "ExecuteTransactionAndWait")
+	if parent == nil {
+		t.Fatalf("missing ExecuteTransactionAndWait span")
+	}
+	if v, ok := spanAttribute(parent, "sui.transaction.digest"); !ok || v.AsString() != executed.GetDigest() {
+		t.Fatalf("unexpected digest attribute %v", v)
+	}
+	execute := findSpan(ended, "sui.rpc.v2.TransactionExecutionService/ExecuteTransaction")
+	if execute == nil || execute.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatalf("ExecuteTransaction span is not a child of ExecuteTransactionAndWait")
+	}
+	if n := histogramCount(t, reader, "sui.client.checkpoint_wait.duration"); n != 1 {
+		t.Fatalf("expected one checkpoint wait recording, got %d", n)
+	}
+}
+
+func TestMessageAttributesWithoutTransaction(t *testing.T) {
+	for _, msg := range []any{&v2.ExecuteTransactionResponse{}, &v2.SimulateTransactionResponse{}} {
+		if attrs := messageAttributes(msg); len(attrs) != 0 {
+			t.Fatalf("%T: unexpected attributes %v", msg, attrs)
+		}
+	}
+}
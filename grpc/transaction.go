@@ -84,6 +84,8 @@ type checkpointResult struct {
 }
 
 // ExecuteSignedTransactionAndWait submits a signed transaction and resolves when it appears in a checkpoint.
+// When the effects report that execution failed, the executed transaction is returned together with a
+// *TransactionFailedError, as for ExecuteTransactionAndWait.
 func (c *GRPCClient) ExecuteSignedTransactionAndWait(ctx context.Context, req *ExecuteAndWaitRequest, options *ExecuteAndWaitOptions) (*v2.ExecutedTransaction, error) {
 	if c == nil {
 		return nil, errors.New("nil client")
@@ -93,6 +95,10 @@ func (c *GRPCClient) ExecuteSignedTransactionAndWait(ctx context.Context, req *E
 		return nil, err
 	}
 	resp, err := c.ExecuteTransactionAndWait(ctx, built, options)
+	var failed *TransactionFailedError
+	if errors.As(err, &failed) {
+		return resp.GetTransaction(), err
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -105,6 +111,9 @@ func (c *GRPCClient) ExecuteSignedTransactionAndWait(ctx context.Context, req *E
 }
 
 // ExecuteTransactionAndWait submits an ExecuteTransactionRequest and blocks until the transaction is observed in a checkpoint or an error occurs.
+// A transaction whose effects report failed execution is still checkpointed; its response is returned together
+// with a *TransactionFailedError wrapping the decoded *ExecutionFailure. effects.status is always requested
+// so failures are never missed.
 func (c *GRPCClient) ExecuteTransactionAndWait(ctx context.Context, request *v2.ExecuteTransactionRequest, options *ExecuteAndWaitOptions) (*v2.ExecuteTransactionResponse, error) {
 	if c == nil {
 		return nil, errors.New("nil client")
@@ -112,6 +121,19 @@ func (c *GRPCClient) ExecuteTransactionAndWait(ctx context.Context, request *v2.
 	if ctx == nil {
 		return nil, errors.New("nil context")
 	}
+	ctx, span := c.telemetry.startSpan(ctx, "ExecuteTransactionAndWait")
+	response, err := c.executeTransactionAndWait(ctx, request, options)
+	if err == nil {
+		if failure := c.executionFailure(ctx, response.GetTransaction(), false); failure != nil {
+			err = failure
+		}
+	}
+	span.SetAttributes(executedTransactionAttributes(response.GetTransaction())...)
+	endSpan(span, err)
+	return response, err
+}
+
+func (c *GRPCClient) executeTransactionAndWait(ctx context.Context, request *v2.ExecuteTransactionRequest, options *ExecuteAndWaitOptions) (*v2.ExecuteTransactionResponse, error) {
 	if request == nil {
 		return nil, errors.New("nil request")
 	}
@@ -184,6 +206,7 @@ func (c *GRPCClient) ExecuteTransactionAndWait(ctx context.Context, request *v2.
 		cancel()
 		return response, &CheckpointWaitError{Response: response, Err: ErrResponseMissingDigest}
 	}
+	waitStarted := time.Now()
 
 	for {
 		select {
@@ -209,6 +232,7 @@ func (c *GRPCClient) ExecuteTransactionAndWait(ctx context.Context, request *v2.
 			for _, t := range cp.GetTransactions() {
 				if t.GetDigest() == digest {
 					cancel()
+					c.telemetry.recordCheckpointWait(ctx, time.Since(waitStarted))
 					return response, nil
 				}
 			}
@@ -224,6 +248,9 @@ type SimulateTransactionOptions struct {
 }
 
 // SimulateTransaction executes the SimulateTransaction RPC for the provided transaction.
+// When the simulated effects report failed execution, the response is returned together with a
+// *TransactionFailedError (with DryRun set) wrapping the decoded *ExecutionFailure. Failures are only
+// detected when the read mask includes effects.status.
 func (c *GRPCClient) SimulateTransaction(ctx context.Context, tx *v2.Transaction, options *SimulateTransactionOptions, opts ...grpc.CallOption) (*v2.SimulateTransactionResponse, error) {
 	if c == nil {
 		return nil, errors.New("nil client")
@@ -240,7 +267,14 @@ func (c *GRPCClient) SimulateTransaction(ctx context.Context, tx *v2.Transaction
 		return nil, err
 	}
 
-	return c.TransactionExecutionClient().SimulateTransaction(ctx, req, opts...)
+	resp, err := c.TransactionExecutionClient().SimulateTransaction(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if failure := c.executionFailure(ctx, resp.GetTransaction(), true); failure != nil {
+		return resp, failure
+	}
+	return resp, nil
 }
 
 func buildExecuteTransactionRequest(req *ExecuteAndWaitRequest) (*v2.ExecuteTransactionRequest, error) {
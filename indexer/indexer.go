@@ -0,0 +1,371 @@
+// Package indexer runs checkpoint processors concurrently and commits their
+// rows to a Sink in checkpoint order.
+//
+// Each checkpoint received from the Source is handed to every registered
+// Processor. Checkpoints are processed in parallel but committed strictly in
+// sequence, after which the watermark (the next checkpoint to commit) is saved
+// to a grpc.CursorStore so a restarted indexer resumes where it stopped.
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	sui "github.com/0xdraco/sui-go-sdk/grpc"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+)
+
+const (
+	// DefaultConcurrency is the number of checkpoints processed in parallel by default.
+	DefaultConcurrency = 8
+	// DefaultShutdownTimeout bounds how long Run keeps committing processed
+	// checkpoints after its context is cancelled.
+	DefaultShutdownTimeout = 30 * time.Second
+)
+
+// Processor turns a checkpoint into rows for the sink. Process may be called
+// concurrently for different checkpoints.
+type Processor interface {
+	// Name identifies the processor's rows in a Batch.
+	Name() string
+	Process(ctx context.Context, checkpoint *v2.Checkpoint) ([]any, error)
+}
+
+// ProcessorFunc adapts a function to a Processor.
+type ProcessorFunc func(ctx context.Context, checkpoint *v2.Checkpoint) ([]any, error)
+
+type namedProcessor struct {
+	name string
+	fn   ProcessorFunc
+}
+
+// NewProcessor returns a Processor named name that calls fn.
+func NewProcessor(name string, fn ProcessorFunc) Processor {
+	return &namedProcessor{name: name, fn: fn}
+}
+
+func (p *namedProcessor) Name() string { return p.name }
+
+func (p *namedProcessor) Process(ctx context.Context, checkpoint *v2.Checkpoint) ([]any, error) {
+	return p.fn(ctx, checkpoint)
+}
+
+// Source delivers checkpoints in order, starting at start or at the latest
+// checkpoint when start is nil, until ctx is done or handler fails.
+type Source interface {
+	Run(ctx context.Context, start *uint64, handler sui.CheckpointHandler) error
+}
+
+type streamSource struct {
+	client  *sui.GRPCClient
+	options sui.CheckpointStreamOptions
+}
+
+// NewStreamSource returns a Source backed by client.CheckpointStream. The read
+// mask in options should cover every field the processors use; Start and
+// CursorStore are managed by the indexer and ignored.
+func NewStreamSource(client *sui.GRPCClient, options *sui.CheckpointStreamOptions) Source {
+	s := &streamSource{client: client}
+	if options != nil {
+		s.options = *options
+	}
+	s.options.CursorStore = nil
+	return s
+}
+
+func (s *streamSource) Run(ctx context.Context, start *uint64, handler sui.CheckpointHandler) error {
+	options := s.options
+	options.Start = start
+	stream, err := s.client.CheckpointStream(&options)
+	if err != nil {
+		return err
+	}
+	return stream.Run(ctx, handler)
+}
+
+// Options configures an Indexer.
+type Options struct {
+	// Concurrency bounds the number of checkpoints processed ahead of the
+	// last committed one. Zero uses DefaultConcurrency.
+	Concurrency int
+	// Watermarks persists the next checkpoint to commit. When nil an
+	// in-memory store is used.
+	Watermarks sui.CursorStore
+	// Start is the first checkpoint to index when no watermark has been saved.
+	// When nil, indexing starts at the latest checkpoint.
+	Start *uint64
+	// ShutdownTimeout bounds how long Run keeps committing checkpoints that
+	// were already received once its context is cancelled. Zero uses
+	// DefaultShutdownTimeout.
+	ShutdownTimeout time.Duration
+}
+
+// Watermarks is a snapshot of an Indexer's progress.
+type Watermarks struct {
+	// Next is the next checkpoint to commit.
+	Next uint64
+	// LatestReceived is the highest checkpoint received from the source.
+	LatestReceived uint64
+	// Committed counts checkpoints committed by this Indexer.
+	Committed uint64
+	// InFlight is the number of received checkpoints not yet committed.
+	InFlight int
+}
+
+// Indexer processes checkpoints from a Source and commits the rows to a Sink.
+type Indexer struct {
+	source     Source
+	sink       Sink
+	processors []Processor
+	options    Options
+
+	running sync.Mutex
+	mu      sync.Mutex
+	marks   Watermarks
+	started bool
+}
+
+// New returns an Indexer that feeds checkpoints from source to processors and
+// commits the resulting rows to sink.
+func New(source Source, sink Sink, processors []Processor, options *Options) (*Indexer, error) {
+	if source == nil {
+		return nil, errors.New("indexer: nil source")
+	}
+	if sink == nil {
+		return nil, errors.New("indexer: nil sink")
+	}
+	if len(processors) == 0 {
+		return nil, errors.New("indexer: no processors")
+	}
+	seen := make(map[string]struct{}, len(processors))
+	for _, p := range processors {
+		if p == nil {
+			return nil, errors.New("indexer: nil processor")
+		}
+		if _, ok := seen[p.Name()]; ok {
+			return nil, fmt.Errorf("indexer: duplicate processor %q", p.Name())
+		}
+		seen[p.Name()] = struct{}{}
+	}
+
+	ix := &Indexer{
+		source:     source,
+		sink:       sink,
+		processors: append([]Processor(nil), processors...),
+	}
+	if options != nil {
+		ix.options = *options
+	}
+	if ix.options.Concurrency <= 0 {
+		ix.options.Concurrency = DefaultConcurrency
+	}
+	if ix.options.ShutdownTimeout <= 0 {
+		ix.options.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	if ix.options.Watermarks == nil {
+		ix.options.Watermarks = sui.NewMemoryCursorStore()
+	}
+	return ix, nil
+}
+
+// Watermarks returns a snapshot of the indexer's progress.
+func (ix *Indexer) Watermarks() Watermarks {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	return ix.marks
+}
+
+// job is one checkpoint moving through the pipeline.
+type job struct {
+	sequence   uint64
+	checkpoint *v2.Checkpoint
+	done       chan struct{}
+	rows       map[string][]any
+	err        error
+}
+
+// Run indexes checkpoints until ctx is done or a processor, the sink or the
+// source fails. On cancellation it stops receiving checkpoints, commits the
+// ones already received within ShutdownTimeout and returns ctx.Err().
+func (ix *Indexer) Run(ctx context.Context) error {
+	if ctx == nil {
+		return errors.New("indexer: nil context")
+	}
+	if !ix.running.TryLock() {
+		return errors.New("indexer: already running")
+	}
+	defer ix.running.Unlock()
+
+	start, err := ix.loadWatermark(ctx)
+	if err != nil {
+		return err
+	}
+
+	sourceCtx, stopSource := context.WithCancel(ctx)
+	defer stopSource()
+	// Processing and commits outlive ctx by up to ShutdownTimeout.
+	processCtx, stopProcessing := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopProcessing()
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-processCtx.Done():
+			return
+		}
+		timer := time.NewTimer(ix.options.ShutdownTimeout)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			stopProcessing()
+		case <-processCtx.Done():
+		}
+	}()
+
+	queue := make(chan *job, ix.options.Concurrency)
+	sourceErr := make(chan error, 1)
+	go func() {
+		defer close(queue)
+		sourceErr <- ix.source.Run(sourceCtx, start, func(ctx context.Context, checkpoint *v2.Checkpoint) error {
+			return ix.enqueue(ctx, processCtx, queue, checkpoint)
+		})
+	}()
+
+	commitErr := ix.commitLoop(processCtx, queue)
+	stopSource()
+	stopProcessing()
+	for range queue {
+		// Drain so the source goroutine can exit.
+	}
+	err = <-sourceErr
+
+	switch {
+	case commitErr != nil:
+		return commitErr
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case err != nil:
+		return fmt.Errorf("indexer: source: %w", err)
+	default:
+		return nil
+	}
+}
+
+func (ix *Indexer) loadWatermark(ctx context.Context) (*uint64, error) {
+	cursor, ok, err := ix.options.Watermarks.Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("indexer: load watermark: %w", err)
+	}
+	start := ix.options.Start
+	if ok {
+		next, err := strconv.ParseUint(cursor, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("indexer: invalid watermark %q: %w", cursor, err)
+		}
+		start = &next
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.marks.InFlight = 0
+	if start != nil {
+		ix.marks.Next = *start
+		ix.started = true
+	}
+	return start, nil
+}
+
+// enqueue starts processing checkpoint and queues it for commit, blocking
+// while Concurrency checkpoints are waiting.
+func (ix *Indexer) enqueue(ctx, processCtx context.Context, queue chan<- *job, checkpoint *v2.Checkpoint) error {
+	sequence := checkpoint.GetSequenceNumber()
+	ix.mu.Lock()
+	if !ix.started {
+		ix.marks.Next = sequence
+		ix.started = true
+	}
+	skip := sequence < ix.marks.Next
+	ix.mu.Unlock()
+	if skip {
+		return nil
+	}
+
+	j := &job{sequence: sequence, checkpoint: checkpoint, done: make(chan struct{})}
+	select {
+	case queue <- j:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	ix.mu.Lock()
+	if sequence > ix.marks.LatestReceived {
+		ix.marks.LatestReceived = sequence
+	}
+	ix.marks.InFlight++
+	ix.mu.Unlock()
+
+	go ix.process(processCtx, j)
+	return nil
+}
+
+func (ix *Indexer) process(ctx context.Context, j *job) {
+	defer close(j.done)
+	j.rows = make(map[string][]any, len(ix.processors))
+	for _, p := range ix.processors {
+		rows, err := p.Process(ctx, j.checkpoint)
+		if err != nil {
+			j.err = fmt.Errorf("indexer: processor %q at checkpoint %d: %w", p.Name(), j.sequence, err)
+			return
+		}
+		j.rows[p.Name()] = rows
+	}
+}
+
+// commitLoop commits queued checkpoints in order until the queue is closed or
+// processCtx is cancelled at the end of the shutdown grace period.
+func (ix *Indexer) commitLoop(processCtx context.Context, queue <-chan *job) error {
+	for j := range queue {
+		select {
+		case <-j.done:
+		case <-processCtx.Done():
+			return nil
+		}
+		if processCtx.Err() != nil {
+			return nil
+		}
+		if j.err != nil {
+			return j.err
+		}
+		if err := ix.commit(processCtx, j); err != nil {
+			if processCtx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (ix *Indexer) commit(ctx context.Context, j *job) error {
+	var timestamp time.Time
+	if ts := j.checkpoint.GetSummary().GetTimestamp(); ts != nil {
+		timestamp = ts.AsTime()
+	}
+	batch := &Batch{Checkpoint: j.sequence, Timestamp: timestamp, Rows: j.rows}
+	if err := ix.sink.Write(ctx, batch); err != nil {
+		return fmt.Errorf("indexer: sink at checkpoint %d: %w", j.sequence, err)
+	}
+	if err := ix.options.Watermarks.Save(ctx, strconv.FormatUint(j.sequence+1, 10)); err != nil {
+		return fmt.Errorf("indexer: save watermark: %w", err)
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.marks.Next = j.sequence + 1
+	ix.marks.Committed++
+	ix.marks.InFlight--
+	return nil
+}
@@ -0,0 +1,222 @@
+package indexer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sui "github.com/0xdraco/sui-go-sdk/grpc"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+)
+
+// fakeSource delivers checkpoints from start (or first) up to last, then
+// blocks until cancelled.
+type fakeSource struct {
+	first, last uint64
+
+	mu     sync.Mutex
+	starts []*uint64
+}
+
+func (s *fakeSource) Run(ctx context.Context, start *uint64, handler sui.CheckpointHandler) error {
+	s.mu.Lock()
+	s.starts = append(s.starts, start)
+	s.mu.Unlock()
+
+	seq := s.first
+	if start != nil {
+		seq = *start
+	}
+	for ; seq <= s.last; seq++ {
+		sequence := seq
+		if err := handler(ctx, &v2.Checkpoint{SequenceNumber: &sequence}); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+// sequenceProcessor emits each checkpoint's sequence number, sleeping longer
+// for earlier checkpoints so that processing finishes out of order.
+func sequenceProcessor(name string) Processor {
+	return NewProcessor(name, func(ctx context.Context, checkpoint *v2.Checkpoint) ([]any, error) {
+		seq := checkpoint.GetSequenceNumber()
+		time.Sleep(time.Duration(5-seq%5) * time.Millisecond)
+		return []any{seq}, nil
+	})
+}
+
+// runUntil runs ix until the watermark reaches next.
+func runUntil(t *testing.T, ix *Indexer, next uint64) error {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- ix.Run(ctx) }()
+	for ix.Watermarks().Next < next {
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(time.Millisecond):
+		}
+	}
+	cancel()
+	return <-done
+}
+
+func TestIndexerCommitsInOrder(t *testing.T) {
+	sink := NewMemorySink()
+	watermarks := sui.NewMemoryCursorStore()
+	start := uint64(100)
+	ix, err := New(&fakeSource{first: 0, last: 119}, sink,
+		[]Processor{sequenceProcessor("a"), sequenceProcessor("b")},
+		&Options{Concurrency: 4, Watermarks: watermarks, Start: &start})
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	if err := runUntil(t, ix, 120); !errors.Is(err, context.Canceled) {
+		t.Fatalf("run: %v", err)
+	}
+	batches := sink.Batches()
+	if len(batches) != 20 {
+		t.Fatalf("committed %d batches, want 20", len(batches))
+	}
+	for i, batch := range batches {
+		want := uint64(100 + i)
+		if batch.Checkpoint != want || batch.Rows["a"][0] != want || batch.Rows["b"][0] != want {
+			t.Fatalf("batch %d: %+v", i, batch)
+		}
+	}
+	if cursor, _, _ := watermarks.Load(context.Background()); cursor != "120" {
+		t.Fatalf("watermark %q", cursor)
+	}
+	if marks := ix.Watermarks(); marks.Committed != 20 || marks.LatestReceived != 119 || marks.InFlight != 0 {
+		t.Fatalf("watermarks %+v", marks)
+	}
+}
+
+func TestIndexerResumesFromWatermark(t *testing.T) {
+	watermarks := sui.NewMemoryCursorStore()
+	if err := watermarks.Save(context.Background(), "7"); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	source := &fakeSource{first: 0, last: 9}
+	sink := NewMemorySink()
+	ix, err := New(source, sink, []Processor{sequenceProcessor("a")}, &Options{Watermarks: watermarks})
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	if err := runUntil(t, ix, 10); !errors.Is(err, context.Canceled) {
+		t.Fatalf("run: %v", err)
+	}
+	if len(source.starts) != 1 || source.starts[0] == nil || *source.starts[0] != 7 {
+		t.Fatalf("source started at %v", source.starts)
+	}
+	if rows := sink.Rows("a"); len(rows) != 3 || rows[0] != uint64(7) {
+		t.Fatalf("rows %v", rows)
+	}
+}
+
+func TestIndexerStopsOnProcessorError(t *testing.T) {
+	errBoom := errors.New("boom")
+	failing := NewProcessor("fail", func(_ context.Context, checkpoint *v2.Checkpoint) ([]any, error) {
+		if checkpoint.GetSequenceNumber() == 3 {
+			return nil, errBoom
+		}
+		return nil, nil
+	})
+	watermarks := sui.NewMemoryCursorStore()
+	sink := NewMemorySink()
+	ix, err := New(&fakeSource{first: 0, last: 9}, sink, []Processor{failing}, &Options{Watermarks: watermarks})
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := ix.Run(ctx); !errors.Is(err, errBoom) {
+		t.Fatalf("expected processor error, got %v", err)
+	}
+	if batches := sink.Batches(); len(batches) != 3 {
+		t.Fatalf("committed %d batches, want 3", len(batches))
+	}
+	if cursor, _, _ := watermarks.Load(context.Background()); cursor != "3" {
+		t.Fatalf("watermark %q", cursor)
+	}
+}
+
+func TestIndexerCommitsReceivedCheckpointsOnShutdown(t *testing.T) {
+	release := make(chan struct{})
+	slow := NewProcessor("slow", func(context.Context, *v2.Checkpoint) ([]any, error) {
+		<-release
+		return []any{1}, nil
+	})
+	sink := NewMemorySink()
+	ix, err := New(&fakeSource{first: 0, last: 2}, sink, []Processor{slow}, &Options{Concurrency: 4})
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- ix.Run(ctx) }()
+	for ix.Watermarks().InFlight < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	close(release)
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("run: %v", err)
+	}
+	if batches := sink.Batches(); len(batches) != 3 {
+		t.Fatalf("committed %d batches after shutdown, want 3", len(batches))
+	}
+}
+
+func TestJSONLSink(t *testing.T) {
+	var out bytes.Buffer
+	sink, err := NewJSONLSink(&out)
+	if err != nil {
+		t.Fatalf("sink: %v", err)
+	}
+	batch := &Batch{Checkpoint: 9, Rows: map[string][]any{
+		"transfers": {map[string]any{"amount": 5}},
+		"events":    {"a", "b"},
+	}}
+	if err := sink.Write(context.Background(), batch); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("wrote %d lines: %q", len(lines), out.String())
+	}
+	var first JSONLRecord
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if first.Processor != "events" || first.Checkpoint != 9 || first.Row != "a" {
+		t.Fatalf("first record %+v", first)
+	}
+	if !strings.Contains(lines[2], `"row":{"amount":5}`) {
+		t.Fatalf("last record %s", lines[2])
+	}
+}
+
+func TestNewValidatesProcessors(t *testing.T) {
+	source := &fakeSource{}
+	if _, err := New(source, NewMemorySink(), nil, nil); err == nil {
+		t.Fatalf("expected error without processors")
+	}
+	if _, err := New(source, NewMemorySink(), []Processor{sequenceProcessor("a"), sequenceProcessor("a")}, nil); err == nil {
+		t.Fatalf("expected error for duplicate processor names")
+	}
+}
@@ -0,0 +1,120 @@
+package indexer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Batch holds the rows produced for one checkpoint, keyed by processor name.
+type Batch struct {
+	Checkpoint uint64
+	Timestamp  time.Time
+	Rows       map[string][]any
+}
+
+// Sink stores committed rows. Write is called once per checkpoint, in
+// checkpoint order and never concurrently. The watermark is saved only after
+// Write returns, so after a crash the last batch may be written again; sinks
+// that need exactly-once writes should deduplicate on Batch.Checkpoint.
+type Sink interface {
+	Write(ctx context.Context, batch *Batch) error
+}
+
+// MemorySink keeps committed batches in memory.
+type MemorySink struct {
+	mu      sync.Mutex
+	batches []*Batch
+}
+
+// NewMemorySink returns an empty MemorySink.
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+// Write implements Sink.
+func (s *MemorySink) Write(_ context.Context, batch *Batch) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, batch)
+	return nil
+}
+
+// Batches returns the committed batches in commit order.
+func (s *MemorySink) Batches() []*Batch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*Batch(nil), s.batches...)
+}
+
+// Rows returns every row committed by the named processor, in checkpoint order.
+func (s *MemorySink) Rows(processor string) []any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var rows []any
+	for _, batch := range s.batches {
+		rows = append(rows, batch.Rows[processor]...)
+	}
+	return rows
+}
+
+// JSONLRecord is one line written by JSONLSink.
+type JSONLRecord struct {
+	Processor  string    `json:"processor"`
+	Checkpoint uint64    `json:"checkpoint"`
+	Timestamp  time.Time `json:"timestamp"`
+	Row        any       `json:"row"`
+}
+
+// JSONLSink writes each row as a JSON line. Rows of a batch are grouped by
+// processor name in sorted order. If the writer has a Sync method, as
+// *os.File does, it is called after every batch.
+type JSONLSink struct {
+	mu  sync.Mutex
+	w   io.Writer
+	buf *bufio.Writer
+}
+
+// NewJSONLSink returns a sink writing JSON lines to w.
+func NewJSONLSink(w io.Writer) (*JSONLSink, error) {
+	if w == nil {
+		return nil, errors.New("indexer: nil writer")
+	}
+	return &JSONLSink{w: w, buf: bufio.NewWriter(w)}, nil
+}
+
+// Write implements Sink.
+func (s *JSONLSink) Write(_ context.Context, batch *Batch) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	processors := make([]string, 0, len(batch.Rows))
+	for name := range batch.Rows {
+		processors = append(processors, name)
+	}
+	sort.Strings(processors)
+
+	enc := json.NewEncoder(s.buf)
+	for _, name := range processors {
+		for _, row := range batch.Rows[name] {
+			record := JSONLRecord{Processor: name, Checkpoint: batch.Checkpoint, Timestamp: batch.Timestamp, Row: row}
+			if err := enc.Encode(&record); err != nil {
+				s.buf.Reset(s.w)
+				return fmt.Errorf("encode %s row: %w", name, err)
+			}
+		}
+	}
+	if err := s.buf.Flush(); err != nil {
+		return err
+	}
+	if syncer, ok := s.w.(interface{ Sync() error }); ok {
+		return syncer.Sync()
+	}
+	return nil
+}
@@ -11,4 +11,6 @@ type Keypair interface {
 	PublicKeyBase64() string
 	SignPersonalMessage(message []byte) ([]byte, error)
 	VerifyPersonalMessage(message []byte, signature []byte) error
+	SignTransaction(txBytes []byte) ([]byte, error)
+	VerifyTransaction(txBytes []byte, signature []byte) error
 }
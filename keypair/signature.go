@@ -0,0 +1,76 @@
+package keypair
+
+import (
+	"fmt"
+
+	"github.com/0xdraco/sui-go-sdk/keychain"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+)
+
+// UserSignatureBcsName is the Bcs.Name the RPC uses for serialized UserSignature payloads.
+const UserSignatureBcsName = "UserSignature"
+
+const signatureSize = 64
+
+var publicKeySizes = map[keychain.Scheme]int{
+	keychain.SchemeEd25519:   32,
+	keychain.SchemeSecp256k1: 33,
+	keychain.SchemeSecp256r1: 33,
+}
+
+var signatureSchemes = map[keychain.Scheme]v2.SignatureScheme{
+	keychain.SchemeEd25519:   v2.SignatureScheme_ED25519,
+	keychain.SchemeSecp256k1: v2.SignatureScheme_SECP256K1,
+	keychain.SchemeSecp256r1: v2.SignatureScheme_SECP256R1,
+}
+
+// UserSignature converts a serialized `flag || sig || pubkey` signature, as
+// returned by SignTransaction, into the form expected by
+// ExecuteTransactionRequest.Signatures.
+func UserSignature(serialized []byte) (*v2.UserSignature, error) {
+	if len(serialized) == 0 {
+		return nil, fmt.Errorf("signature: empty signature")
+	}
+
+	scheme, err := keychain.SchemeFromFlag(serialized[0])
+	if err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+
+	expected := 1 + signatureSize + publicKeySizes[scheme]
+	if len(serialized) != expected {
+		return nil, fmt.Errorf("signature: %s signature must be %d bytes, got %d", scheme.Label(), expected, len(serialized))
+	}
+
+	sigScheme := signatureSchemes[scheme]
+	name := UserSignatureBcsName
+	return &v2.UserSignature{
+		Bcs: &v2.Bcs{
+			Name:  &name,
+			Value: append([]byte(nil), serialized...),
+		},
+		Scheme: &sigScheme,
+		Signature: &v2.UserSignature_Simple{
+			Simple: &v2.SimpleSignature{
+				Scheme:    &sigScheme,
+				Signature: append([]byte(nil), serialized[1:1+signatureSize]...),
+				PublicKey: append([]byte(nil), serialized[1+signatureSize:]...),
+			},
+		},
+	}, nil
+}
+
+// SignTransactionUserSignature signs BCS-encoded TransactionData with k and
+// returns the signature ready to attach to an execute request.
+func SignTransactionUserSignature(k Keypair, txBytes []byte) (*v2.UserSignature, error) {
+	if k == nil {
+		return nil, fmt.Errorf("signature: nil keypair")
+	}
+
+	serialized, err := k.SignTransaction(txBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return UserSignature(serialized)
+}
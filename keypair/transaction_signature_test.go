@@ -0,0 +1,137 @@
+package keypair_test
+
+import (
+	cryptoed25519 "crypto/ed25519"
+	"testing"
+
+	"github.com/0xdraco/sui-go-sdk/keychain"
+	"github.com/0xdraco/sui-go-sdk/keypair"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/0xdraco/sui-go-sdk/types"
+	"github.com/iotaledger/bcs-go"
+	"golang.org/x/crypto/blake2b"
+)
+
+func testTransactionBytes(t *testing.T) []byte {
+	t.Helper()
+
+	amount := []byte{100, 0, 0, 0, 0, 0, 0, 0}
+	input := uint16(0)
+	data := &types.TransactionData{V1: &types.TransactionDataV1{
+		Kind: types.TransactionKind{ProgrammableTransaction: &types.ProgrammableTransaction{
+			Inputs: []types.CallArg{{Pure: &amount}},
+			Commands: []types.Command{{SplitCoins: &types.SplitCoinsCommand{
+				Coin:    types.Argument{GasCoin: &bcs.None{}},
+				Amounts: []types.Argument{{Input: &input}},
+			}}},
+		}},
+		Sender: types.MustParseAddress("0x5e93a736d04fbb25737aa40bee40171ef79f65fae833749e3c089fe7cc2161f1"),
+		GasData: types.GasData{
+			Payment: []types.ObjectRef{{ObjectID: types.MustParseAddress("0x72f5"), Version: 7}},
+			Owner:   types.MustParseAddress("0x5e93a736d04fbb25737aa40bee40171ef79f65fae833749e3c089fe7cc2161f1"),
+			Price:   1000,
+			Budget:  5_000_000,
+		},
+		Expiration: types.TransactionExpiration{None: &bcs.None{}},
+	}}
+
+	encoded, err := types.MarshalTransactionData(data)
+	if err != nil {
+		t.Fatalf("marshal transaction data: %v", err)
+	}
+	return encoded
+}
+
+func TestTransactionSignatures(t *testing.T) {
+	txBytes := testTransactionBytes(t)
+
+	cases := []struct {
+		scheme keychain.Scheme
+		want   v2.SignatureScheme
+	}{
+		{keychain.SchemeEd25519, v2.SignatureScheme_ED25519},
+		{keychain.SchemeSecp256k1, v2.SignatureScheme_SECP256K1},
+		{keychain.SchemeSecp256r1, v2.SignatureScheme_SECP256R1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.scheme.Label(), func(t *testing.T) {
+			kp, err := keypair.Generate(tc.scheme)
+			if err != nil {
+				t.Fatalf("generate: %v", err)
+			}
+
+			sig, err := kp.SignTransaction(txBytes)
+			if err != nil {
+				t.Fatalf("sign transaction: %v", err)
+			}
+			if sig[0] != tc.scheme.AddressFlag() {
+				t.Fatalf("signature flag mismatch: got 0x%x", sig[0])
+			}
+			if err := kp.VerifyTransaction(txBytes, sig); err != nil {
+				t.Fatalf("verify transaction: %v", err)
+			}
+
+			tampered := append([]byte(nil), txBytes...)
+			tampered[len(tampered)-1] ^= 0x01
+			if err := kp.VerifyTransaction(tampered, sig); err == nil {
+				t.Fatalf("verify should fail for tampered transaction")
+			}
+			// A personal message signature over the same bytes must not verify
+			// as a transaction signature.
+			personal, err := kp.SignPersonalMessage(txBytes)
+			if err != nil {
+				t.Fatalf("sign personal message: %v", err)
+			}
+			if err := kp.VerifyTransaction(txBytes, personal); err == nil {
+				t.Fatalf("personal message signature verified as transaction")
+			}
+
+			userSig, err := keypair.SignTransactionUserSignature(kp, txBytes)
+			if err != nil {
+				t.Fatalf("user signature: %v", err)
+			}
+			if userSig.GetScheme() != tc.want || userSig.GetSimple().GetScheme() != tc.want {
+				t.Fatalf("unexpected scheme %v", userSig.GetScheme())
+			}
+			if string(userSig.GetSimple().GetPublicKey()) != string(kp.PublicKeyBytes()) {
+				t.Fatalf("public key mismatch")
+			}
+			if len(userSig.GetBcs().GetValue()) != len(sig) {
+				t.Fatalf("unexpected bcs length %d", len(userSig.GetBcs().GetValue()))
+			}
+		})
+	}
+}
+
+func TestTransactionSignatureDigest(t *testing.T) {
+	txBytes := testTransactionBytes(t)
+	kp, err := keypair.Generate(keychain.SchemeEd25519)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	sig, err := kp.SignTransaction(txBytes)
+	if err != nil {
+		t.Fatalf("sign transaction: %v", err)
+	}
+
+	// Transaction intent is (scope 0, version 0, app 0) followed by the raw
+	// TransactionData bytes.
+	digest := blake2b.Sum256(append([]byte{0, 0, 0}, txBytes...))
+	if !cryptoed25519.Verify(kp.PublicKeyBytes(), digest[:], sig[1:65]) {
+		t.Fatalf("signature does not cover the transaction intent digest")
+	}
+}
+
+func TestUserSignatureRejectsMalformedInput(t *testing.T) {
+	if _, err := keypair.UserSignature(nil); err == nil {
+		t.Fatalf("expected empty signature to fail")
+	}
+	if _, err := keypair.UserSignature([]byte{0x09, 0x01}); err == nil {
+		t.Fatalf("expected unknown flag to fail")
+	}
+	if _, err := keypair.UserSignature(make([]byte, 1+64+33)); err == nil {
+		t.Fatalf("expected ed25519 signature with wrong length to fail")
+	}
+}
@@ -0,0 +1,254 @@
+package movevalue
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/0xdraco/sui-go-sdk/types"
+)
+
+// maxDepth bounds the nesting of decoded values, matching Move's limit on
+// value depth.
+const maxDepth = 128
+
+var errUnexpectedEOF = errors.New("unexpected end of data")
+
+type reader struct {
+	buf   []byte
+	pos   int
+	depth int
+}
+
+func (r *reader) next(n int) ([]byte, error) {
+	if n < 0 || n > len(r.buf)-r.pos {
+		return nil, errUnexpectedEOF
+	}
+	b := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *reader) uleb() (int, error) {
+	v, n := binary.Uvarint(r.buf[r.pos:])
+	if n <= 0 || v > uint64(len(r.buf)) {
+		// A length can never exceed the remaining input, so larger values are corrupt.
+		return 0, errors.New("invalid ULEB128 length")
+	}
+	r.pos += n
+	return int(v), nil
+}
+
+// uint reads a little-endian unsigned integer of size bytes.
+func (r *reader) uint(size int) (*big.Int, error) {
+	raw, err := r.next(size)
+	if err != nil {
+		return nil, err
+	}
+	be := make([]byte, size)
+	for i, b := range raw {
+		be[size-1-i] = b
+	}
+	return new(big.Int).SetBytes(be), nil
+}
+
+func (d *Decoder) decode(ctx context.Context, r *reader, typ types.TypeTag) (Value, error) {
+	r.depth++
+	defer func() { r.depth-- }()
+	if r.depth > maxDepth {
+		return nil, errors.New("value nested too deeply")
+	}
+
+	switch {
+	case typ.Bool != nil:
+		b, err := r.next(1)
+		if err != nil {
+			return nil, err
+		}
+		if b[0] > 1 {
+			return nil, fmt.Errorf("invalid bool %d", b[0])
+		}
+		return b[0] == 1, nil
+	case typ.U8 != nil:
+		b, err := r.next(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0], nil
+	case typ.U16 != nil:
+		b, err := r.next(2)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.Uint16(b), nil
+	case typ.U32 != nil:
+		b, err := r.next(4)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.Uint32(b), nil
+	case typ.U64 != nil:
+		b, err := r.next(8)
+		if err != nil {
+			return nil, err
+		}
+		return binary.LittleEndian.Uint64(b), nil
+	case typ.U128 != nil:
+		return r.uint(16)
+	case typ.U256 != nil:
+		return r.uint(32)
+	case typ.Address != nil, typ.Signer != nil:
+		b, err := r.next(types.AddressLength)
+		if err != nil {
+			return nil, err
+		}
+		return types.Address(b), nil
+	case typ.Vector != nil:
+		return d.decodeVector(ctx, r, *typ.Vector)
+	case typ.Struct != nil:
+		return d.decodeDatatype(ctx, r, *typ.Struct)
+	default:
+		return nil, errors.New("invalid type tag")
+	}
+}
+
+func (d *Decoder) decodeVector(ctx context.Context, r *reader, elem types.TypeTag) (Value, error) {
+	n, err := r.uleb()
+	if err != nil {
+		return nil, err
+	}
+	if elem.U8 != nil {
+		b, err := r.next(n)
+		if err != nil {
+			return nil, err
+		}
+		return slices.Clone(b), nil
+	}
+	items := make([]Value, 0, min(n, len(r.buf)-r.pos))
+	for i := range n {
+		item, err := d.decode(ctx, r, elem)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (d *Decoder) decodeDatatype(ctx context.Context, r *reader, tag types.StructTag) (Value, error) {
+	desc, err := d.datatype(ctx, tag)
+	if err != nil {
+		return nil, err
+	}
+	if len(desc.GetTypeParameters()) != len(tag.TypeParams) {
+		return nil, fmt.Errorf("%s: expected %d type arguments, got %d", tag, len(desc.GetTypeParameters()), len(tag.TypeParams))
+	}
+
+	if desc.GetKind() == v2.DatatypeDescriptor_ENUM {
+		index, err := r.uleb()
+		if err != nil {
+			return nil, err
+		}
+		variant := variantAt(desc.GetVariants(), index)
+		if variant == nil {
+			return nil, fmt.Errorf("%s: invalid variant index %d", tag, index)
+		}
+		fields, err := d.decodeFields(ctx, r, tag, variant.GetFields())
+		if err != nil {
+			return nil, err
+		}
+		return &Variant{Type: tag, Name: variant.GetName(), Index: index, Fields: fields}, nil
+	}
+
+	fields, err := d.decodeFields(ctx, r, tag, desc.GetFields())
+	if err != nil {
+		return nil, err
+	}
+	return &Struct{Type: tag, Fields: fields}, nil
+}
+
+func variantAt(variants []*v2.VariantDescriptor, index int) *v2.VariantDescriptor {
+	for _, v := range variants {
+		if int(v.GetPosition()) == index {
+			return v
+		}
+	}
+	return nil
+}
+
+// decodeFields decodes fields in declaration order.
+func (d *Decoder) decodeFields(ctx context.Context, r *reader, owner types.StructTag, descs []*v2.FieldDescriptor) ([]Field, error) {
+	ordered := slices.Clone(descs)
+	slices.SortStableFunc(ordered, func(a, b *v2.FieldDescriptor) int {
+		return int(a.GetPosition()) - int(b.GetPosition())
+	})
+
+	fields := make([]Field, 0, len(ordered))
+	for _, desc := range ordered {
+		typ, err := fieldType(desc.GetType(), owner.TypeParams)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", owner, desc.GetName(), err)
+		}
+		value, err := d.decode(ctx, r, typ)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", owner, desc.GetName(), err)
+		}
+		fields = append(fields, Field{Name: desc.GetName(), Value: value})
+	}
+	return fields, nil
+}
+
+var primitiveSignatures = map[v2.OpenSignatureBody_Type]string{
+	v2.OpenSignatureBody_ADDRESS: "address",
+	v2.OpenSignatureBody_BOOL:    "bool",
+	v2.OpenSignatureBody_U8:      "u8",
+	v2.OpenSignatureBody_U16:     "u16",
+	v2.OpenSignatureBody_U32:     "u32",
+	v2.OpenSignatureBody_U64:     "u64",
+	v2.OpenSignatureBody_U128:    "u128",
+	v2.OpenSignatureBody_U256:    "u256",
+}
+
+// fieldType instantiates a field's signature with the type arguments of the
+// enclosing datatype.
+func fieldType(body *v2.OpenSignatureBody, typeArgs []types.TypeTag) (types.TypeTag, error) {
+	if name, ok := primitiveSignatures[body.GetType()]; ok {
+		return types.ParseTypeTag(name)
+	}
+	switch body.GetType() {
+	case v2.OpenSignatureBody_VECTOR:
+		if len(body.GetTypeParameterInstantiation()) != 1 {
+			return types.TypeTag{}, errors.New("vector signature without element type")
+		}
+		elem, err := fieldType(body.GetTypeParameterInstantiation()[0], typeArgs)
+		if err != nil {
+			return types.TypeTag{}, err
+		}
+		return types.TypeTag{Vector: &elem}, nil
+	case v2.OpenSignatureBody_DATATYPE:
+		tag, err := types.ParseStructTag(body.GetTypeName())
+		if err != nil {
+			return types.TypeTag{}, err
+		}
+		for _, param := range body.GetTypeParameterInstantiation() {
+			arg, err := fieldType(param, typeArgs)
+			if err != nil {
+				return types.TypeTag{}, err
+			}
+			tag.TypeParams = append(tag.TypeParams, arg)
+		}
+		return types.TypeTag{Struct: &tag}, nil
+	case v2.OpenSignatureBody_TYPE_PARAMETER:
+		index := int(body.GetTypeParameter())
+		if index >= len(typeArgs) {
+			return types.TypeTag{}, fmt.Errorf("type parameter %d out of range", index)
+		}
+		return typeArgs[index], nil
+	default:
+		return types.TypeTag{}, fmt.Errorf("unsupported signature %s", body.GetType())
+	}
+}
@@ -0,0 +1,177 @@
+// Package movevalue decodes the BCS contents of Move objects and events
+// without the fullnode's JSON rendering.
+//
+// A Decoder walks the bytes using datatype layouts from GetDatatype and
+// produces a Value tree in which u64 stays a uint64 and u128 and u256 are
+// *big.Int, so no precision is lost. Unmarshal then copies a Value into a Go
+// value by field name, or by position for structs declared with `bcs` tags.
+package movevalue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	sui "github.com/0xdraco/sui-go-sdk/grpc"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/0xdraco/sui-go-sdk/types"
+)
+
+// Value is a decoded Move value. Its dynamic type is one of:
+//
+//	bool                          bool
+//	u8, u16, u32, u64             uint8, uint16, uint32, uint64
+//	u128, u256                    *big.Int
+//	address                       types.Address
+//	vector<u8>                    []byte
+//	vector<T>                     []Value
+//	struct                        *Struct
+//	enum                          *Variant
+type Value any
+
+// Field is a named field of a struct or enum variant.
+type Field struct {
+	Name  string
+	Value Value
+}
+
+// Struct is a decoded Move struct.
+type Struct struct {
+	Type   types.StructTag
+	Fields []Field
+}
+
+// Field returns the value of the field called name.
+func (s *Struct) Field(name string) (Value, bool) {
+	return lookupField(s.Fields, name)
+}
+
+// Variant is a decoded Move enum value.
+type Variant struct {
+	Type types.StructTag
+	// Name and Index identify the variant.
+	Name   string
+	Index  int
+	Fields []Field
+}
+
+// Field returns the value of the variant's field called name.
+func (v *Variant) Field(name string) (Value, bool) {
+	return lookupField(v.Fields, name)
+}
+
+func lookupField(fields []Field, name string) (Value, bool) {
+	for _, f := range fields {
+		if f.Name == name {
+			return f.Value, true
+		}
+	}
+	return nil, false
+}
+
+// DatatypeSource looks up the layout of the datatype module::name defined in
+// packageID.
+type DatatypeSource interface {
+	Datatype(ctx context.Context, packageID, module, name string) (*v2.DatatypeDescriptor, error)
+}
+
+// DatatypeSourceFunc adapts a function to a DatatypeSource.
+type DatatypeSourceFunc func(ctx context.Context, packageID, module, name string) (*v2.DatatypeDescriptor, error)
+
+// Datatype calls f.
+func (f DatatypeSourceFunc) Datatype(ctx context.Context, packageID, module, name string) (*v2.DatatypeDescriptor, error) {
+	return f(ctx, packageID, module, name)
+}
+
+// NewClientSource returns a DatatypeSource backed by client.GetDatatype.
+func NewClientSource(client *sui.GRPCClient) DatatypeSource {
+	return DatatypeSourceFunc(func(ctx context.Context, packageID, module, name string) (*v2.DatatypeDescriptor, error) {
+		return client.GetDatatype(ctx, packageID, module, name)
+	})
+}
+
+// Decoder decodes BCS bytes into Values. Layouts are fetched from its source
+// once and cached, so a Decoder should be reused. It is safe for concurrent use.
+type Decoder struct {
+	source DatatypeSource
+
+	mu        sync.Mutex
+	datatypes map[string]*v2.DatatypeDescriptor
+}
+
+// NewDecoder returns a Decoder that looks up layouts in source.
+func NewDecoder(source DatatypeSource) *Decoder {
+	return &Decoder{source: source, datatypes: make(map[string]*v2.DatatypeDescriptor)}
+}
+
+// Decode decodes data as a value of type typ.
+func (d *Decoder) Decode(ctx context.Context, typ types.TypeTag, data []byte) (Value, error) {
+	if ctx == nil {
+		return nil, errors.New("nil context")
+	}
+	r := &reader{buf: data}
+	value, err := d.decode(ctx, r, typ)
+	if err != nil {
+		return nil, fmt.Errorf("movevalue: decode %s: %w", typ, err)
+	}
+	if r.pos != len(r.buf) {
+		return nil, fmt.Errorf("movevalue: decode %s: %d trailing bytes", typ, len(r.buf)-r.pos)
+	}
+	return value, nil
+}
+
+// DecodeInto decodes data as a value of type typ and unmarshals it into out.
+func (d *Decoder) DecodeInto(ctx context.Context, typ types.TypeTag, data []byte, out any) error {
+	value, err := d.Decode(ctx, typ, data)
+	if err != nil {
+		return err
+	}
+	return Unmarshal(value, out)
+}
+
+// DecodeObject decodes the contents of a Move object. The object must have
+// been fetched with the object_type and contents fields.
+func (d *Decoder) DecodeObject(ctx context.Context, object *v2.Object) (Value, error) {
+	if object.GetContents() == nil {
+		return nil, errors.New("movevalue: object has no contents")
+	}
+	typ, err := types.ParseTypeTag(object.GetObjectType())
+	if err != nil {
+		return nil, fmt.Errorf("movevalue: object type: %w", err)
+	}
+	return d.Decode(ctx, typ, object.GetContents().GetValue())
+}
+
+// DecodeEvent decodes the contents of an event. The event must have been
+// fetched with the event_type and contents fields.
+func (d *Decoder) DecodeEvent(ctx context.Context, event *v2.Event) (Value, error) {
+	if event.GetContents() == nil {
+		return nil, errors.New("movevalue: event has no contents")
+	}
+	typ, err := types.ParseTypeTag(event.GetEventType())
+	if err != nil {
+		return nil, fmt.Errorf("movevalue: event type: %w", err)
+	}
+	return d.Decode(ctx, typ, event.GetContents().GetValue())
+}
+
+// datatype returns the cached layout of tag, fetching it on first use.
+func (d *Decoder) datatype(ctx context.Context, tag types.StructTag) (*v2.DatatypeDescriptor, error) {
+	key := tag.Address.String() + "::" + tag.Module + "::" + tag.Name
+	d.mu.Lock()
+	desc, ok := d.datatypes[key]
+	d.mu.Unlock()
+	if ok {
+		return desc, nil
+	}
+
+	desc, err := d.source.Datatype(ctx, tag.Address.String(), tag.Module, tag.Name)
+	if err != nil {
+		return nil, fmt.Errorf("datatype %s: %w", key, err)
+	}
+	d.mu.Lock()
+	d.datatypes[key] = desc
+	d.mu.Unlock()
+	return desc, nil
+}
@@ -0,0 +1,294 @@
+package movevalue
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/0xdraco/sui-go-sdk/types"
+)
+
+func strPtr(s string) *string { return &s }
+
+func u32Ptr(v uint32) *uint32 { return &v }
+
+func sig(typ v2.OpenSignatureBody_Type) *v2.OpenSignatureBody {
+	return &v2.OpenSignatureBody{Type: &typ}
+}
+
+func sigVector(elem *v2.OpenSignatureBody) *v2.OpenSignatureBody {
+	body := sig(v2.OpenSignatureBody_VECTOR)
+	body.TypeParameterInstantiation = []*v2.OpenSignatureBody{elem}
+	return body
+}
+
+func sigDatatype(name string, args ...*v2.OpenSignatureBody) *v2.OpenSignatureBody {
+	body := sig(v2.OpenSignatureBody_DATATYPE)
+	body.TypeName = strPtr(name)
+	body.TypeParameterInstantiation = args
+	return body
+}
+
+func sigParam(index uint32) *v2.OpenSignatureBody {
+	body := sig(v2.OpenSignatureBody_TYPE_PARAMETER)
+	body.TypeParameter = u32Ptr(index)
+	return body
+}
+
+func fields(pairs ...any) []*v2.FieldDescriptor {
+	var out []*v2.FieldDescriptor
+	for i := 0; i < len(pairs); i += 2 {
+		out = append(out, &v2.FieldDescriptor{
+			Name:     strPtr(pairs[i].(string)),
+			Position: u32Ptr(uint32(i / 2)),
+			Type:     pairs[i+1].(*v2.OpenSignatureBody),
+		})
+	}
+	return out
+}
+
+func structDesc(typeParams int, f []*v2.FieldDescriptor) *v2.DatatypeDescriptor {
+	kind := v2.DatatypeDescriptor_STRUCT
+	return &v2.DatatypeDescriptor{Kind: &kind, TypeParameters: make([]*v2.TypeParameter, typeParams), Fields: f}
+}
+
+// testSource serves the layouts of a pool module and the framework types it uses.
+func testSource(calls *int) DatatypeSource {
+	enum := v2.DatatypeDescriptor_ENUM
+	datatypes := map[string]*v2.DatatypeDescriptor{
+		"0x1::string::String":   structDesc(0, fields("bytes", sigVector(sig(v2.OpenSignatureBody_U8)))),
+		"0x1::option::Option":   structDesc(1, fields("vec", sigVector(sigParam(0)))),
+		"0x2::object::UID":      structDesc(0, fields("id", sigDatatype("0x2::object::ID"))),
+		"0x2::object::ID":       structDesc(0, fields("bytes", sig(v2.OpenSignatureBody_ADDRESS))),
+		"0x2::balance::Balance": structDesc(1, fields("value", sig(v2.OpenSignatureBody_U64))),
+		"0xabc::pool::Pool": structDesc(1, fields(
+			"id", sigDatatype("0x2::object::UID"),
+			"balance", sigDatatype("0x2::balance::Balance", sigParam(0)),
+			"fee_bps", sig(v2.OpenSignatureBody_U64),
+			"total", sig(v2.OpenSignatureBody_U128),
+			"shares", sig(v2.OpenSignatureBody_U256),
+			"owners", sigVector(sig(v2.OpenSignatureBody_ADDRESS)),
+			"name", sigDatatype("0x1::string::String"),
+			"limit", sigDatatype("0x1::option::Option", sig(v2.OpenSignatureBody_U64)),
+			"state", sigDatatype("0xabc::pool::State"),
+		)),
+		"0xabc::pool::State": {
+			Kind: &enum,
+			Variants: []*v2.VariantDescriptor{
+				{Name: strPtr("Paused"), Position: u32Ptr(1), Fields: fields("until", sig(v2.OpenSignatureBody_U64))},
+				{Name: strPtr("Active"), Position: u32Ptr(0)},
+			},
+		},
+	}
+	byTag := make(map[string]*v2.DatatypeDescriptor, len(datatypes))
+	for name, desc := range datatypes {
+		byTag[mustTypeTag(name).String()] = desc
+	}
+	return DatatypeSourceFunc(func(_ context.Context, packageID, module, name string) (*v2.DatatypeDescriptor, error) {
+		*calls++
+		key := fmt.Sprintf("%s::%s::%s", packageID, module, name)
+		desc, ok := byTag[mustTypeTag(key).String()]
+		if !ok {
+			return nil, fmt.Errorf("unknown datatype %s", key)
+		}
+		return desc, nil
+	})
+}
+
+func uleb(n int) []byte { return binary.AppendUvarint(nil, uint64(n)) }
+
+func le(v *big.Int, size int) []byte {
+	out := make([]byte, size)
+	v.FillBytes(out)
+	for i, j := 0, size-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// testPoolBytes encodes a Pool<0x2::sui::SUI> that is paused until until.
+func testPoolBytes(id, owner types.Address, total, shares *big.Int, limit *uint64, until uint64) []byte {
+	var b []byte
+	b = append(b, id[:]...)
+	b = binary.LittleEndian.AppendUint64(b, 5_000)
+	b = binary.LittleEndian.AppendUint64(b, 30)
+	b = append(b, le(total, 16)...)
+	b = append(b, le(shares, 32)...)
+	b = append(b, uleb(1)...)
+	b = append(b, owner[:]...)
+	b = append(b, uleb(4)...)
+	b = append(b, "main"...)
+	if limit == nil {
+		b = append(b, uleb(0)...)
+	} else {
+		b = append(b, uleb(1)...)
+		b = binary.LittleEndian.AppendUint64(b, *limit)
+	}
+	b = append(b, uleb(1)...)
+	return binary.LittleEndian.AppendUint64(b, until)
+}
+
+var testPoolType = types.TypeTag{Struct: &types.StructTag{
+	Address:    types.MustParseAddress("0xabc"),
+	Module:     "pool",
+	Name:       "Pool",
+	TypeParams: []types.TypeTag{mustTypeTag("0x2::sui::SUI")},
+}}
+
+func mustTypeTag(raw string) types.TypeTag {
+	tag, err := types.ParseTypeTag(raw)
+	if err != nil {
+		panic(err)
+	}
+	return tag
+}
+
+type testPool struct {
+	ID      types.Address
+	Balance uint64
+	FeeBps  uint16 `move:"fee_bps"`
+	Total   big.Int
+	Shares  *big.Int
+	Owners  []string
+	Name    string
+	Limit   *uint64
+	State   struct {
+		Active *struct{}
+		Paused *struct{ Until uint64 }
+	}
+	Ignored string `bcs:"-"`
+}
+
+func TestDecodeObjectKeepsFullPrecision(t *testing.T) {
+	var calls int
+	decoder := NewDecoder(testSource(&calls))
+	id := types.MustParseAddress("0x1234")
+	owner := types.MustParseAddress("0x5678")
+	total, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+	shares := new(big.Int).Lsh(big.NewInt(1), 255)
+	limit := uint64(1<<64 - 1)
+	contents := testPoolBytes(id, owner, total, shares, &limit, 99)
+
+	value, err := decoder.DecodeObject(context.Background(), &v2.Object{
+		ObjectType: strPtr(testPoolType.String()),
+		Contents:   &v2.Bcs{Value: contents},
+	})
+	if err != nil {
+		t.Fatalf("decode object: %v", err)
+	}
+	pool, ok := value.(*Struct)
+	if !ok {
+		t.Fatalf("expected *Struct, got %T", value)
+	}
+	if got, _ := pool.Field("total"); got.(*big.Int).Cmp(total) != 0 {
+		t.Fatalf("unexpected total %v", got)
+	}
+	if got, _ := pool.Field("limit"); got.(*Struct).Fields[0].Value.([]Value)[0] != limit {
+		t.Fatalf("unexpected limit %v", got)
+	}
+	state, _ := pool.Field("state")
+	if v, ok := state.(*Variant); !ok || v.Name != "Paused" || v.Index != 1 {
+		t.Fatalf("unexpected state %#v", state)
+	}
+
+	var out testPool
+	out.Ignored = "kept"
+	if err := Unmarshal(value, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.ID != id || out.Balance != 5_000 || out.FeeBps != 30 || out.Name != "main" || out.Ignored != "kept" {
+		t.Fatalf("unexpected pool %+v", out)
+	}
+	if out.Total.Cmp(total) != 0 || out.Shares.Cmp(shares) != 0 {
+		t.Fatalf("unexpected big integers %v %v", &out.Total, out.Shares)
+	}
+	if len(out.Owners) != 1 || out.Owners[0] != owner.String() {
+		t.Fatalf("unexpected owners %v", out.Owners)
+	}
+	if out.Limit == nil || *out.Limit != limit {
+		t.Fatalf("unexpected limit %v", out.Limit)
+	}
+	if out.State.Active != nil || out.State.Paused == nil || out.State.Paused.Until != 99 {
+		t.Fatalf("unexpected state %+v", out.State)
+	}
+
+	before := calls
+	var again testPool
+	if err := decoder.DecodeInto(context.Background(), testPoolType, testPoolBytes(id, owner, total, shares, nil, 1), &again); err != nil {
+		t.Fatalf("decode into: %v", err)
+	}
+	if calls != before {
+		t.Fatalf("layouts fetched again: %d calls after %d", calls, before)
+	}
+	if again.Limit != nil {
+		t.Fatalf("expected nil limit for none, got %v", *again.Limit)
+	}
+}
+
+func TestDecodeRejectsMalformedData(t *testing.T) {
+	var calls int
+	decoder := NewDecoder(testSource(&calls))
+	one := big.NewInt(1)
+	contents := testPoolBytes(types.MustParseAddress("0x1"), types.MustParseAddress("0x2"), one, one, nil, 0)
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"truncated", contents[:len(contents)-1], "unexpected end of data"},
+		{"trailing", slices.Concat(contents, []byte{0}), "1 trailing bytes"},
+		{"variant", slices.Concat(contents[:len(contents)-9], []byte{2}), "invalid variant index 2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decoder.Decode(context.Background(), testPoolType, tt.data)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestUnmarshalReportsOverflow(t *testing.T) {
+	var out struct{ Total uint64 }
+	value := &Struct{Fields: []Field{{Name: "total", Value: new(big.Int).Lsh(big.NewInt(1), 64)}}}
+	err := Unmarshal(value, &out)
+	if err == nil || !strings.Contains(err.Error(), "total: 18446744073709551616 overflows uint64") {
+		t.Fatalf("expected overflow error, got %v", err)
+	}
+	if err := Unmarshal(value, out); err == nil {
+		t.Fatalf("expected error for non-pointer target")
+	}
+}
+
+func TestUnmarshalBcsStructByPosition(t *testing.T) {
+	value := &Struct{Fields: []Field{
+		{Name: "value", Value: uint64(5)},
+		{Name: "fee_bps", Value: uint64(30)},
+	}}
+	var out struct {
+		Amount  uint64
+		Skipped string `bcs:"-"`
+		Fee     uint16
+	}
+	out.Skipped = "kept"
+	if err := Unmarshal(value, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.Amount != 5 || out.Fee != 30 || out.Skipped != "kept" {
+		t.Fatalf("unexpected struct %+v", out)
+	}
+
+	var short struct {
+		Amount *uint64 `bcs:"optional"`
+	}
+	if err := Unmarshal(value, &short); err == nil || !strings.Contains(err.Error(), "cannot unmarshal 2 fields") {
+		t.Fatalf("expected field count error, got %v", err)
+	}
+}
@@ -0,0 +1,285 @@
+package movevalue
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/0xdraco/sui-go-sdk/types"
+)
+
+var (
+	bigIntType  = reflect.TypeOf(big.Int{})
+	addressType = reflect.TypeOf(types.Address{})
+)
+
+// Unmarshal copies value into the Go value out points to.
+//
+// Struct and variant fields are matched to Go struct fields by the name in a
+// `move:"name"` tag or, without one, by the field name ignoring case and
+// underscores; `move:"-"` or `bcs:"-"` skips a field. Unmatched fields on
+// either side are ignored. A Go struct declared for bcs.Unmarshal, that is one
+// with `bcs` tags and no `move` tags, is filled by position instead, like the
+// BCS decoder does: its exported fields not tagged `bcs:"-"` must match the
+// Move fields one to one. An enum variant is stored in the Go field named after it, which is
+// typically a pointer, so a Go struct with one field per variant mirrors the
+// enum; a string receives the variant name.
+//
+// Integers fit any Go integer type that can hold them, or big.Int. Move's
+// Option<T> maps to a pointer that is nil for none, and a struct with a single
+// field, such as String, UID or Balance<T>, may be unmarshalled directly into
+// the type of that field, so std::string::String fills a Go string and UID a
+// types.Address.
+func Unmarshal(value Value, out any) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return errors.New("movevalue: Unmarshal needs a non-nil pointer")
+	}
+	if err := assign(value, rv.Elem()); err != nil {
+		return fmt.Errorf("movevalue: %w", err)
+	}
+	return nil
+}
+
+func assign(value Value, dst reflect.Value) error {
+	if dst.Kind() == reflect.Interface && dst.NumMethod() == 0 {
+		dst.Set(reflect.ValueOf(&value).Elem())
+		return nil
+	}
+	if dst.Kind() == reflect.Pointer {
+		if s, ok := value.(*Struct); ok && isOption(s) {
+			items, _ := s.Fields[0].Value.([]Value)
+			if len(items) == 0 {
+				dst.SetZero()
+				return nil
+			}
+			value = items[0]
+		}
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return assign(value, dst.Elem())
+	}
+
+	switch v := value.(type) {
+	case bool:
+		if dst.Kind() == reflect.Bool {
+			dst.SetBool(v)
+			return nil
+		}
+	case uint8:
+		return assignUint(new(big.Int).SetUint64(uint64(v)), dst)
+	case uint16:
+		return assignUint(new(big.Int).SetUint64(uint64(v)), dst)
+	case uint32:
+		return assignUint(new(big.Int).SetUint64(uint64(v)), dst)
+	case uint64:
+		return assignUint(new(big.Int).SetUint64(v), dst)
+	case *big.Int:
+		return assignUint(v, dst)
+	case types.Address:
+		if dst.Type() == addressType {
+			dst.Set(reflect.ValueOf(v))
+			return nil
+		}
+		if dst.Kind() == reflect.String {
+			dst.SetString(v.String())
+			return nil
+		}
+	case []byte:
+		switch {
+		case dst.Kind() == reflect.String:
+			dst.SetString(string(v))
+			return nil
+		case dst.Kind() == reflect.Slice && dst.Type().Elem().Kind() == reflect.Uint8:
+			dst.SetBytes(append([]byte(nil), v...))
+			return nil
+		case dst.Kind() == reflect.Array && dst.Type().Elem().Kind() == reflect.Uint8 && dst.Len() == len(v):
+			reflect.Copy(dst, reflect.ValueOf(v))
+			return nil
+		}
+	case []Value:
+		return assignSlice(v, dst)
+	case *Struct:
+		if dst.Kind() == reflect.Struct && dst.Type() != bigIntType && dst.Type() != addressType {
+			return assignFields(v.Fields, dst)
+		}
+		if len(v.Fields) == 1 {
+			if err := assign(v.Fields[0].Value, dst); err != nil {
+				return fmt.Errorf("%s: %w", v.Type, err)
+			}
+			return nil
+		}
+	case *Variant:
+		return assignVariant(v, dst)
+	}
+	return fmt.Errorf("cannot unmarshal %s into %s", describe(value), dst.Type())
+}
+
+func isOption(s *Struct) bool {
+	return s.Type.Address == types.MustParseAddress("0x1") && s.Type.Module == "option" && s.Type.Name == "Option" && len(s.Fields) == 1
+}
+
+func assignUint(v *big.Int, dst reflect.Value) error {
+	switch dst.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !v.IsUint64() || dst.OverflowUint(v.Uint64()) {
+			return fmt.Errorf("%s overflows %s", v, dst.Type())
+		}
+		dst.SetUint(v.Uint64())
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !v.IsInt64() || dst.OverflowInt(v.Int64()) {
+			return fmt.Errorf("%s overflows %s", v, dst.Type())
+		}
+		dst.SetInt(v.Int64())
+		return nil
+	case reflect.String:
+		dst.SetString(v.String())
+		return nil
+	}
+	if dst.Type() == bigIntType {
+		dst.Set(reflect.ValueOf(new(big.Int).Set(v)).Elem())
+		return nil
+	}
+	return fmt.Errorf("cannot unmarshal integer into %s", dst.Type())
+}
+
+func assignSlice(items []Value, dst reflect.Value) error {
+	switch dst.Kind() {
+	case reflect.Slice:
+		slice := reflect.MakeSlice(dst.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := assign(item, slice.Index(i)); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		dst.Set(slice)
+		return nil
+	case reflect.Array:
+		if dst.Len() != len(items) {
+			return fmt.Errorf("cannot unmarshal %d elements into %s", len(items), dst.Type())
+		}
+		for i, item := range items {
+			if err := assign(item, dst.Index(i)); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("cannot unmarshal vector into %s", dst.Type())
+}
+
+func assignFields(fields []Field, dst reflect.Value) error {
+	if targets, ok := positionalFields(dst.Type()); ok {
+		if len(targets) != len(fields) {
+			return fmt.Errorf("cannot unmarshal %d fields into %s with %d bcs fields", len(fields), dst.Type(), len(targets))
+		}
+		for i, f := range fields {
+			if err := assign(f.Value, dst.Field(targets[i])); err != nil {
+				return fmt.Errorf("%s: %w", f.Name, err)
+			}
+		}
+		return nil
+	}
+	for _, f := range fields {
+		target, ok := fieldByName(dst, f.Name)
+		if !ok {
+			continue
+		}
+		if err := assign(f.Value, target); err != nil {
+			return fmt.Errorf("%s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+func assignVariant(v *Variant, dst reflect.Value) error {
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(v.Name)
+		return nil
+	case reflect.Struct:
+		target, ok := fieldByName(dst, v.Name)
+		if !ok {
+			return fmt.Errorf("%s has no field for variant %s", dst.Type(), v.Name)
+		}
+		if target.Kind() == reflect.Pointer {
+			target.Set(reflect.New(target.Type().Elem()))
+			target = target.Elem()
+		}
+		if target.Kind() != reflect.Struct {
+			return fmt.Errorf("cannot unmarshal variant %s into %s", v.Name, target.Type())
+		}
+		if err := assignFields(v.Fields, target); err != nil {
+			return fmt.Errorf("%s: %w", v.Name, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("cannot unmarshal variant %s into %s", v.Name, dst.Type())
+}
+
+// positionalFields returns the indices of the fields of t filled by position
+// and true when t carries `bcs` tags and no `move` tags.
+func positionalFields(t reflect.Type) ([]int, bool) {
+	var (
+		indices []int
+		tagged  bool
+	)
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if _, ok := sf.Tag.Lookup("move"); ok {
+			return nil, false
+		}
+		tag, ok := sf.Tag.Lookup("bcs")
+		tagged = tagged || ok
+		if sf.IsExported() && tag != "-" {
+			indices = append(indices, i)
+		}
+	}
+	return indices, tagged
+}
+
+// fieldByName finds the exported field of the struct dst that Move field name maps to.
+func fieldByName(dst reflect.Value, name string) (reflect.Value, bool) {
+	t := dst.Type()
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		tag, _, _ := strings.Cut(sf.Tag.Get("move"), ",")
+		switch {
+		case tag == "-" || sf.Tag.Get("bcs") == "-":
+			continue
+		case tag != "":
+			if tag == name {
+				return dst.Field(i), true
+			}
+		case normalizeName(sf.Name) == normalizeName(name):
+			return dst.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+func describe(value Value) string {
+	switch v := value.(type) {
+	case *Struct:
+		return v.Type.String()
+	case *Variant:
+		return v.Type.String() + "::" + v.Name
+	case []Value:
+		return "vector"
+	case []byte:
+		return "vector<u8>"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
@@ -0,0 +1,276 @@
+// Package multisig builds Sui multisig committees, derives their addresses and
+// combines member signatures into the serialized multisig format.
+//
+// A committee is a list of weighted member public keys plus a threshold. A
+// multisig signature is valid when the summed weight of the members that
+// signed reaches the threshold.
+//
+// Ref: https://docs.sui.io/concepts/cryptography/transaction-auth/multisig
+package multisig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/0xdraco/sui-go-sdk/keychain"
+	"github.com/0xdraco/sui-go-sdk/keypair"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/iotaledger/bcs-go"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// SignatureFlag prefixes multisig signatures and multisig address preimages.
+	SignatureFlag byte = 0x03
+	// MaxMembers is the largest committee Sui accepts.
+	MaxMembers = 10
+)
+
+// ErrInvalidCommittee indicates the committee violates Sui's multisig constraints.
+var ErrInvalidCommittee = errors.New("multisig: invalid committee")
+
+// Member is a committee member's public key and the weight of its signature.
+type Member struct {
+	Scheme    keychain.Scheme
+	PublicKey []byte
+	Weight    uint8
+}
+
+// NewMember returns a member for the public key of k.
+func NewMember(k keypair.Keypair, weight uint8) Member {
+	return Member{
+		Scheme:    k.Scheme(),
+		PublicKey: k.PublicKeyBytes(),
+		Weight:    weight,
+	}
+}
+
+// Committee is a validated multisig public key.
+type Committee struct {
+	members   []Member
+	threshold uint16
+}
+
+// NewCommittee validates members and threshold and returns the committee. Member
+// order is significant: it determines the address and the signature bitmap.
+func NewCommittee(threshold uint16, members ...Member) (*Committee, error) {
+	if len(members) == 0 || len(members) > MaxMembers {
+		return nil, fmt.Errorf("%w: committee must have 1 to %d members, got %d", ErrInvalidCommittee, MaxMembers, len(members))
+	}
+	if threshold == 0 {
+		return nil, fmt.Errorf("%w: threshold must be positive", ErrInvalidCommittee)
+	}
+
+	var total uint32
+	cloned := make([]Member, len(members))
+	for i, m := range members {
+		if m.Weight == 0 {
+			return nil, fmt.Errorf("%w: member %d has zero weight", ErrInvalidCommittee, i)
+		}
+		if size, ok := publicKeySize(m.Scheme); !ok || len(m.PublicKey) != size {
+			return nil, fmt.Errorf("%w: member %d has an invalid %s public key", ErrInvalidCommittee, i, m.Scheme.Label())
+		}
+		for j := 0; j < i; j++ {
+			if cloned[j].Scheme == m.Scheme && bytes.Equal(cloned[j].PublicKey, m.PublicKey) {
+				return nil, fmt.Errorf("%w: members %d and %d share a public key", ErrInvalidCommittee, j, i)
+			}
+		}
+		total += uint32(m.Weight)
+		cloned[i] = Member{Scheme: m.Scheme, PublicKey: append([]byte(nil), m.PublicKey...), Weight: m.Weight}
+	}
+	if uint32(threshold) > total {
+		return nil, fmt.Errorf("%w: threshold %d exceeds total weight %d", ErrInvalidCommittee, threshold, total)
+	}
+
+	return &Committee{members: cloned, threshold: threshold}, nil
+}
+
+// CommitteeFromProto converts an RPC committee into a Committee.
+func CommitteeFromProto(committee *v2.MultisigCommittee) (*Committee, error) {
+	if committee == nil {
+		return nil, fmt.Errorf("%w: nil committee", ErrInvalidCommittee)
+	}
+	if committee.GetThreshold() > 0xffff {
+		return nil, fmt.Errorf("%w: threshold %d overflows u16", ErrInvalidCommittee, committee.GetThreshold())
+	}
+
+	members := make([]Member, 0, len(committee.GetMembers()))
+	for i, m := range committee.GetMembers() {
+		scheme, ok := schemeFromProto(m.GetPublicKey().GetScheme())
+		if !ok {
+			return nil, fmt.Errorf("%w: member %d has unsupported scheme %s", ErrInvalidCommittee, i, m.GetPublicKey().GetScheme())
+		}
+		if m.GetWeight() > 0xff {
+			return nil, fmt.Errorf("%w: member %d weight %d overflows u8", ErrInvalidCommittee, i, m.GetWeight())
+		}
+		members = append(members, Member{
+			Scheme:    scheme,
+			PublicKey: m.GetPublicKey().GetPublicKey(),
+			Weight:    uint8(m.GetWeight()),
+		})
+	}
+
+	return NewCommittee(uint16(committee.GetThreshold()), members...)
+}
+
+// Members returns a copy of the committee members in committee order.
+func (c *Committee) Members() []Member {
+	out := make([]Member, len(c.members))
+	for i, m := range c.members {
+		out[i] = Member{Scheme: m.Scheme, PublicKey: append([]byte(nil), m.PublicKey...), Weight: m.Weight}
+	}
+	return out
+}
+
+// Threshold returns the weight required for a valid signature.
+func (c *Committee) Threshold() uint16 {
+	return c.threshold
+}
+
+// Address derives the committee's Sui address: the Blake2b-256 hash of the
+// multisig flag, the little-endian threshold, then each member's
+// flag || public key || weight.
+func (c *Committee) Address() (string, error) {
+	hasher, err := blake2b.New256(nil)
+	if err != nil {
+		return "", fmt.Errorf("multisig: blake2b init: %w", err)
+	}
+	hasher.Write([]byte{SignatureFlag, byte(c.threshold), byte(c.threshold >> 8)})
+	for _, m := range c.members {
+		hasher.Write([]byte{m.Scheme.AddressFlag()})
+		hasher.Write(m.PublicKey)
+		hasher.Write([]byte{m.Weight})
+	}
+	return fmt.Sprintf("0x%x", hasher.Sum(nil)), nil
+}
+
+// Bytes returns the BCS encoding of the committee (Sui's MultiSigPublicKey).
+func (c *Committee) Bytes() ([]byte, error) {
+	return bcs.Marshal(c)
+}
+
+// MarshalBCS encodes the committee as a vector of (PublicKey, weight) pairs
+// followed by the u16 threshold.
+func (c *Committee) MarshalBCS(e *bcs.Encoder) error {
+	e.WriteLen(len(c.members))
+	for _, m := range c.members {
+		e.WriteEnumIdx(publicKeyVariant(m.Scheme))
+		_, _ = e.Write(m.PublicKey)
+		e.WriteUint8(m.Weight)
+	}
+	e.WriteUint16(c.threshold)
+	return nil
+}
+
+func (c *Committee) UnmarshalBCS(d *bcs.Decoder) error {
+	n := d.ReadLen()
+	if n > MaxMembers {
+		return fmt.Errorf("%w: %d members", ErrInvalidCommittee, n)
+	}
+	members := make([]Member, 0, n)
+	for i := 0; i < n; i++ {
+		scheme, ok := schemeFromPublicKeyVariant(d.ReadEnumIdx())
+		if !ok {
+			return fmt.Errorf("%w: member %d has an unsupported public key type", ErrInvalidCommittee, i)
+		}
+		size, _ := publicKeySize(scheme)
+		pub, err := d.ReadN(size)
+		if err != nil {
+			return err
+		}
+		members = append(members, Member{Scheme: scheme, PublicKey: pub, Weight: d.ReadUint8()})
+	}
+	threshold := d.ReadUint16()
+	if err := d.Err(); err != nil {
+		return err
+	}
+
+	validated, err := NewCommittee(threshold, members...)
+	if err != nil {
+		return err
+	}
+	*c = *validated
+	return nil
+}
+
+// Proto converts the committee into its RPC representation.
+func (c *Committee) Proto() *v2.MultisigCommittee {
+	out := &v2.MultisigCommittee{Members: make([]*v2.MultisigMember, 0, len(c.members))}
+	for _, m := range c.members {
+		scheme := schemeToProto(m.Scheme)
+		weight := uint32(m.Weight)
+		out.Members = append(out.Members, &v2.MultisigMember{
+			PublicKey: &v2.MultisigMemberPublicKey{
+				Scheme:    &scheme,
+				PublicKey: append([]byte(nil), m.PublicKey...),
+			},
+			Weight: &weight,
+		})
+	}
+	threshold := uint32(c.threshold)
+	out.Threshold = &threshold
+	return out
+}
+
+// indexOf returns the position of the member with the given key, or -1.
+func (c *Committee) indexOf(scheme keychain.Scheme, publicKey []byte) int {
+	for i, m := range c.members {
+		if m.Scheme == scheme && bytes.Equal(m.PublicKey, publicKey) {
+			return i
+		}
+	}
+	return -1
+}
+
+func publicKeySize(scheme keychain.Scheme) (int, bool) {
+	switch scheme {
+	case keychain.SchemeEd25519:
+		return 32, true
+	case keychain.SchemeSecp256k1, keychain.SchemeSecp256r1:
+		return 33, true
+	default:
+		return 0, false
+	}
+}
+
+// publicKeyVariant maps a scheme to its index in Sui's PublicKey enum, which
+// coincides with the signature flag for the simple schemes.
+func publicKeyVariant(scheme keychain.Scheme) int {
+	return int(scheme.AddressFlag())
+}
+
+func schemeFromPublicKeyVariant(variant int) (keychain.Scheme, bool) {
+	if variant < 0 || variant > 0xff {
+		return 0, false
+	}
+	scheme, err := keychain.SchemeFromFlag(byte(variant))
+	if err != nil {
+		return 0, false
+	}
+	return scheme, true
+}
+
+func schemeToProto(scheme keychain.Scheme) v2.SignatureScheme {
+	switch scheme {
+	case keychain.SchemeSecp256k1:
+		return v2.SignatureScheme_SECP256K1
+	case keychain.SchemeSecp256r1:
+		return v2.SignatureScheme_SECP256R1
+	default:
+		return v2.SignatureScheme_ED25519
+	}
+}
+
+func schemeFromProto(scheme v2.SignatureScheme) (keychain.Scheme, bool) {
+	switch scheme {
+	case v2.SignatureScheme_ED25519:
+		return keychain.SchemeEd25519, true
+	case v2.SignatureScheme_SECP256K1:
+		return keychain.SchemeSecp256k1, true
+	case v2.SignatureScheme_SECP256R1:
+		return keychain.SchemeSecp256r1, true
+	default:
+		return 0, false
+	}
+}
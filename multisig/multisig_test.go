@@ -0,0 +1,219 @@
+package multisig_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/0xdraco/sui-go-sdk/keychain"
+	"github.com/0xdraco/sui-go-sdk/keypair"
+	"github.com/0xdraco/sui-go-sdk/multisig"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"golang.org/x/crypto/blake2b"
+)
+
+func testKeypairs(t *testing.T) []keypair.Keypair {
+	t.Helper()
+	schemes := []keychain.Scheme{keychain.SchemeEd25519, keychain.SchemeSecp256k1, keychain.SchemeSecp256r1}
+	out := make([]keypair.Keypair, 0, len(schemes))
+	for _, scheme := range schemes {
+		kp, err := keypair.Generate(scheme)
+		if err != nil {
+			t.Fatalf("generate %s: %v", scheme.Label(), err)
+		}
+		out = append(out, kp)
+	}
+	return out
+}
+
+func testCommittee(t *testing.T, keys []keypair.Keypair) *multisig.Committee {
+	t.Helper()
+	committee, err := multisig.NewCommittee(3,
+		multisig.NewMember(keys[0], 1),
+		multisig.NewMember(keys[1], 2),
+		multisig.NewMember(keys[2], 3),
+	)
+	if err != nil {
+		t.Fatalf("new committee: %v", err)
+	}
+	return committee
+}
+
+func TestCommitteeAddress(t *testing.T) {
+	keys := testKeypairs(t)
+	committee := testCommittee(t, keys)
+
+	preimage := []byte{multisig.SignatureFlag, 3, 0}
+	for i, k := range keys {
+		preimage = append(preimage, k.Scheme().AddressFlag())
+		preimage = append(preimage, k.PublicKeyBytes()...)
+		preimage = append(preimage, byte(i+1))
+	}
+	want := fmt.Sprintf("0x%x", blake2b.Sum256(preimage))
+
+	got, err := committee.Address()
+	if err != nil {
+		t.Fatalf("address: %v", err)
+	}
+	if got != want {
+		t.Fatalf("address mismatch\n\tgot:  %s\n\twant: %s", got, want)
+	}
+
+	reordered, err := multisig.NewCommittee(3,
+		multisig.NewMember(keys[1], 2),
+		multisig.NewMember(keys[0], 1),
+		multisig.NewMember(keys[2], 3),
+	)
+	if err != nil {
+		t.Fatalf("new committee: %v", err)
+	}
+	if other, _ := reordered.Address(); other == got {
+		t.Fatalf("member order must change the address")
+	}
+}
+
+func TestCommitteeValidation(t *testing.T) {
+	keys := testKeypairs(t)
+	cases := []struct {
+		name      string
+		threshold uint16
+		members   []multisig.Member
+	}{
+		{"no members", 1, nil},
+		{"zero threshold", 0, []multisig.Member{multisig.NewMember(keys[0], 1)}},
+		{"zero weight", 1, []multisig.Member{multisig.NewMember(keys[0], 0)}},
+		{"threshold above weight", 4, []multisig.Member{multisig.NewMember(keys[0], 1), multisig.NewMember(keys[1], 2)}},
+		{"duplicate key", 1, []multisig.Member{multisig.NewMember(keys[0], 1), multisig.NewMember(keys[0], 1)}},
+		{"bad key", 1, []multisig.Member{{Scheme: keychain.SchemeEd25519, PublicKey: []byte{1}, Weight: 1}}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := multisig.NewCommittee(tc.threshold, tc.members...); !errors.Is(err, multisig.ErrInvalidCommittee) {
+				t.Fatalf("expected ErrInvalidCommittee, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCombineAndVerify(t *testing.T) {
+	keys := testKeypairs(t)
+	committee := testCommittee(t, keys)
+	txBytes := []byte("not real transaction data, but signed bytes all the same")
+
+	sign := func(k keypair.Keypair) []byte {
+		sig, err := k.SignTransaction(txBytes)
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		return sig
+	}
+
+	// Members 2 and 0 reach weight 4 >= 3; order of partial signatures is irrelevant.
+	combined, err := committee.Combine(sign(keys[2]), sign(keys[0]))
+	if err != nil {
+		t.Fatalf("combine: %v", err)
+	}
+	if combined.Bitmap() != 0b101 {
+		t.Fatalf("unexpected bitmap %b", combined.Bitmap())
+	}
+	serialized, err := combined.Bytes()
+	if err != nil {
+		t.Fatalf("bytes: %v", err)
+	}
+	if serialized[0] != multisig.SignatureFlag {
+		t.Fatalf("unexpected flag 0x%02x", serialized[0])
+	}
+	if err := multisig.VerifyTransaction(txBytes, serialized); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+
+	parsed, err := multisig.ParseSignature(serialized)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	reencoded, _ := parsed.Bytes()
+	if !bytes.Equal(reencoded, serialized) {
+		t.Fatalf("round trip mismatch")
+	}
+	wantAddr, _ := committee.Address()
+	if gotAddr, _ := parsed.Committee().Address(); gotAddr != wantAddr {
+		t.Fatalf("parsed committee address mismatch")
+	}
+
+	tampered := append([]byte(nil), txBytes...)
+	tampered[0] ^= 0x01
+	if err := multisig.VerifyTransaction(tampered, serialized); err == nil {
+		t.Fatalf("verify should fail for tampered transaction")
+	}
+	if err := multisig.VerifyPersonalMessage(txBytes, serialized); err == nil {
+		t.Fatalf("transaction signature must not verify as a personal message")
+	}
+
+	lowWeight, err := committee.Combine(sign(keys[1]))
+	if err != nil {
+		t.Fatalf("combine: %v", err)
+	}
+	lowSerialized, _ := lowWeight.Bytes()
+	if err := multisig.VerifyTransaction(txBytes, lowSerialized); !errors.Is(err, multisig.ErrThresholdNotMet) {
+		t.Fatalf("expected ErrThresholdNotMet, got %v", err)
+	}
+}
+
+func TestCombineRejectsUnknownAndDuplicateSigners(t *testing.T) {
+	keys := testKeypairs(t)
+	committee := testCommittee(t, keys)
+	outsider, err := keypair.Generate(keychain.SchemeEd25519)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	message := []byte("hello")
+	outsiderSig, _ := outsider.SignPersonalMessage(message)
+	if _, err := committee.Combine(outsiderSig); !errors.Is(err, multisig.ErrUnknownSigner) {
+		t.Fatalf("expected ErrUnknownSigner, got %v", err)
+	}
+
+	memberSig, _ := keys[0].SignPersonalMessage(message)
+	if _, err := committee.Combine(memberSig, memberSig); !errors.Is(err, multisig.ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestUserSignatureAndProto(t *testing.T) {
+	keys := testKeypairs(t)
+	committee := testCommittee(t, keys)
+	message := []byte("hello")
+
+	sig2, _ := keys[2].SignPersonalMessage(message)
+	combined, err := committee.Combine(sig2)
+	if err != nil {
+		t.Fatalf("combine: %v", err)
+	}
+	userSig, err := combined.UserSignature()
+	if err != nil {
+		t.Fatalf("user signature: %v", err)
+	}
+	if userSig.GetScheme() != v2.SignatureScheme_MULTISIG {
+		t.Fatalf("unexpected scheme %v", userSig.GetScheme())
+	}
+	aggregated := userSig.GetMultisig()
+	if aggregated.GetBitmap() != 0b100 || len(aggregated.GetSignatures()) != 1 {
+		t.Fatalf("unexpected aggregated signature %v", aggregated)
+	}
+	if aggregated.GetSignatures()[0].GetScheme() != v2.SignatureScheme_SECP256R1 {
+		t.Fatalf("unexpected member scheme %v", aggregated.GetSignatures()[0].GetScheme())
+	}
+	if err := multisig.VerifyPersonalMessage(message, userSig.GetBcs().GetValue()); err != nil {
+		t.Fatalf("verify bcs payload: %v", err)
+	}
+
+	fromProto, err := multisig.CommitteeFromProto(aggregated.GetCommittee())
+	if err != nil {
+		t.Fatalf("committee from proto: %v", err)
+	}
+	want, _ := committee.Address()
+	if got, _ := fromProto.Address(); got != want {
+		t.Fatalf("committee proto round trip changed the address")
+	}
+}
@@ -0,0 +1,279 @@
+package multisig
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+
+	ed25519keys "github.com/0xdraco/sui-go-sdk/cryptography/ed25519"
+	"github.com/0xdraco/sui-go-sdk/cryptography/personalmsg"
+	secp256k1keys "github.com/0xdraco/sui-go-sdk/cryptography/secp256k1"
+	secp256r1keys "github.com/0xdraco/sui-go-sdk/cryptography/secp256r1"
+	"github.com/0xdraco/sui-go-sdk/cryptography/txmsg"
+	"github.com/0xdraco/sui-go-sdk/keychain"
+	"github.com/0xdraco/sui-go-sdk/keypair"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/iotaledger/bcs-go"
+)
+
+const memberSignatureSize = 64
+
+var (
+	// ErrUnknownSigner indicates a partial signature was produced by a key outside the committee.
+	ErrUnknownSigner = errors.New("multisig: signer is not a committee member")
+	// ErrThresholdNotMet indicates the signing members do not carry enough weight.
+	ErrThresholdNotMet = errors.New("multisig: signature weight below threshold")
+	// ErrInvalidSignature indicates a malformed multisig signature.
+	ErrInvalidSignature = errors.New("multisig: invalid signature")
+)
+
+// MemberSignature is one committee member's signature inside a multisig.
+type MemberSignature struct {
+	Scheme    keychain.Scheme
+	Signature []byte
+}
+
+// Signature is an aggregated multisig signature together with its committee.
+type Signature struct {
+	signatures []MemberSignature
+	bitmap     uint16
+	committee  *Committee
+}
+
+// Combine aggregates serialized member signatures (`flag || sig || pubkey`, as
+// returned by SignTransaction or SignPersonalMessage) into a multisig
+// signature. Signatures may be given in any order but each member may sign once.
+// Combine does not check the threshold; use Verify for that.
+func (c *Committee) Combine(signatures ...[]byte) (*Signature, error) {
+	if len(signatures) == 0 {
+		return nil, fmt.Errorf("%w: no member signatures", ErrInvalidSignature)
+	}
+
+	bySigner := make(map[int]MemberSignature, len(signatures))
+	var bitmap uint16
+	for i, serialized := range signatures {
+		if len(serialized) < 1+memberSignatureSize {
+			return nil, fmt.Errorf("%w: member signature %d is too short", ErrInvalidSignature, i)
+		}
+		scheme, err := keychain.SchemeFromFlag(serialized[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: member signature %d: %v", ErrInvalidSignature, i, err)
+		}
+		index := c.indexOf(scheme, serialized[1+memberSignatureSize:])
+		if index < 0 {
+			return nil, fmt.Errorf("%w: member signature %d", ErrUnknownSigner, i)
+		}
+		if bitmap&(1<<index) != 0 {
+			return nil, fmt.Errorf("%w: member %d signed more than once", ErrInvalidSignature, index)
+		}
+		bitmap |= 1 << index
+		bySigner[index] = MemberSignature{
+			Scheme:    scheme,
+			Signature: append([]byte(nil), serialized[1:1+memberSignatureSize]...),
+		}
+	}
+
+	out := &Signature{bitmap: bitmap, committee: c}
+	for index := range c.members {
+		if sig, ok := bySigner[index]; ok {
+			out.signatures = append(out.signatures, sig)
+		}
+	}
+	return out, nil
+}
+
+// ParseSignature decodes a serialized multisig signature (`0x03 || BCS(MultiSig)`).
+func ParseSignature(serialized []byte) (*Signature, error) {
+	if len(serialized) == 0 || serialized[0] != SignatureFlag {
+		return nil, fmt.Errorf("%w: missing multisig flag", ErrInvalidSignature)
+	}
+	sig, err := bcs.Unmarshal[Signature](serialized[1:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return &sig, nil
+}
+
+// Committee returns the committee the signature was produced for.
+func (s *Signature) Committee() *Committee {
+	return s.committee
+}
+
+// Bitmap reports which committee members signed; bit i is set for member i.
+func (s *Signature) Bitmap() uint16 {
+	return s.bitmap
+}
+
+// Signatures returns the member signatures in committee order.
+func (s *Signature) Signatures() []MemberSignature {
+	out := make([]MemberSignature, len(s.signatures))
+	for i, sig := range s.signatures {
+		out[i] = MemberSignature{Scheme: sig.Scheme, Signature: append([]byte(nil), sig.Signature...)}
+	}
+	return out
+}
+
+// Bytes returns the serialized signature `0x03 || BCS(MultiSig)` accepted by Sui.
+func (s *Signature) Bytes() ([]byte, error) {
+	encoded, err := bcs.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("multisig: marshal: %w", err)
+	}
+	return append([]byte{SignatureFlag}, encoded...), nil
+}
+
+// MarshalBCS encodes the member signatures, the u16 bitmap and the committee.
+func (s *Signature) MarshalBCS(e *bcs.Encoder) error {
+	if s.committee == nil {
+		return fmt.Errorf("%w: missing committee", ErrInvalidSignature)
+	}
+	e.WriteLen(len(s.signatures))
+	for _, sig := range s.signatures {
+		e.WriteEnumIdx(publicKeyVariant(sig.Scheme))
+		_, _ = e.Write(sig.Signature)
+	}
+	e.WriteUint16(s.bitmap)
+	return s.committee.MarshalBCS(e)
+}
+
+func (s *Signature) UnmarshalBCS(d *bcs.Decoder) error {
+	n := d.ReadLen()
+	if n > MaxMembers {
+		return fmt.Errorf("%w: %d member signatures", ErrInvalidSignature, n)
+	}
+	sigs := make([]MemberSignature, 0, n)
+	for i := 0; i < n; i++ {
+		scheme, ok := schemeFromPublicKeyVariant(d.ReadEnumIdx())
+		if !ok {
+			return fmt.Errorf("%w: member signature %d has an unsupported scheme", ErrInvalidSignature, i)
+		}
+		raw, err := d.ReadN(memberSignatureSize)
+		if err != nil {
+			return err
+		}
+		sigs = append(sigs, MemberSignature{Scheme: scheme, Signature: raw})
+	}
+	bitmap := d.ReadUint16()
+	if err := d.Err(); err != nil {
+		return err
+	}
+
+	committee := &Committee{}
+	if err := committee.UnmarshalBCS(d); err != nil {
+		return err
+	}
+	if bits.OnesCount16(bitmap) != len(sigs) {
+		return fmt.Errorf("%w: bitmap does not match %d signatures", ErrInvalidSignature, len(sigs))
+	}
+	if bitmap>>len(committee.members) != 0 {
+		return fmt.Errorf("%w: bitmap references unknown members", ErrInvalidSignature)
+	}
+
+	*s = Signature{signatures: sigs, bitmap: bitmap, committee: committee}
+	return nil
+}
+
+// UserSignature converts the signature into the form expected by
+// ExecuteTransactionRequest.Signatures.
+func (s *Signature) UserSignature() (*v2.UserSignature, error) {
+	serialized, err := s.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	aggregated := &v2.MultisigAggregatedSignature{
+		Signatures: make([]*v2.MultisigMemberSignature, 0, len(s.signatures)),
+		Committee:  s.committee.Proto(),
+	}
+	for _, sig := range s.signatures {
+		scheme := schemeToProto(sig.Scheme)
+		aggregated.Signatures = append(aggregated.Signatures, &v2.MultisigMemberSignature{
+			Scheme:    &scheme,
+			Signature: append([]byte(nil), sig.Signature...),
+		})
+	}
+	bitmap := uint32(s.bitmap)
+	aggregated.Bitmap = &bitmap
+
+	name := keypair.UserSignatureBcsName
+	scheme := v2.SignatureScheme_MULTISIG
+	return &v2.UserSignature{
+		Bcs:       &v2.Bcs{Name: &name, Value: serialized},
+		Scheme:    &scheme,
+		Signature: &v2.UserSignature_Multisig{Multisig: aggregated},
+	}, nil
+}
+
+// VerifyDigest checks every member signature against the intent digest and
+// that the signing members reach the committee threshold.
+func (s *Signature) VerifyDigest(digest [32]byte) error {
+	if s.committee == nil {
+		return fmt.Errorf("%w: missing committee", ErrInvalidSignature)
+	}
+	if bits.OnesCount16(s.bitmap) != len(s.signatures) {
+		return fmt.Errorf("%w: bitmap does not match %d signatures", ErrInvalidSignature, len(s.signatures))
+	}
+
+	var weight uint32
+	next := 0
+	for index, member := range s.committee.members {
+		if s.bitmap&(1<<index) == 0 {
+			continue
+		}
+		sig := s.signatures[next]
+		next++
+		if sig.Scheme != member.Scheme {
+			return fmt.Errorf("%w: member %d signed with %s, expected %s", ErrInvalidSignature, index, sig.Scheme.Label(), member.Scheme.Label())
+		}
+		if err := verifyMember(member, digest, sig.Signature); err != nil {
+			return fmt.Errorf("multisig: member %d: %w", index, err)
+		}
+		weight += uint32(member.Weight)
+	}
+	if next != len(s.signatures) {
+		return fmt.Errorf("%w: bitmap references unknown members", ErrInvalidSignature)
+	}
+	if weight < uint32(s.committee.threshold) {
+		return fmt.Errorf("%w: weight %d, threshold %d", ErrThresholdNotMet, weight, s.committee.threshold)
+	}
+	return nil
+}
+
+// VerifyPersonalMessage verifies a serialized multisig signature over a personal message.
+func VerifyPersonalMessage(message []byte, serialized []byte) error {
+	digest, err := personalmsg.Digest(message)
+	if err != nil {
+		return fmt.Errorf("multisig: %w", err)
+	}
+	return verifySerialized(digest, serialized)
+}
+
+// VerifyTransaction verifies a serialized multisig signature over BCS-encoded TransactionData.
+func VerifyTransaction(txBytes []byte, serialized []byte) error {
+	digest, err := txmsg.Digest(txBytes)
+	if err != nil {
+		return fmt.Errorf("multisig: %w", err)
+	}
+	return verifySerialized(digest, serialized)
+}
+
+func verifySerialized(digest [32]byte, serialized []byte) error {
+	sig, err := ParseSignature(serialized)
+	if err != nil {
+		return err
+	}
+	return sig.VerifyDigest(digest)
+}
+
+func verifyMember(member Member, digest [32]byte, signature []byte) error {
+	switch member.Scheme {
+	case keychain.SchemeEd25519:
+		return ed25519keys.VerifyDigest(member.PublicKey, digest, signature)
+	case keychain.SchemeSecp256k1:
+		return secp256k1keys.VerifyDigest(member.PublicKey, digest, signature)
+	case keychain.SchemeSecp256r1:
+		return secp256r1keys.VerifyDigest(member.PublicKey, digest, signature)
+	default:
+		return fmt.Errorf("unsupported scheme %d", member.Scheme)
+	}
+}
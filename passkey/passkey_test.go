@@ -0,0 +1,231 @@
+package passkey_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/0xdraco/sui-go-sdk/passkey"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"golang.org/x/crypto/blake2b"
+)
+
+var testTxBytes = []byte{0x00, 0x01, 0x02, 0x03, 0x04}
+
+func testSigner(t *testing.T) (*passkey.Signer, *passkey.SoftwareAuthenticator) {
+	t.Helper()
+	auth, err := passkey.NewSoftwareAuthenticator("example.com", "https://example.com")
+	if err != nil {
+		t.Fatalf("authenticator: %v", err)
+	}
+	signer, err := passkey.NewSigner(auth)
+	if err != nil {
+		t.Fatalf("signer: %v", err)
+	}
+	return signer, auth
+}
+
+func TestAddressHashesFlagAndPublicKey(t *testing.T) {
+	signer, _ := testSigner(t)
+	address, err := signer.SuiAddress()
+	if err != nil {
+		t.Fatalf("address: %v", err)
+	}
+	sum := blake2b.Sum256(append([]byte{passkey.SignatureFlag}, signer.PublicKeyBytes()...))
+	if address != fmt.Sprintf("0x%x", sum) {
+		t.Fatalf("address = %s, want 0x%x", address, sum)
+	}
+	if _, err := passkey.Address(make([]byte, 33)); err == nil {
+		t.Fatalf("expected error for invalid public key")
+	}
+}
+
+func TestSignAndVerifyTransaction(t *testing.T) {
+	signer, _ := testSigner(t)
+	ctx := context.Background()
+
+	serialized, err := signer.SignTransaction(ctx, testTxBytes)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if serialized[0] != passkey.SignatureFlag {
+		t.Fatalf("flag = %#x", serialized[0])
+	}
+	if err := passkey.VerifyTransaction(testTxBytes, serialized); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if err := passkey.VerifyTransaction(append(testTxBytes, 0x05), serialized); !errors.Is(err, passkey.ErrChallengeMismatch) {
+		t.Fatalf("verify over other transaction: %v", err)
+	}
+	if err := passkey.VerifyPersonalMessage(testTxBytes, serialized); !errors.Is(err, passkey.ErrChallengeMismatch) {
+		t.Fatalf("transaction signature accepted as personal message: %v", err)
+	}
+}
+
+func TestSignAndVerifyPersonalMessage(t *testing.T) {
+	signer, _ := testSigner(t)
+	message := []byte("hello passkey")
+
+	serialized, err := signer.SignPersonalMessage(context.Background(), message)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if err := passkey.VerifyPersonalMessage(message, serialized); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+}
+
+func TestSignatureRoundTrip(t *testing.T) {
+	signer, _ := testSigner(t)
+	serialized, err := signer.SignTransaction(context.Background(), testTxBytes)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	sig, err := passkey.ParseSignature(serialized)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if !bytes.Equal(sig.PublicKey, signer.PublicKeyBytes()) {
+		t.Fatalf("public key not decoded")
+	}
+	if len(sig.AuthenticatorData) != 37 {
+		t.Fatalf("authenticator data length = %d", len(sig.AuthenticatorData))
+	}
+	if !strings.Contains(sig.ClientDataJSON, `"type":"webauthn.get"`) {
+		t.Fatalf("client data = %s", sig.ClientDataJSON)
+	}
+	again, err := sig.Bytes()
+	if err != nil {
+		t.Fatalf("bytes: %v", err)
+	}
+	if !bytes.Equal(again, serialized) {
+		t.Fatalf("round trip mismatch")
+	}
+	address, _ := sig.Address()
+	want, _ := signer.SuiAddress()
+	if address != want {
+		t.Fatalf("address = %s, want %s", address, want)
+	}
+
+	sig.AuthenticatorData[len(sig.AuthenticatorData)-1] ^= 0xff
+	tampered, _ := sig.Bytes()
+	if err := passkey.VerifyTransaction(testTxBytes, tampered); err == nil {
+		t.Fatalf("tampered authenticator data verified")
+	}
+}
+
+func TestSignatureUserSignature(t *testing.T) {
+	signer, _ := testSigner(t)
+	serialized, err := signer.SignTransaction(context.Background(), testTxBytes)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	sig, err := passkey.ParseSignature(serialized)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	out, err := sig.UserSignature()
+	if err != nil {
+		t.Fatalf("user signature: %v", err)
+	}
+	if out.GetScheme() != v2.SignatureScheme_PASSKEY {
+		t.Fatalf("scheme = %s", out.GetScheme())
+	}
+	if !bytes.Equal(out.GetBcs().GetValue(), serialized) {
+		t.Fatalf("bcs value does not match serialized signature")
+	}
+	auth := out.GetPasskey()
+	if auth.GetClientDataJson() != sig.ClientDataJSON || !bytes.Equal(auth.GetAuthenticatorData(), sig.AuthenticatorData) {
+		t.Fatalf("webauthn data not copied")
+	}
+	if auth.GetSignature().GetScheme() != v2.SignatureScheme_SECP256R1 || !bytes.Equal(auth.GetSignature().GetPublicKey(), sig.PublicKey) {
+		t.Fatalf("simple signature not copied: %v", auth.GetSignature())
+	}
+}
+
+// highSAuthenticator flips the authenticator's signature to its high-s form.
+type highSAuthenticator struct {
+	*passkey.SoftwareAuthenticator
+}
+
+func (a highSAuthenticator) GetAssertion(ctx context.Context, challenge []byte) (*passkey.Assertion, error) {
+	assertion, err := a.SoftwareAuthenticator.GetAssertion(ctx, challenge)
+	if err != nil {
+		return nil, err
+	}
+	var sig struct{ R, S *big.Int }
+	if _, err := asn1.Unmarshal(assertion.Signature, &sig); err != nil {
+		return nil, err
+	}
+	order := elliptic.P256().Params().N
+	if sig.S.Cmp(new(big.Int).Rsh(order, 1)) <= 0 {
+		sig.S.Sub(order, sig.S)
+	}
+	assertion.Signature, err = asn1.Marshal(sig)
+	return assertion, err
+}
+
+func TestSignerNormalizesHighS(t *testing.T) {
+	_, auth := testSigner(t)
+	signer, err := passkey.NewSigner(highSAuthenticator{auth})
+	if err != nil {
+		t.Fatalf("signer: %v", err)
+	}
+	serialized, err := signer.SignTransaction(context.Background(), testTxBytes)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if err := passkey.VerifyTransaction(testTxBytes, serialized); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+
+	sig, _ := passkey.ParseSignature(serialized)
+	s := new(big.Int).SetBytes(sig.Signature[32:])
+	s.Sub(elliptic.P256().Params().N, s)
+	s.FillBytes(sig.Signature[32:])
+	highS, _ := sig.Bytes()
+	if err := passkey.VerifyTransaction(testTxBytes, highS); !errors.Is(err, passkey.ErrInvalidSignature) {
+		t.Fatalf("high-s signature: %v", err)
+	}
+}
+
+// wrongChallengeAuthenticator signs a different challenge than requested.
+type wrongChallengeAuthenticator struct {
+	*passkey.SoftwareAuthenticator
+}
+
+func (a wrongChallengeAuthenticator) GetAssertion(ctx context.Context, challenge []byte) (*passkey.Assertion, error) {
+	return a.SoftwareAuthenticator.GetAssertion(ctx, append([]byte{0x00}, challenge...))
+}
+
+func TestSignerRejectsForeignChallenge(t *testing.T) {
+	_, auth := testSigner(t)
+	signer, err := passkey.NewSigner(wrongChallengeAuthenticator{auth})
+	if err != nil {
+		t.Fatalf("signer: %v", err)
+	}
+	if _, err := signer.SignTransaction(context.Background(), testTxBytes); !errors.Is(err, passkey.ErrChallengeMismatch) {
+		t.Fatalf("expected challenge mismatch, got %v", err)
+	}
+}
+
+func TestParseSignatureRejectsMalformedInput(t *testing.T) {
+	cases := map[string][]byte{
+		"empty":      nil,
+		"wrong flag": {0x02, 0x00},
+		"truncated":  {passkey.SignatureFlag, 0x05, 0x01},
+	}
+	for name, input := range cases {
+		if _, err := passkey.ParseSignature(input); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+// Package passkey builds and verifies Sui passkey (WebAuthn) signatures and
+// derives passkey addresses.
+//
+// A passkey signs the WebAuthn assertion payload
+// `authenticatorData || sha256(clientDataJSON)` with a secp256r1 key, where
+// clientDataJSON carries the base64url-encoded intent digest as its challenge.
+//
+// Ref: https://docs.sui.io/concepts/cryptography/passkeys
+package passkey
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xdraco/sui-go-sdk/cryptography/personalmsg"
+	secp256r1keys "github.com/0xdraco/sui-go-sdk/cryptography/secp256r1"
+	"github.com/0xdraco/sui-go-sdk/cryptography/txmsg"
+	"github.com/0xdraco/sui-go-sdk/keychain"
+	"github.com/0xdraco/sui-go-sdk/keypair"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/iotaledger/bcs-go"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// SignatureFlag prefixes passkey signatures and passkey address preimages.
+	SignatureFlag byte = 0x06
+	// PublicKeySize is the length of a compressed secp256r1 public key.
+	PublicKeySize = 33
+
+	signatureSize     = 64
+	clientDataTypeGet = "webauthn.get"
+)
+
+var (
+	// ErrInvalidSignature indicates a malformed passkey signature.
+	ErrInvalidSignature = errors.New("passkey: invalid signature")
+	// ErrChallengeMismatch indicates clientDataJSON was not produced for the signed message.
+	ErrChallengeMismatch = errors.New("passkey: challenge does not match intent digest")
+)
+
+// Address derives the Sui address of a passkey: the Blake2b-256 hash of the
+// passkey flag followed by the compressed secp256r1 public key.
+func Address(publicKey []byte) (string, error) {
+	if _, err := secp256r1keys.ParsePublicKey(publicKey); err != nil {
+		return "", fmt.Errorf("passkey: %w", err)
+	}
+	sum := blake2b.Sum256(append([]byte{SignatureFlag}, publicKey...))
+	return fmt.Sprintf("0x%x", sum), nil
+}
+
+// Signature is a passkey signature: the WebAuthn authenticator data and client
+// data together with the secp256r1 signature and public key of the passkey.
+type Signature struct {
+	AuthenticatorData []byte
+	ClientDataJSON    string
+	// Signature is the normalized (low-s) 64-byte r || s signature.
+	Signature []byte
+	PublicKey []byte
+}
+
+// ParseSignature decodes a serialized passkey signature (`0x06 || BCS(PasskeyAuthenticator)`).
+func ParseSignature(serialized []byte) (*Signature, error) {
+	if len(serialized) == 0 || serialized[0] != SignatureFlag {
+		return nil, fmt.Errorf("%w: missing passkey flag", ErrInvalidSignature)
+	}
+	sig, err := bcs.Unmarshal[Signature](serialized[1:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	return &sig, nil
+}
+
+// Bytes returns the serialized signature accepted by Sui.
+func (s *Signature) Bytes() ([]byte, error) {
+	encoded, err := bcs.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("passkey: marshal: %w", err)
+	}
+	return append([]byte{SignatureFlag}, encoded...), nil
+}
+
+// MarshalBCS encodes the authenticator data, the client data JSON and the
+// secp256r1 user signature `0x02 || sig || pubkey`.
+func (s *Signature) MarshalBCS(e *bcs.Encoder) error {
+	if len(s.Signature) != signatureSize || len(s.PublicKey) != PublicKeySize {
+		return fmt.Errorf("%w: signature must be %d bytes and public key %d bytes", ErrInvalidSignature, signatureSize, PublicKeySize)
+	}
+	e.WriteLen(len(s.AuthenticatorData))
+	_, _ = e.Write(s.AuthenticatorData)
+	e.WriteLen(len(s.ClientDataJSON))
+	_, _ = e.Write([]byte(s.ClientDataJSON))
+	e.WriteLen(1 + signatureSize + PublicKeySize)
+	e.WriteUint8(keychain.SchemeSecp256r1.AddressFlag())
+	_, _ = e.Write(s.Signature)
+	_, _ = e.Write(s.PublicKey)
+	return nil
+}
+
+func (s *Signature) UnmarshalBCS(d *bcs.Decoder) error {
+	authenticatorData, err := d.ReadN(d.ReadLen())
+	if err != nil {
+		return err
+	}
+	clientDataJSON, err := d.ReadN(d.ReadLen())
+	if err != nil {
+		return err
+	}
+	userSignature, err := d.ReadN(d.ReadLen())
+	if err != nil {
+		return err
+	}
+	if len(userSignature) != 1+signatureSize+PublicKeySize || userSignature[0] != keychain.SchemeSecp256r1.AddressFlag() {
+		return fmt.Errorf("%w: user signature is not a secp256r1 signature", ErrInvalidSignature)
+	}
+
+	*s = Signature{
+		AuthenticatorData: authenticatorData,
+		ClientDataJSON:    string(clientDataJSON),
+		Signature:         userSignature[1 : 1+signatureSize],
+		PublicKey:         userSignature[1+signatureSize:],
+	}
+	return nil
+}
+
+// Address derives the passkey address that produced the signature.
+func (s *Signature) Address() (string, error) {
+	return Address(s.PublicKey)
+}
+
+// UserSignature converts the signature into the form expected by
+// ExecuteTransactionRequest.Signatures.
+func (s *Signature) UserSignature() (*v2.UserSignature, error) {
+	serialized, err := s.Bytes()
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataJSON := s.ClientDataJSON
+	simpleScheme := v2.SignatureScheme_SECP256R1
+	name := keypair.UserSignatureBcsName
+	scheme := v2.SignatureScheme_PASSKEY
+	return &v2.UserSignature{
+		Bcs:    &v2.Bcs{Name: &name, Value: serialized},
+		Scheme: &scheme,
+		Signature: &v2.UserSignature_Passkey{Passkey: &v2.PasskeyAuthenticator{
+			AuthenticatorData: append([]byte(nil), s.AuthenticatorData...),
+			ClientDataJson:    &clientDataJSON,
+			Signature: &v2.SimpleSignature{
+				Scheme:    &simpleScheme,
+				Signature: append([]byte(nil), s.Signature...),
+				PublicKey: append([]byte(nil), s.PublicKey...),
+			},
+		}},
+	}, nil
+}
+
+// VerifyDigest checks that clientDataJSON commits to the intent digest and that
+// the secp256r1 signature over the WebAuthn payload is valid and normalized.
+func (s *Signature) VerifyDigest(digest [32]byte) error {
+	var clientData struct {
+		Type      string `json:"type"`
+		Challenge string `json:"challenge"`
+	}
+	if err := json.Unmarshal([]byte(s.ClientDataJSON), &clientData); err != nil {
+		return fmt.Errorf("%w: client data: %v", ErrInvalidSignature, err)
+	}
+	if clientData.Type != clientDataTypeGet {
+		return fmt.Errorf("%w: client data type %q", ErrInvalidSignature, clientData.Type)
+	}
+	if clientData.Challenge != encodeChallenge(digest) {
+		return ErrChallengeMismatch
+	}
+
+	pub, err := secp256r1keys.ParsePublicKey(s.PublicKey)
+	if err != nil {
+		return fmt.Errorf("passkey: %w", err)
+	}
+	if len(s.Signature) != signatureSize {
+		return fmt.Errorf("%w: signature must be %d bytes", ErrInvalidSignature, signatureSize)
+	}
+	r := new(big.Int).SetBytes(s.Signature[:32])
+	sv := new(big.Int).SetBytes(s.Signature[32:])
+	if sv.Cmp(halfOrder) > 0 {
+		return fmt.Errorf("%w: signature is not normalized", ErrInvalidSignature)
+	}
+	hash := payloadHash(s.AuthenticatorData, s.ClientDataJSON)
+	if !ecdsa.Verify(pub, hash[:], r, sv) {
+		return errors.New("passkey: verification failed")
+	}
+	return nil
+}
+
+// VerifyPersonalMessage verifies a serialized passkey signature over a personal message.
+func VerifyPersonalMessage(message []byte, serialized []byte) error {
+	digest, err := personalmsg.Digest(message)
+	if err != nil {
+		return fmt.Errorf("passkey: %w", err)
+	}
+	return verifySerialized(digest, serialized)
+}
+
+// VerifyTransaction verifies a serialized passkey signature over BCS-encoded TransactionData.
+func VerifyTransaction(txBytes []byte, serialized []byte) error {
+	digest, err := txmsg.Digest(txBytes)
+	if err != nil {
+		return fmt.Errorf("passkey: %w", err)
+	}
+	return verifySerialized(digest, serialized)
+}
+
+func verifySerialized(digest [32]byte, serialized []byte) error {
+	sig, err := ParseSignature(serialized)
+	if err != nil {
+		return err
+	}
+	return sig.VerifyDigest(digest)
+}
+
+var (
+	curveOrder = elliptic.P256().Params().N
+	halfOrder  = new(big.Int).Rsh(curveOrder, 1)
+)
+
+func encodeChallenge(digest [32]byte) string {
+	return base64URL(digest[:])
+}
+
+func base64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// payloadHash is the message hash signed by a WebAuthn authenticator:
+// sha256(authenticatorData || sha256(clientDataJSON)).
+func payloadHash(authenticatorData []byte, clientDataJSON string) [32]byte {
+	clientDataHash := sha256.Sum256([]byte(clientDataJSON))
+	payload := make([]byte, 0, len(authenticatorData)+len(clientDataHash))
+	payload = append(payload, authenticatorData...)
+	payload = append(payload, clientDataHash[:]...)
+	return sha256.Sum256(payload)
+}
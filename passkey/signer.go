@@ -0,0 +1,140 @@
+package passkey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xdraco/sui-go-sdk/cryptography/personalmsg"
+	secp256r1keys "github.com/0xdraco/sui-go-sdk/cryptography/secp256r1"
+	"github.com/0xdraco/sui-go-sdk/cryptography/txmsg"
+	"golang.org/x/crypto/cryptobyte"
+	cbasn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// Assertion is the result of a WebAuthn navigator.credentials.get() call.
+type Assertion struct {
+	AuthenticatorData []byte
+	ClientDataJSON    string
+	// Signature is the ASN.1 DER ECDSA signature returned by the authenticator.
+	// A raw 64-byte r || s signature is also accepted.
+	Signature []byte
+}
+
+// Authenticator produces WebAuthn assertions for a secp256r1 passkey. Browser
+// and platform integrations implement it by forwarding the challenge to the
+// user's authenticator; SoftwareAuthenticator implements it in memory.
+type Authenticator interface {
+	// PublicKey returns the 33-byte compressed public key of the passkey.
+	PublicKey() []byte
+	// GetAssertion requests a signature over challenge.
+	GetAssertion(ctx context.Context, challenge []byte) (*Assertion, error)
+}
+
+// Signer signs transactions and personal messages with a passkey.
+type Signer struct {
+	authenticator Authenticator
+	publicKey     []byte
+}
+
+// NewSigner returns a Signer for the passkey behind authenticator.
+func NewSigner(authenticator Authenticator) (*Signer, error) {
+	if authenticator == nil {
+		return nil, errors.New("passkey: nil authenticator")
+	}
+	publicKey := append([]byte(nil), authenticator.PublicKey()...)
+	if _, err := secp256r1keys.ParsePublicKey(publicKey); err != nil {
+		return nil, fmt.Errorf("passkey: %w", err)
+	}
+	return &Signer{authenticator: authenticator, publicKey: publicKey}, nil
+}
+
+// PublicKeyBytes returns the compressed secp256r1 public key of the passkey.
+func (s *Signer) PublicKeyBytes() []byte {
+	return append([]byte(nil), s.publicKey...)
+}
+
+// SuiAddress returns the passkey address.
+func (s *Signer) SuiAddress() (string, error) {
+	return Address(s.publicKey)
+}
+
+// SignTransaction signs BCS-encoded TransactionData and returns the serialized
+// passkey signature.
+func (s *Signer) SignTransaction(ctx context.Context, txBytes []byte) ([]byte, error) {
+	digest, err := txmsg.Digest(txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("passkey: %w", err)
+	}
+	return s.signDigest(ctx, digest)
+}
+
+// SignPersonalMessage signs a personal message and returns the serialized
+// passkey signature.
+func (s *Signer) SignPersonalMessage(ctx context.Context, message []byte) ([]byte, error) {
+	digest, err := personalmsg.Digest(message)
+	if err != nil {
+		return nil, fmt.Errorf("passkey: %w", err)
+	}
+	return s.signDigest(ctx, digest)
+}
+
+func (s *Signer) signDigest(ctx context.Context, digest [32]byte) ([]byte, error) {
+	if ctx == nil {
+		return nil, errors.New("passkey: nil context")
+	}
+	assertion, err := s.authenticator.GetAssertion(ctx, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("passkey: get assertion: %w", err)
+	}
+	if assertion == nil {
+		return nil, errors.New("passkey: authenticator returned no assertion")
+	}
+	raw, err := normalizeSignature(assertion.Signature)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := &Signature{
+		AuthenticatorData: append([]byte(nil), assertion.AuthenticatorData...),
+		ClientDataJSON:    assertion.ClientDataJSON,
+		Signature:         raw,
+		PublicKey:         s.PublicKeyBytes(),
+	}
+	// Reject assertions that would fail on chain, e.g. a challenge the
+	// authenticator did not echo back verbatim.
+	if err := sig.VerifyDigest(digest); err != nil {
+		return nil, err
+	}
+	return sig.Bytes()
+}
+
+// normalizeSignature converts a DER or raw ECDSA signature into a 64-byte
+// r || s signature with s in the lower half of the curve order.
+func normalizeSignature(signature []byte) ([]byte, error) {
+	var r, s *big.Int
+	if len(signature) == signatureSize {
+		r = new(big.Int).SetBytes(signature[:32])
+		s = new(big.Int).SetBytes(signature[32:])
+	} else {
+		r, s = new(big.Int), new(big.Int)
+		var inner cryptobyte.String
+		input := cryptobyte.String(signature)
+		if !input.ReadASN1(&inner, cbasn1.SEQUENCE) || !input.Empty() ||
+			!inner.ReadASN1Integer(r) || !inner.ReadASN1Integer(s) || !inner.Empty() {
+			return nil, fmt.Errorf("%w: malformed DER signature", ErrInvalidSignature)
+		}
+	}
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(curveOrder) >= 0 || s.Cmp(curveOrder) >= 0 {
+		return nil, fmt.Errorf("%w: signature out of range", ErrInvalidSignature)
+	}
+	if s.Cmp(halfOrder) > 0 {
+		s = new(big.Int).Sub(curveOrder, s)
+	}
+
+	out := make([]byte, signatureSize)
+	r.FillBytes(out[:32])
+	s.FillBytes(out[32:])
+	return out, nil
+}
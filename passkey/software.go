@@ -0,0 +1,97 @@
+package passkey
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	// authenticatorFlagsUPUV marks the assertion as user present and user verified.
+	authenticatorFlagsUPUV byte = 0x05
+)
+
+// SoftwareAuthenticator is an in-memory Authenticator backed by a P-256 key.
+// It produces the same authenticatorData and clientDataJSON shapes as a
+// browser and is intended for tests and tooling, not for protecting funds.
+type SoftwareAuthenticator struct {
+	key    *ecdsa.PrivateKey
+	rpID   string
+	origin string
+
+	mu        sync.Mutex
+	signCount uint32
+}
+
+// NewSoftwareAuthenticator generates a fresh passkey for the relying party rpID,
+// asserting from origin (for example "example.com" and "https://example.com").
+func NewSoftwareAuthenticator(rpID, origin string) (*SoftwareAuthenticator, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("passkey: generate key: %w", err)
+	}
+	return SoftwareAuthenticatorFromKey(key, rpID, origin)
+}
+
+// SoftwareAuthenticatorFromKey wraps an existing P-256 private key.
+func SoftwareAuthenticatorFromKey(key *ecdsa.PrivateKey, rpID, origin string) (*SoftwareAuthenticator, error) {
+	if key == nil || key.Curve != elliptic.P256() {
+		return nil, errors.New("passkey: software authenticator requires a P-256 key")
+	}
+	return &SoftwareAuthenticator{key: key, rpID: rpID, origin: origin}, nil
+}
+
+// PublicKey returns the compressed public key of the passkey.
+func (a *SoftwareAuthenticator) PublicKey() []byte {
+	return elliptic.MarshalCompressed(elliptic.P256(), a.key.X, a.key.Y)
+}
+
+// GetAssertion signs challenge the way a WebAuthn authenticator does and
+// returns a DER-encoded signature.
+func (a *SoftwareAuthenticator) GetAssertion(ctx context.Context, challenge []byte) (*Assertion, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	a.signCount++
+	count := a.signCount
+	a.mu.Unlock()
+
+	rpIDHash := sha256.Sum256([]byte(a.rpID))
+	authenticatorData := make([]byte, 0, len(rpIDHash)+5)
+	authenticatorData = append(authenticatorData, rpIDHash[:]...)
+	authenticatorData = append(authenticatorData, authenticatorFlagsUPUV,
+		byte(count>>24), byte(count>>16), byte(count>>8), byte(count))
+
+	clientData, err := json.Marshal(struct {
+		Type        string `json:"type"`
+		Challenge   string `json:"challenge"`
+		Origin      string `json:"origin"`
+		CrossOrigin bool   `json:"crossOrigin"`
+	}{
+		Type:      clientDataTypeGet,
+		Challenge: base64URL(challenge),
+		Origin:    a.origin,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("passkey: client data: %w", err)
+	}
+
+	hash := payloadHash(authenticatorData, string(clientData))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, hash[:])
+	if err != nil {
+		return nil, fmt.Errorf("passkey: sign: %w", err)
+	}
+	return &Assertion{
+		AuthenticatorData: authenticatorData,
+		ClientDataJSON:    string(clientData),
+		Signature:         signature,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package transaction
+
+import (
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+)
+
+// Argument is a handle to a value that can be passed to a programmable
+// transaction command: the gas coin, one of the transaction inputs, or the
+// result of an earlier command.
+//
+// Arguments are only meaningful for the TransactionBuilder that produced them.
+type Argument struct {
+	kind      v2.Argument_ArgumentKind
+	index     uint32
+	subresult *uint32
+}
+
+// Kind reports whether the argument refers to gas, an input, or a command result.
+func (a Argument) Kind() v2.Argument_ArgumentKind {
+	return a.kind
+}
+
+// Index returns the input or command index the argument refers to. It is
+// always zero for the gas coin.
+func (a Argument) Index() uint32 {
+	return a.index
+}
+
+// Nested selects the i-th value of a command that returns multiple results,
+// such as SplitCoins. Calling Nested on anything other than a plain command
+// result yields an invalid argument which Build rejects.
+func (a Argument) Nested(i uint32) Argument {
+	if a.kind != v2.Argument_RESULT || a.subresult != nil {
+		return Argument{}
+	}
+	sub := i
+	return Argument{kind: v2.Argument_RESULT, index: a.index, subresult: &sub}
+}
+
+// Proto converts the handle into its protobuf representation.
+func (a Argument) Proto() *v2.Argument {
+	out := &v2.Argument{Kind: a.kind.Enum()}
+	switch a.kind {
+	case v2.Argument_INPUT:
+		idx := a.index
+		out.Input = &idx
+	case v2.Argument_RESULT:
+		idx := a.index
+		out.Result = &idx
+		if a.subresult != nil {
+			sub := *a.subresult
+			out.Subresult = &sub
+		}
+	}
+	return out
+}
+
+func (a Argument) valid() bool {
+	switch a.kind {
+	case v2.Argument_GAS, v2.Argument_INPUT, v2.Argument_RESULT:
+		return true
+	default:
+		return false
+	}
+}
+
+func gasArgument() Argument {
+	return Argument{kind: v2.Argument_GAS}
+}
+
+func inputArgument(index uint32) Argument {
+	return Argument{kind: v2.Argument_INPUT, index: index}
+}
+
+func resultArgument(index uint32) Argument {
+	return Argument{kind: v2.Argument_RESULT, index: index}
+}
@@ -0,0 +1,439 @@
+// Package transaction assembles programmable transaction blocks without
+// hand-wiring input indices and command results.
+package transaction
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/0xdraco/sui-go-sdk/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// transactionDataVersion is the TransactionData variant produced by Build (V1).
+const transactionDataVersion int32 = 1
+
+var (
+	// ErrInvalidArgument indicates a command received an argument handle that
+	// does not refer to gas, an input, or a command result.
+	ErrInvalidArgument = errors.New("transaction: invalid argument")
+	// ErrNoCommands indicates Build was called before any command was added.
+	ErrNoCommands = errors.New("transaction: no commands")
+)
+
+// TransactionBuilder incrementally builds a programmable transaction. Methods
+// return argument handles that can be fed into later commands. The first error
+// encountered is retained and reported by Err and Build, so calls can be
+// chained without checking each one.
+type TransactionBuilder struct {
+	sender     *string
+	gasOwner   *string
+	gasPrice   *uint64
+	gasBudget  *uint64
+	gasObjects []*v2.ObjectReference
+	expiration *v2.TransactionExpiration
+
+	inputs      []*v2.Input
+	commands    []*v2.Command
+	objectIndex map[types.Address]uint32
+	pureIndex   map[string]uint32
+
+	err error
+}
+
+// NewTransactionBuilder returns an empty builder.
+func NewTransactionBuilder() *TransactionBuilder {
+	return &TransactionBuilder{
+		objectIndex: make(map[types.Address]uint32),
+		pureIndex:   make(map[string]uint32),
+	}
+}
+
+// Err reports the first error recorded by the builder.
+func (b *TransactionBuilder) Err() error {
+	return b.err
+}
+
+func (b *TransactionBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// SetSender sets the address that signs and sends the transaction.
+func (b *TransactionBuilder) SetSender(address string) *TransactionBuilder {
+	normalized, err := types.NormalizeAddress(address)
+	if err != nil {
+		b.setErr(fmt.Errorf("transaction: sender: %w", err))
+		return b
+	}
+	b.sender = &normalized
+	return b
+}
+
+// SetGasOwner sets the gas owner, used when the transaction is sponsored.
+func (b *TransactionBuilder) SetGasOwner(address string) *TransactionBuilder {
+	normalized, err := types.NormalizeAddress(address)
+	if err != nil {
+		b.setErr(fmt.Errorf("transaction: gas owner: %w", err))
+		return b
+	}
+	b.gasOwner = &normalized
+	return b
+}
+
+// SetGasPrice sets the gas unit price.
+func (b *TransactionBuilder) SetGasPrice(price uint64) *TransactionBuilder {
+	b.gasPrice = &price
+	return b
+}
+
+// SetGasBudget sets the maximum amount of gas the transaction may consume.
+func (b *TransactionBuilder) SetGasBudget(budget uint64) *TransactionBuilder {
+	b.gasBudget = &budget
+	return b
+}
+
+// SetGasPayment sets the coins used to pay for gas.
+func (b *TransactionBuilder) SetGasPayment(refs ...*v2.ObjectReference) *TransactionBuilder {
+	b.gasObjects = make([]*v2.ObjectReference, 0, len(refs))
+	for _, ref := range refs {
+		if ref == nil {
+			continue
+		}
+		b.gasObjects = append(b.gasObjects, proto.Clone(ref).(*v2.ObjectReference))
+	}
+	return b
+}
+
+// SetGasPaymentObjects is a convenience wrapper around SetGasPayment for coins
+// returned by helpers such as GRPCClient.SelectCoins.
+func (b *TransactionBuilder) SetGasPaymentObjects(objects ...*v2.Object) *TransactionBuilder {
+	refs := make([]*v2.ObjectReference, 0, len(objects))
+	for _, obj := range objects {
+		if obj == nil {
+			continue
+		}
+		refs = append(refs, &v2.ObjectReference{
+			ObjectId: cloneString(obj.ObjectId),
+			Version:  cloneUint64(obj.Version),
+			Digest:   cloneString(obj.Digest),
+		})
+	}
+	return b.SetGasPayment(refs...)
+}
+
+// SetExpiration makes the transaction invalid after the given epoch.
+func (b *TransactionBuilder) SetExpiration(epoch uint64) *TransactionBuilder {
+	b.expiration = &v2.TransactionExpiration{
+		Kind:  v2.TransactionExpiration_EPOCH.Enum(),
+		Epoch: &epoch,
+	}
+	return b
+}
+
+// Gas returns a handle to the gas coin.
+func (b *TransactionBuilder) Gas() Argument {
+	return gasArgument()
+}
+
+// MoveCall appends a call to target, formatted as "package::module::function".
+func (b *TransactionBuilder) MoveCall(target string, typeArguments []string, arguments ...Argument) Argument {
+	if b.err != nil {
+		return Argument{}
+	}
+	pkg, module, function, err := parseMoveCallTarget(target)
+	if err != nil {
+		b.setErr(err)
+		return Argument{}
+	}
+	args, ok := b.protoArguments(arguments)
+	if !ok {
+		return Argument{}
+	}
+	return b.addCommand(&v2.Command{Command: &v2.Command_MoveCall{MoveCall: &v2.MoveCall{
+		Package:       &pkg,
+		Module:        &module,
+		Function:      &function,
+		TypeArguments: append([]string(nil), typeArguments...),
+		Arguments:     args,
+	}}})
+}
+
+// SplitCoins splits coin into one new coin per amount and returns a handle per new coin.
+func (b *TransactionBuilder) SplitCoins(coin Argument, amounts ...Argument) []Argument {
+	if b.err != nil {
+		return nil
+	}
+	if len(amounts) == 0 {
+		b.setErr(errors.New("transaction: split coins requires at least one amount"))
+		return nil
+	}
+	coinArg, ok := b.protoArgument(coin)
+	if !ok {
+		return nil
+	}
+	amountArgs, ok := b.protoArguments(amounts)
+	if !ok {
+		return nil
+	}
+	result := b.addCommand(&v2.Command{Command: &v2.Command_SplitCoins{SplitCoins: &v2.SplitCoins{
+		Coin:    coinArg,
+		Amounts: amountArgs,
+	}}})
+
+	out := make([]Argument, len(amounts))
+	for i := range amounts {
+		out[i] = result.Nested(uint32(i))
+	}
+	return out
+}
+
+// MergeCoins merges sources into destination.
+func (b *TransactionBuilder) MergeCoins(destination Argument, sources ...Argument) {
+	if b.err != nil {
+		return
+	}
+	if len(sources) == 0 {
+		b.setErr(errors.New("transaction: merge coins requires at least one source"))
+		return
+	}
+	dst, ok := b.protoArgument(destination)
+	if !ok {
+		return
+	}
+	srcs, ok := b.protoArguments(sources)
+	if !ok {
+		return
+	}
+	b.addCommand(&v2.Command{Command: &v2.Command_MergeCoins{MergeCoins: &v2.MergeCoins{
+		Coin:         dst,
+		CoinsToMerge: srcs,
+	}}})
+}
+
+// TransferObjects sends objects to recipient, which must be an address argument.
+func (b *TransactionBuilder) TransferObjects(objects []Argument, recipient Argument) {
+	if b.err != nil {
+		return
+	}
+	if len(objects) == 0 {
+		b.setErr(errors.New("transaction: transfer objects requires at least one object"))
+		return
+	}
+	objs, ok := b.protoArguments(objects)
+	if !ok {
+		return
+	}
+	addr, ok := b.protoArgument(recipient)
+	if !ok {
+		return
+	}
+	b.addCommand(&v2.Command{Command: &v2.Command_TransferObjects{TransferObjects: &v2.TransferObjects{
+		Objects: objs,
+		Address: addr,
+	}}})
+}
+
+// MakeMoveVector builds a vector from elements. elementType may be empty when
+// the elements are objects, in which case it is inferred on chain.
+func (b *TransactionBuilder) MakeMoveVector(elementType string, elements ...Argument) Argument {
+	if b.err != nil {
+		return Argument{}
+	}
+	if elementType == "" && len(elements) == 0 {
+		b.setErr(errors.New("transaction: make move vector requires an element type or at least one element"))
+		return Argument{}
+	}
+	elems, ok := b.protoArguments(elements)
+	if !ok {
+		return Argument{}
+	}
+	cmd := &v2.MakeMoveVector{Elements: elems}
+	if elementType != "" {
+		cmd.ElementType = &elementType
+	}
+	return b.addCommand(&v2.Command{Command: &v2.Command_MakeMoveVector{MakeMoveVector: cmd}})
+}
+
+// Publish publishes a new package and returns a handle to its UpgradeCap.
+func (b *TransactionBuilder) Publish(modules [][]byte, dependencies []string) Argument {
+	if b.err != nil {
+		return Argument{}
+	}
+	if len(modules) == 0 {
+		b.setErr(errors.New("transaction: publish requires at least one module"))
+		return Argument{}
+	}
+	deps, ok := b.normalizeDependencies(dependencies)
+	if !ok {
+		return Argument{}
+	}
+	return b.addCommand(&v2.Command{Command: &v2.Command_Publish{Publish: &v2.Publish{
+		Modules:      cloneModules(modules),
+		Dependencies: deps,
+	}}})
+}
+
+// Upgrade upgrades packageID using an UpgradeTicket and returns a handle to
+// the UpgradeReceipt that must be committed afterwards.
+func (b *TransactionBuilder) Upgrade(modules [][]byte, dependencies []string, packageID string, ticket Argument) Argument {
+	if b.err != nil {
+		return Argument{}
+	}
+	if len(modules) == 0 {
+		b.setErr(errors.New("transaction: upgrade requires at least one module"))
+		return Argument{}
+	}
+	pkg, err := types.NormalizeAddress(packageID)
+	if err != nil {
+		b.setErr(fmt.Errorf("transaction: upgrade package: %w", err))
+		return Argument{}
+	}
+	deps, ok := b.normalizeDependencies(dependencies)
+	if !ok {
+		return Argument{}
+	}
+	ticketArg, ok := b.protoArgument(ticket)
+	if !ok {
+		return Argument{}
+	}
+	return b.addCommand(&v2.Command{Command: &v2.Command_Upgrade{Upgrade: &v2.Upgrade{
+		Modules:      cloneModules(modules),
+		Dependencies: deps,
+		Package:      &pkg,
+		Ticket:       ticketArg,
+	}}})
+}
+
+// ProgrammableTransaction returns the inputs and commands accumulated so far.
+func (b *TransactionBuilder) ProgrammableTransaction() (*v2.ProgrammableTransaction, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	if len(b.commands) == 0 {
+		return nil, ErrNoCommands
+	}
+
+	ptb := &v2.ProgrammableTransaction{
+		Inputs:   make([]*v2.Input, len(b.inputs)),
+		Commands: make([]*v2.Command, len(b.commands)),
+	}
+	for i, in := range b.inputs {
+		ptb.Inputs[i] = proto.Clone(in).(*v2.Input)
+	}
+	for i, cmd := range b.commands {
+		ptb.Commands[i] = proto.Clone(cmd).(*v2.Command)
+	}
+	return ptb, nil
+}
+
+// Build returns a transaction ready for SimulateTransaction or, once signed,
+// ExecuteTransactionAndWait. Gas fields that were not set are left empty so
+// the fullnode or later helpers can fill them.
+func (b *TransactionBuilder) Build() (*v2.Transaction, error) {
+	ptb, err := b.ProgrammableTransaction()
+	if err != nil {
+		return nil, err
+	}
+
+	version := transactionDataVersion
+	tx := &v2.Transaction{
+		Version: &version,
+		Kind: &v2.TransactionKind{
+			Kind: v2.TransactionKind_PROGRAMMABLE_TRANSACTION.Enum(),
+			Data: &v2.TransactionKind_ProgrammableTransaction{ProgrammableTransaction: ptb},
+		},
+		Sender: cloneString(b.sender),
+	}
+
+	if b.gasOwner != nil || b.gasPrice != nil || b.gasBudget != nil || len(b.gasObjects) > 0 {
+		payment := &v2.GasPayment{
+			Owner:  cloneString(b.gasOwner),
+			Price:  cloneUint64(b.gasPrice),
+			Budget: cloneUint64(b.gasBudget),
+		}
+		for _, ref := range b.gasObjects {
+			payment.Objects = append(payment.Objects, proto.Clone(ref).(*v2.ObjectReference))
+		}
+		tx.GasPayment = payment
+	}
+	if b.expiration != nil {
+		tx.Expiration = proto.Clone(b.expiration).(*v2.TransactionExpiration)
+	}
+
+	return tx, nil
+}
+
+func (b *TransactionBuilder) addCommand(cmd *v2.Command) Argument {
+	idx := uint32(len(b.commands))
+	b.commands = append(b.commands, cmd)
+	return resultArgument(idx)
+}
+
+func (b *TransactionBuilder) protoArgument(arg Argument) (*v2.Argument, bool) {
+	if !arg.valid() {
+		b.setErr(ErrInvalidArgument)
+		return nil, false
+	}
+	switch arg.kind {
+	case v2.Argument_INPUT:
+		if int(arg.index) >= len(b.inputs) {
+			b.setErr(fmt.Errorf("%w: input %d out of range", ErrInvalidArgument, arg.index))
+			return nil, false
+		}
+	case v2.Argument_RESULT:
+		if int(arg.index) >= len(b.commands) {
+			b.setErr(fmt.Errorf("%w: result %d out of range", ErrInvalidArgument, arg.index))
+			return nil, false
+		}
+	}
+	return arg.Proto(), true
+}
+
+func (b *TransactionBuilder) protoArguments(args []Argument) ([]*v2.Argument, bool) {
+	out := make([]*v2.Argument, len(args))
+	for i, arg := range args {
+		converted, ok := b.protoArgument(arg)
+		if !ok {
+			return nil, false
+		}
+		out[i] = converted
+	}
+	return out, true
+}
+
+func (b *TransactionBuilder) normalizeDependencies(deps []string) ([]string, bool) {
+	out := make([]string, len(deps))
+	for i, dep := range deps {
+		normalized, err := types.NormalizeAddress(dep)
+		if err != nil {
+			b.setErr(fmt.Errorf("transaction: dependency %d: %w", i, err))
+			return nil, false
+		}
+		out[i] = normalized
+	}
+	return out, true
+}
+
+func parseMoveCallTarget(target string) (string, string, string, error) {
+	parts := strings.Split(target, "::")
+	if len(parts) != 3 || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("transaction: invalid move call target %q", target)
+	}
+	pkg, err := types.NormalizeAddress(parts[0])
+	if err != nil {
+		return "", "", "", fmt.Errorf("transaction: move call target %q: %w", target, err)
+	}
+	return pkg, parts[1], parts[2], nil
+}
+
+func cloneModules(modules [][]byte) [][]byte {
+	out := make([][]byte, len(modules))
+	for i, m := range modules {
+		out[i] = append([]byte(nil), m...)
+	}
+	return out
+}
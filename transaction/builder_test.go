@@ -0,0 +1,180 @@
+package transaction
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+)
+
+const (
+	testSender = "0x5e93a736d04fbb25737aa40bee40171ef79f65fae833749e3c089fe7cc2161f1"
+	testCoinID = "0x72f5c6eef73d77de271886219a2543e7c29a33de19a6c69c5cf1899f729c3f17"
+)
+
+func TestBuilderSplitAndTransfer(t *testing.T) {
+	b := NewTransactionBuilder()
+	b.SetSender(testSender).SetGasPrice(750).SetGasBudget(5_000_000)
+
+	coins := b.SplitCoins(b.Gas(), b.PureU64(100), b.PureU64(200), b.PureU64(100))
+	b.TransferObjects(coins, b.PureAddress(testSender))
+
+	tx, err := b.Build()
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	if tx.GetSender() != testSender {
+		t.Fatalf("sender mismatch: got %s", tx.GetSender())
+	}
+	if tx.GetGasPayment().GetPrice() != 750 || tx.GetGasPayment().GetBudget() != 5_000_000 {
+		t.Fatalf("unexpected gas payment: %v", tx.GetGasPayment())
+	}
+
+	ptb := tx.GetKind().GetProgrammableTransaction()
+	// 100 appears twice but must only be stored once.
+	if got := len(ptb.GetInputs()); got != 3 {
+		t.Fatalf("expected 3 inputs, got %d", got)
+	}
+	if got := len(ptb.GetCommands()); got != 2 {
+		t.Fatalf("expected 2 commands, got %d", got)
+	}
+
+	split := ptb.GetCommands()[0].GetSplitCoins()
+	if split.GetCoin().GetKind() != v2.Argument_GAS {
+		t.Fatalf("expected split from gas, got %v", split.GetCoin().GetKind())
+	}
+	if split.GetAmounts()[2].GetInput() != 0 {
+		t.Fatalf("expected deduplicated amount to reuse input 0, got %d", split.GetAmounts()[2].GetInput())
+	}
+
+	transfer := ptb.GetCommands()[1].GetTransferObjects()
+	for i, obj := range transfer.GetObjects() {
+		if obj.GetKind() != v2.Argument_RESULT || obj.GetResult() != 0 || obj.GetSubresult() != uint32(i) {
+			t.Fatalf("transfer object %d: unexpected argument %v", i, obj)
+		}
+	}
+	if transfer.GetAddress().GetInput() != 2 {
+		t.Fatalf("expected recipient input 2, got %d", transfer.GetAddress().GetInput())
+	}
+}
+
+func TestBuilderObjectDeduplication(t *testing.T) {
+	b := NewTransactionBuilder()
+	shortID := "0x6"
+
+	first := b.SharedObject(shortID, 1, false)
+	second := b.SharedObject("0x0000000000000000000000000000000000000000000000000000000000000006", 1, true)
+	if first != second {
+		t.Fatalf("expected shared object to be deduplicated")
+	}
+	b.MoveCall("0x2::clock::timestamp_ms", nil, first)
+
+	ptb, err := b.ProgrammableTransaction()
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if len(ptb.GetInputs()) != 1 {
+		t.Fatalf("expected single input, got %d", len(ptb.GetInputs()))
+	}
+	if !ptb.GetInputs()[0].GetMutable() {
+		t.Fatalf("expected shared input to be widened to mutable")
+	}
+
+	call := ptb.GetCommands()[0].GetMoveCall()
+	if call.GetPackage() != "0x0000000000000000000000000000000000000000000000000000000000000002" {
+		t.Fatalf("package not normalized: %s", call.GetPackage())
+	}
+	if call.GetModule() != "clock" || call.GetFunction() != "timestamp_ms" {
+		t.Fatalf("unexpected target %s::%s", call.GetModule(), call.GetFunction())
+	}
+}
+
+func TestBuilderConflictingObjectKinds(t *testing.T) {
+	b := NewTransactionBuilder()
+	version := uint64(3)
+	digest := "digest"
+	ref := &v2.ObjectReference{ObjectId: stringPtr(testCoinID), Version: &version, Digest: &digest}
+
+	b.Object(ref)
+	b.ReceivingObject(ref)
+	if b.Err() == nil {
+		t.Fatalf("expected conflicting object kinds to fail")
+	}
+	if _, err := b.Build(); err == nil {
+		t.Fatalf("expected build to report recorded error")
+	}
+}
+
+func TestBuilderRejectsInvalidArguments(t *testing.T) {
+	b := NewTransactionBuilder()
+	b.MergeCoins(b.Gas(), b.Gas().Nested(0))
+	if !errors.Is(b.Err(), ErrInvalidArgument) {
+		t.Fatalf("expected ErrInvalidArgument, got %v", b.Err())
+	}
+
+	b = NewTransactionBuilder()
+	b.MoveCall("0x2::coin", nil)
+	if b.Err() == nil {
+		t.Fatalf("expected malformed target to fail")
+	}
+
+	if _, err := NewTransactionBuilder().Build(); !errors.Is(err, ErrNoCommands) {
+		t.Fatalf("expected ErrNoCommands, got %v", err)
+	}
+}
+
+func TestBuilderPublishAndUpgrade(t *testing.T) {
+	b := NewTransactionBuilder()
+	upgradeCap := b.Publish([][]byte{{0xa1, 0x1c}}, []string{"0x1", "0x2"})
+	b.TransferObjects([]Argument{upgradeCap}, b.PureAddress(testSender))
+
+	ticket := b.MoveCall("0x2::package::authorize_upgrade", nil, b.ObjectID(testCoinID), b.PureU8(0), b.PureBytes([]byte{0}))
+	receipt := b.Upgrade([][]byte{{0xa1}}, []string{"0x1"}, testCoinID, ticket)
+	b.MoveCall("0x2::package::commit_upgrade", nil, b.ObjectID(testCoinID), receipt)
+
+	ptb, err := b.ProgrammableTransaction()
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if got := len(ptb.GetCommands()); got != 5 {
+		t.Fatalf("expected 5 commands, got %d", got)
+	}
+	upgrade := ptb.GetCommands()[3].GetUpgrade()
+	if upgrade.GetTicket().GetResult() != 2 {
+		t.Fatalf("expected ticket from command 2, got %d", upgrade.GetTicket().GetResult())
+	}
+	if upgrade.GetPackage() != testCoinID {
+		t.Fatalf("unexpected package %s", upgrade.GetPackage())
+	}
+	// u8(0) and the raw 0x00 byte share an encoding.
+	if got := len(ptb.GetInputs()); got != 3 {
+		t.Fatalf("expected 3 inputs, got %d", got)
+	}
+}
+
+func TestBuilderPureWideIntegers(t *testing.T) {
+	b := NewTransactionBuilder()
+	max128 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 128), big.NewInt(1))
+	b.MoveCall("0x2::math::max", nil, b.PureU128(max128), b.PureU256(big.NewInt(258)))
+	ptb, err := b.ProgrammableTransaction()
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if got := ptb.GetInputs()[0].GetPure(); !bytes.Equal(got, bytes.Repeat([]byte{0xff}, 16)) {
+		t.Fatalf("unexpected u128 encoding %x", got)
+	}
+	want := make([]byte, 32)
+	want[0], want[1] = 2, 1
+	if got := ptb.GetInputs()[1].GetPure(); !bytes.Equal(got, want) {
+		t.Fatalf("unexpected u256 encoding %x", got)
+	}
+
+	b = NewTransactionBuilder()
+	b.PureU128(new(big.Int).Lsh(big.NewInt(1), 128))
+	if b.Err() == nil {
+		t.Fatalf("expected u128 overflow to fail")
+	}
+}
@@ -0,0 +1,584 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/0xdraco/sui-go-sdk/types"
+	"github.com/iotaledger/bcs-go"
+)
+
+// TransactionDataBcsName is the Bcs.Name the RPC uses for TransactionData payloads.
+const TransactionDataBcsName = "TransactionData"
+
+var (
+	// ErrIncompleteTransaction indicates the transaction lacks data required for
+	// BCS encoding, such as the sender, gas payment or resolved object inputs.
+	ErrIncompleteTransaction = errors.New("transaction: incomplete transaction")
+)
+
+// TransactionDataFromProto converts a fully-resolved transaction into its BCS
+// model. The gas owner defaults to the sender when unset.
+func TransactionDataFromProto(tx *v2.Transaction) (*types.TransactionData, error) {
+	if tx == nil {
+		return nil, errors.New("transaction: nil transaction")
+	}
+	if tx.Version != nil && tx.GetVersion() != transactionDataVersion {
+		return nil, fmt.Errorf("transaction: unsupported transaction data version %d", tx.GetVersion())
+	}
+	if tx.GetKind().GetKind() != v2.TransactionKind_PROGRAMMABLE_TRANSACTION {
+		return nil, fmt.Errorf("%w: %s", types.ErrUnsupportedTransactionKind, tx.GetKind().GetKind())
+	}
+	ptb, err := programmableFromProto(tx.GetKind().GetProgrammableTransaction())
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.Sender == nil {
+		return nil, fmt.Errorf("%w: missing sender", ErrIncompleteTransaction)
+	}
+	sender, err := types.ParseAddress(tx.GetSender())
+	if err != nil {
+		return nil, fmt.Errorf("transaction: sender: %w", err)
+	}
+
+	gas, err := gasDataFromProto(tx.GetGasPayment(), sender)
+	if err != nil {
+		return nil, err
+	}
+
+	expiration, err := expirationFromProto(tx.GetExpiration())
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TransactionData{V1: &types.TransactionDataV1{
+		Kind:       types.TransactionKind{ProgrammableTransaction: ptb},
+		Sender:     sender,
+		GasData:    gas,
+		Expiration: expiration,
+	}}, nil
+}
+
+// TransactionDataToProto converts a BCS model back into the RPC representation.
+func TransactionDataToProto(data *types.TransactionData) (*v2.Transaction, error) {
+	if data == nil || data.V1 == nil {
+		return nil, errors.New("transaction: missing transaction data")
+	}
+	v1 := data.V1
+	if v1.Kind.ProgrammableTransaction == nil {
+		return nil, types.ErrUnsupportedTransactionKind
+	}
+	ptb, err := programmableToProto(v1.Kind.ProgrammableTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	version := transactionDataVersion
+	sender := v1.Sender.String()
+	owner := v1.GasData.Owner.String()
+	price := v1.GasData.Price
+	budget := v1.GasData.Budget
+	payment := &v2.GasPayment{Owner: &owner, Price: &price, Budget: &budget}
+	for _, ref := range v1.GasData.Payment {
+		payment.Objects = append(payment.Objects, objectRefToProto(ref))
+	}
+
+	tx := &v2.Transaction{
+		Version: &version,
+		Kind: &v2.TransactionKind{
+			Kind: v2.TransactionKind_PROGRAMMABLE_TRANSACTION.Enum(),
+			Data: &v2.TransactionKind_ProgrammableTransaction{ProgrammableTransaction: ptb},
+		},
+		Sender:     &sender,
+		GasPayment: payment,
+	}
+	switch {
+	case v1.Expiration.Epoch != nil:
+		epoch := *v1.Expiration.Epoch
+		tx.Expiration = &v2.TransactionExpiration{Kind: v2.TransactionExpiration_EPOCH.Enum(), Epoch: &epoch}
+	default:
+		tx.Expiration = &v2.TransactionExpiration{Kind: v2.TransactionExpiration_NONE.Enum()}
+	}
+	return tx, nil
+}
+
+// MarshalTransaction returns the BCS-encoded TransactionData for tx. When tx
+// carries only a Bcs payload (and no structured kind) those bytes are used as-is.
+func MarshalTransaction(tx *v2.Transaction) ([]byte, error) {
+	if tx == nil {
+		return nil, errors.New("transaction: nil transaction")
+	}
+	if tx.Kind == nil && len(tx.GetBcs().GetValue()) > 0 {
+		return append([]byte(nil), tx.GetBcs().GetValue()...), nil
+	}
+	data, err := TransactionDataFromProto(tx)
+	if err != nil {
+		return nil, err
+	}
+	return types.MarshalTransactionData(data)
+}
+
+// UnmarshalTransaction decodes BCS TransactionData into a transaction that
+// carries both the structured fields and the original Bcs payload.
+func UnmarshalTransaction(encoded []byte) (*v2.Transaction, error) {
+	data, err := types.UnmarshalTransactionData(encoded)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := TransactionDataToProto(data)
+	if err != nil {
+		return nil, err
+	}
+	name := TransactionDataBcsName
+	digest := types.TransactionDigest(encoded).String()
+	tx.Bcs = &v2.Bcs{Name: &name, Value: append([]byte(nil), encoded...)}
+	tx.Digest = &digest
+	return tx, nil
+}
+
+// Digest computes the base58 transaction digest of tx before it is submitted.
+// The result can be compared with ExecutedTransaction.Digest.
+func Digest(tx *v2.Transaction) (string, error) {
+	encoded, err := MarshalTransaction(tx)
+	if err != nil {
+		return "", err
+	}
+	return types.TransactionDigest(encoded).String(), nil
+}
+
+func gasDataFromProto(payment *v2.GasPayment, sender types.Address) (types.GasData, error) {
+	if payment == nil || payment.Price == nil || payment.Budget == nil {
+		return types.GasData{}, fmt.Errorf("%w: gas price and budget are required", ErrIncompleteTransaction)
+	}
+	if len(payment.GetObjects()) == 0 {
+		return types.GasData{}, fmt.Errorf("%w: missing gas payment objects", ErrIncompleteTransaction)
+	}
+
+	owner := sender
+	if payment.Owner != nil {
+		parsed, err := types.ParseAddress(payment.GetOwner())
+		if err != nil {
+			return types.GasData{}, fmt.Errorf("transaction: gas owner: %w", err)
+		}
+		owner = parsed
+	}
+
+	gas := types.GasData{
+		Owner:   owner,
+		Price:   payment.GetPrice(),
+		Budget:  payment.GetBudget(),
+		Payment: make([]types.ObjectRef, len(payment.GetObjects())),
+	}
+	for i, ref := range payment.GetObjects() {
+		converted, err := objectRefFromProto(ref.GetObjectId(), ref.Version, ref.Digest)
+		if err != nil {
+			return types.GasData{}, fmt.Errorf("transaction: gas object %d: %w", i, err)
+		}
+		gas.Payment[i] = converted
+	}
+	return gas, nil
+}
+
+func expirationFromProto(exp *v2.TransactionExpiration) (types.TransactionExpiration, error) {
+	kind := exp.GetKind()
+	switch {
+	case kind == v2.TransactionExpiration_EPOCH,
+		kind == v2.TransactionExpiration_TRANSACTION_EXPIRATION_KIND_UNKNOWN && exp != nil && exp.Epoch != nil:
+		epoch := exp.GetEpoch()
+		return types.TransactionExpiration{Epoch: &epoch}, nil
+	case kind == v2.TransactionExpiration_NONE,
+		kind == v2.TransactionExpiration_TRANSACTION_EXPIRATION_KIND_UNKNOWN:
+		return types.TransactionExpiration{None: &bcs.None{}}, nil
+	default:
+		return types.TransactionExpiration{}, fmt.Errorf("transaction: unsupported expiration kind %s", kind)
+	}
+}
+
+func programmableFromProto(ptb *v2.ProgrammableTransaction) (*types.ProgrammableTransaction, error) {
+	if ptb == nil {
+		return nil, fmt.Errorf("%w: missing programmable transaction", ErrIncompleteTransaction)
+	}
+	out := &types.ProgrammableTransaction{
+		Inputs:   make([]types.CallArg, len(ptb.GetInputs())),
+		Commands: make([]types.Command, len(ptb.GetCommands())),
+	}
+	for i, in := range ptb.GetInputs() {
+		arg, err := callArgFromProto(in)
+		if err != nil {
+			return nil, fmt.Errorf("transaction: input %d: %w", i, err)
+		}
+		out.Inputs[i] = arg
+	}
+	for i, cmd := range ptb.GetCommands() {
+		converted, err := commandFromProto(cmd)
+		if err != nil {
+			return nil, fmt.Errorf("transaction: command %d: %w", i, err)
+		}
+		out.Commands[i] = converted
+	}
+	return out, nil
+}
+
+func callArgFromProto(in *v2.Input) (types.CallArg, error) {
+	switch in.GetKind() {
+	case v2.Input_PURE:
+		pure := append([]byte{}, in.GetPure()...)
+		return types.CallArg{Pure: &pure}, nil
+	case v2.Input_IMMUTABLE_OR_OWNED:
+		ref, err := objectRefFromProto(in.GetObjectId(), in.Version, in.Digest)
+		if err != nil {
+			return types.CallArg{}, err
+		}
+		return types.CallArg{Object: &types.ObjectArg{ImmOrOwnedObject: &ref}}, nil
+	case v2.Input_RECEIVING:
+		ref, err := objectRefFromProto(in.GetObjectId(), in.Version, in.Digest)
+		if err != nil {
+			return types.CallArg{}, err
+		}
+		return types.CallArg{Object: &types.ObjectArg{Receiving: &ref}}, nil
+	case v2.Input_SHARED:
+		id, err := types.ParseAddress(in.GetObjectId())
+		if err != nil {
+			return types.CallArg{}, err
+		}
+		if in.Version == nil {
+			return types.CallArg{}, fmt.Errorf("%w: shared object %s missing initial version", ErrIncompleteTransaction, id)
+		}
+		return types.CallArg{Object: &types.ObjectArg{SharedObject: &types.SharedObjectArg{
+			ObjectID:             id,
+			InitialSharedVersion: in.GetVersion(),
+			Mutable:              in.GetMutable(),
+		}}}, nil
+	default:
+		return types.CallArg{}, fmt.Errorf("%w: unresolved input kind %s", ErrIncompleteTransaction, in.GetKind())
+	}
+}
+
+func objectRefFromProto(objectID string, version *uint64, digest *string) (types.ObjectRef, error) {
+	id, err := types.ParseAddress(objectID)
+	if err != nil {
+		return types.ObjectRef{}, err
+	}
+	if version == nil || digest == nil {
+		return types.ObjectRef{}, fmt.Errorf("%w: object %s missing version or digest", ErrIncompleteTransaction, id)
+	}
+	d, err := types.ParseDigest(*digest)
+	if err != nil {
+		return types.ObjectRef{}, fmt.Errorf("object %s: %w", id, err)
+	}
+	return types.ObjectRef{ObjectID: id, Version: *version, Digest: d}, nil
+}
+
+func commandFromProto(cmd *v2.Command) (types.Command, error) {
+	switch c := cmd.GetCommand().(type) {
+	case *v2.Command_MoveCall:
+		pkg, err := types.ParseAddress(c.MoveCall.GetPackage())
+		if err != nil {
+			return types.Command{}, fmt.Errorf("move call package: %w", err)
+		}
+		typeArgs := make([]types.TypeTag, len(c.MoveCall.GetTypeArguments()))
+		for i, raw := range c.MoveCall.GetTypeArguments() {
+			tag, err := types.ParseTypeTag(raw)
+			if err != nil {
+				return types.Command{}, err
+			}
+			typeArgs[i] = tag
+		}
+		args, err := argumentsFromProto(c.MoveCall.GetArguments())
+		if err != nil {
+			return types.Command{}, err
+		}
+		return types.Command{MoveCall: &types.ProgrammableMoveCall{
+			Package:       pkg,
+			Module:        c.MoveCall.GetModule(),
+			Function:      c.MoveCall.GetFunction(),
+			TypeArguments: typeArgs,
+			Arguments:     args,
+		}}, nil
+	case *v2.Command_TransferObjects:
+		objs, err := argumentsFromProto(c.TransferObjects.GetObjects())
+		if err != nil {
+			return types.Command{}, err
+		}
+		addr, err := argumentFromProto(c.TransferObjects.GetAddress())
+		if err != nil {
+			return types.Command{}, err
+		}
+		return types.Command{TransferObjects: &types.TransferObjectsCommand{Objects: objs, Address: addr}}, nil
+	case *v2.Command_SplitCoins:
+		coin, err := argumentFromProto(c.SplitCoins.GetCoin())
+		if err != nil {
+			return types.Command{}, err
+		}
+		amounts, err := argumentsFromProto(c.SplitCoins.GetAmounts())
+		if err != nil {
+			return types.Command{}, err
+		}
+		return types.Command{SplitCoins: &types.SplitCoinsCommand{Coin: coin, Amounts: amounts}}, nil
+	case *v2.Command_MergeCoins:
+		dst, err := argumentFromProto(c.MergeCoins.GetCoin())
+		if err != nil {
+			return types.Command{}, err
+		}
+		srcs, err := argumentsFromProto(c.MergeCoins.GetCoinsToMerge())
+		if err != nil {
+			return types.Command{}, err
+		}
+		return types.Command{MergeCoins: &types.MergeCoinsCommand{Destination: dst, Sources: srcs}}, nil
+	case *v2.Command_Publish:
+		deps, err := addressesFromProto(c.Publish.GetDependencies())
+		if err != nil {
+			return types.Command{}, err
+		}
+		return types.Command{Publish: &types.PublishCommand{
+			Modules:      cloneModules(c.Publish.GetModules()),
+			Dependencies: deps,
+		}}, nil
+	case *v2.Command_MakeMoveVector:
+		var elemType *types.TypeTag
+		if c.MakeMoveVector.ElementType != nil {
+			tag, err := types.ParseTypeTag(c.MakeMoveVector.GetElementType())
+			if err != nil {
+				return types.Command{}, err
+			}
+			elemType = &tag
+		}
+		elems, err := argumentsFromProto(c.MakeMoveVector.GetElements())
+		if err != nil {
+			return types.Command{}, err
+		}
+		return types.Command{MakeMoveVec: &types.MakeMoveVecCommand{Type: elemType, Elements: elems}}, nil
+	case *v2.Command_Upgrade:
+		deps, err := addressesFromProto(c.Upgrade.GetDependencies())
+		if err != nil {
+			return types.Command{}, err
+		}
+		pkg, err := types.ParseAddress(c.Upgrade.GetPackage())
+		if err != nil {
+			return types.Command{}, fmt.Errorf("upgrade package: %w", err)
+		}
+		ticket, err := argumentFromProto(c.Upgrade.GetTicket())
+		if err != nil {
+			return types.Command{}, err
+		}
+		return types.Command{Upgrade: &types.UpgradeCommand{
+			Modules:      cloneModules(c.Upgrade.GetModules()),
+			Dependencies: deps,
+			Package:      pkg,
+			Ticket:       ticket,
+		}}, nil
+	default:
+		return types.Command{}, fmt.Errorf("unsupported command %T", c)
+	}
+}
+
+func argumentsFromProto(args []*v2.Argument) ([]types.Argument, error) {
+	out := make([]types.Argument, len(args))
+	for i, arg := range args {
+		converted, err := argumentFromProto(arg)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = converted
+	}
+	return out, nil
+}
+
+func argumentFromProto(arg *v2.Argument) (types.Argument, error) {
+	switch arg.GetKind() {
+	case v2.Argument_GAS:
+		return types.Argument{GasCoin: &bcs.None{}}, nil
+	case v2.Argument_INPUT:
+		idx, err := toUint16(arg.GetInput())
+		if err != nil {
+			return types.Argument{}, err
+		}
+		return types.Argument{Input: &idx}, nil
+	case v2.Argument_RESULT:
+		idx, err := toUint16(arg.GetResult())
+		if err != nil {
+			return types.Argument{}, err
+		}
+		if arg.Subresult == nil {
+			return types.Argument{Result: &idx}, nil
+		}
+		sub, err := toUint16(arg.GetSubresult())
+		if err != nil {
+			return types.Argument{}, err
+		}
+		return types.Argument{NestedResult: &types.NestedResult{Result: idx, Subresult: sub}}, nil
+	default:
+		return types.Argument{}, fmt.Errorf("%w: kind %s", ErrInvalidArgument, arg.GetKind())
+	}
+}
+
+func addressesFromProto(raw []string) ([]types.Address, error) {
+	out := make([]types.Address, len(raw))
+	for i, s := range raw {
+		addr, err := types.ParseAddress(s)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = addr
+	}
+	return out, nil
+}
+
+func toUint16(v uint32) (uint16, error) {
+	if v > math.MaxUint16 {
+		return 0, fmt.Errorf("%w: index %d exceeds u16", ErrInvalidArgument, v)
+	}
+	return uint16(v), nil
+}
+
+func programmableToProto(ptb *types.ProgrammableTransaction) (*v2.ProgrammableTransaction, error) {
+	out := &v2.ProgrammableTransaction{
+		Inputs:   make([]*v2.Input, len(ptb.Inputs)),
+		Commands: make([]*v2.Command, len(ptb.Commands)),
+	}
+	for i, in := range ptb.Inputs {
+		converted, err := callArgToProto(in)
+		if err != nil {
+			return nil, fmt.Errorf("transaction: input %d: %w", i, err)
+		}
+		out.Inputs[i] = converted
+	}
+	for i, cmd := range ptb.Commands {
+		converted, err := commandToProto(cmd)
+		if err != nil {
+			return nil, fmt.Errorf("transaction: command %d: %w", i, err)
+		}
+		out.Commands[i] = converted
+	}
+	return out, nil
+}
+
+func callArgToProto(arg types.CallArg) (*v2.Input, error) {
+	switch {
+	case arg.Pure != nil:
+		return &v2.Input{Kind: v2.Input_PURE.Enum(), Pure: append([]byte{}, (*arg.Pure)...)}, nil
+	case arg.Object == nil:
+		return nil, errors.New("empty call arg")
+	case arg.Object.ImmOrOwnedObject != nil:
+		return objectInputToProto(v2.Input_IMMUTABLE_OR_OWNED, *arg.Object.ImmOrOwnedObject), nil
+	case arg.Object.Receiving != nil:
+		return objectInputToProto(v2.Input_RECEIVING, *arg.Object.Receiving), nil
+	case arg.Object.SharedObject != nil:
+		shared := arg.Object.SharedObject
+		id := shared.ObjectID.String()
+		version := shared.InitialSharedVersion
+		mutable := shared.Mutable
+		return &v2.Input{Kind: v2.Input_SHARED.Enum(), ObjectId: &id, Version: &version, Mutable: &mutable}, nil
+	default:
+		return nil, errors.New("empty object arg")
+	}
+}
+
+func objectInputToProto(kind v2.Input_InputKind, ref types.ObjectRef) *v2.Input {
+	converted := objectRefToProto(ref)
+	return &v2.Input{
+		Kind:     kind.Enum(),
+		ObjectId: converted.ObjectId,
+		Version:  converted.Version,
+		Digest:   converted.Digest,
+	}
+}
+
+func objectRefToProto(ref types.ObjectRef) *v2.ObjectReference {
+	id := ref.ObjectID.String()
+	version := ref.Version
+	digest := ref.Digest.String()
+	return &v2.ObjectReference{ObjectId: &id, Version: &version, Digest: &digest}
+}
+
+func commandToProto(cmd types.Command) (*v2.Command, error) {
+	switch {
+	case cmd.MoveCall != nil:
+		mc := cmd.MoveCall
+		pkg := mc.Package.String()
+		module := mc.Module
+		function := mc.Function
+		typeArgs := make([]string, len(mc.TypeArguments))
+		for i, tag := range mc.TypeArguments {
+			typeArgs[i] = tag.String()
+		}
+		return &v2.Command{Command: &v2.Command_MoveCall{MoveCall: &v2.MoveCall{
+			Package:       &pkg,
+			Module:        &module,
+			Function:      &function,
+			TypeArguments: typeArgs,
+			Arguments:     argumentsToProto(mc.Arguments),
+		}}}, nil
+	case cmd.TransferObjects != nil:
+		return &v2.Command{Command: &v2.Command_TransferObjects{TransferObjects: &v2.TransferObjects{
+			Objects: argumentsToProto(cmd.TransferObjects.Objects),
+			Address: argumentToProto(cmd.TransferObjects.Address),
+		}}}, nil
+	case cmd.SplitCoins != nil:
+		return &v2.Command{Command: &v2.Command_SplitCoins{SplitCoins: &v2.SplitCoins{
+			Coin:    argumentToProto(cmd.SplitCoins.Coin),
+			Amounts: argumentsToProto(cmd.SplitCoins.Amounts),
+		}}}, nil
+	case cmd.MergeCoins != nil:
+		return &v2.Command{Command: &v2.Command_MergeCoins{MergeCoins: &v2.MergeCoins{
+			Coin:         argumentToProto(cmd.MergeCoins.Destination),
+			CoinsToMerge: argumentsToProto(cmd.MergeCoins.Sources),
+		}}}, nil
+	case cmd.Publish != nil:
+		return &v2.Command{Command: &v2.Command_Publish{Publish: &v2.Publish{
+			Modules:      cloneModules(cmd.Publish.Modules),
+			Dependencies: addressesToProto(cmd.Publish.Dependencies),
+		}}}, nil
+	case cmd.MakeMoveVec != nil:
+		out := &v2.MakeMoveVector{Elements: argumentsToProto(cmd.MakeMoveVec.Elements)}
+		if cmd.MakeMoveVec.Type != nil {
+			elemType := cmd.MakeMoveVec.Type.String()
+			out.ElementType = &elemType
+		}
+		return &v2.Command{Command: &v2.Command_MakeMoveVector{MakeMoveVector: out}}, nil
+	case cmd.Upgrade != nil:
+		pkg := cmd.Upgrade.Package.String()
+		return &v2.Command{Command: &v2.Command_Upgrade{Upgrade: &v2.Upgrade{
+			Modules:      cloneModules(cmd.Upgrade.Modules),
+			Dependencies: addressesToProto(cmd.Upgrade.Dependencies),
+			Package:      &pkg,
+			Ticket:       argumentToProto(cmd.Upgrade.Ticket),
+		}}}, nil
+	default:
+		return nil, errors.New("empty command")
+	}
+}
+
+func argumentsToProto(args []types.Argument) []*v2.Argument {
+	out := make([]*v2.Argument, len(args))
+	for i, arg := range args {
+		out[i] = argumentToProto(arg)
+	}
+	return out
+}
+
+func argumentToProto(arg types.Argument) *v2.Argument {
+	switch {
+	case arg.GasCoin != nil:
+		return gasArgument().Proto()
+	case arg.Input != nil:
+		return inputArgument(uint32(*arg.Input)).Proto()
+	case arg.Result != nil:
+		return resultArgument(uint32(*arg.Result)).Proto()
+	case arg.NestedResult != nil:
+		return resultArgument(uint32(arg.NestedResult.Result)).Nested(uint32(arg.NestedResult.Subresult)).Proto()
+	default:
+		return Argument{}.Proto()
+	}
+}
+
+func addressesToProto(addrs []types.Address) []string {
+	out := make([]string, len(addrs))
+	for i, addr := range addrs {
+		out[i] = addr.String()
+	}
+	return out
+}
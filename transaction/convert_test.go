@@ -0,0 +1,100 @@
+package transaction
+
+import (
+	"errors"
+	"testing"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/0xdraco/sui-go-sdk/types"
+	"google.golang.org/protobuf/proto"
+)
+
+func testObjectRef(id string, version uint64) *v2.ObjectReference {
+	digest := types.Digest{byte(version)}.String()
+	return &v2.ObjectReference{ObjectId: stringPtr(id), Version: &version, Digest: &digest}
+}
+
+func TestTransactionBCSRoundTrip(t *testing.T) {
+	b := NewTransactionBuilder()
+	b.SetSender(testSender).SetGasPrice(1000).SetGasBudget(10_000_000).SetExpiration(42)
+	b.SetGasPayment(testObjectRef(testCoinID, 9))
+
+	coin := b.Object(testObjectRef("0x1234", 3))
+	parts := b.SplitCoins(coin, b.PureU64(1))
+	vec := b.MakeMoveVector("0x2::coin::Coin<0x2::sui::SUI>", parts[0])
+	b.MoveCall("0x2::pay::join_vec", []string{"0x2::sui::SUI"}, coin, vec)
+	b.MoveCall("0x2::clock::timestamp_ms", nil, b.SharedObject("0x6", 1, false))
+
+	tx, err := b.Build()
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+
+	encoded, err := MarshalTransaction(tx)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	digest, err := Digest(tx)
+	if err != nil {
+		t.Fatalf("digest: %v", err)
+	}
+	if want := types.TransactionDigest(encoded).String(); digest != want {
+		t.Fatalf("digest mismatch: got %s want %s", digest, want)
+	}
+
+	decoded, err := UnmarshalTransaction(encoded)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if decoded.GetDigest() != digest {
+		t.Fatalf("decoded digest mismatch: got %s want %s", decoded.GetDigest(), digest)
+	}
+	if decoded.GetGasPayment().GetOwner() != testSender {
+		t.Fatalf("gas owner should default to sender, got %s", decoded.GetGasPayment().GetOwner())
+	}
+
+	// Only the Bcs payload: bytes must be reused verbatim.
+	bcsOnly := &v2.Transaction{Bcs: proto.Clone(decoded.GetBcs()).(*v2.Bcs)}
+	if d, err := Digest(bcsOnly); err != nil || d != digest {
+		t.Fatalf("bcs-only digest: got %s, %v", d, err)
+	}
+
+	// Structured fields from the decoded transaction must re-encode identically.
+	decoded.Bcs = nil
+	if d, err := Digest(decoded); err != nil || d != digest {
+		t.Fatalf("re-encoded digest: got %s, %v", d, err)
+	}
+	call := decoded.GetKind().GetProgrammableTransaction().GetCommands()[2].GetMoveCall()
+	if got := call.GetTypeArguments()[0]; got != "0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI" {
+		t.Fatalf("unexpected type argument %s", got)
+	}
+}
+
+func TestTransactionBCSRequiresResolvedData(t *testing.T) {
+	b := NewTransactionBuilder()
+	b.SetSender(testSender).SetGasPrice(1000).SetGasBudget(10_000_000)
+	b.SetGasPayment(testObjectRef(testCoinID, 9))
+	b.TransferObjects([]Argument{b.ObjectID("0x1234")}, b.PureAddress(testSender))
+
+	tx, err := b.Build()
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	if _, err := MarshalTransaction(tx); !errors.Is(err, ErrIncompleteTransaction) {
+		t.Fatalf("expected ErrIncompleteTransaction for unresolved input, got %v", err)
+	}
+
+	resolved := testObjectRef("0x1234", 3)
+	input := tx.GetKind().GetProgrammableTransaction().GetInputs()[0]
+	input.Kind = v2.Input_IMMUTABLE_OR_OWNED.Enum()
+	input.Version = resolved.Version
+	input.Digest = resolved.Digest
+	if _, err := MarshalTransaction(tx); err != nil {
+		t.Fatalf("marshal resolved transaction: %v", err)
+	}
+
+	tx.GasPayment = nil
+	if _, err := MarshalTransaction(tx); !errors.Is(err, ErrIncompleteTransaction) {
+		t.Fatalf("expected ErrIncompleteTransaction for missing gas, got %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package transaction
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/0xdraco/sui-go-sdk/types"
+	"github.com/iotaledger/bcs-go"
+	"google.golang.org/protobuf/proto"
+)
+
+// Pure adds a pure input holding the BCS encoding of value. Inputs with
+// identical encodings are shared.
+func (b *TransactionBuilder) Pure(value any) Argument {
+	if b.err != nil {
+		return Argument{}
+	}
+	enc := bcs.NewBytesEncoder()
+	enc.Encode(value)
+	if err := enc.Err(); err != nil {
+		b.setErr(fmt.Errorf("transaction: encode pure value: %w", err))
+		return Argument{}
+	}
+	return b.PureBytes(enc.Bytes())
+}
+
+// PureBytes adds a pure input from already BCS-encoded bytes.
+func (b *TransactionBuilder) PureBytes(encoded []byte) Argument {
+	if b.err != nil {
+		return Argument{}
+	}
+	key := string(encoded)
+	if idx, ok := b.pureIndex[key]; ok {
+		return inputArgument(idx)
+	}
+
+	idx := uint32(len(b.inputs))
+	b.inputs = append(b.inputs, &v2.Input{
+		Kind: v2.Input_PURE.Enum(),
+		Pure: append([]byte(nil), encoded...),
+	})
+	b.pureIndex[key] = idx
+	return inputArgument(idx)
+}
+
+// PureBool adds a pure bool input.
+func (b *TransactionBuilder) PureBool(v bool) Argument {
+	return b.Pure(v)
+}
+
+// PureU8 adds a pure u8 input.
+func (b *TransactionBuilder) PureU8(v uint8) Argument {
+	return b.Pure(v)
+}
+
+// PureU64 adds a pure u64 input.
+func (b *TransactionBuilder) PureU64(v uint64) Argument {
+	return b.Pure(v)
+}
+
+// PureU128 adds a pure u128 input.
+func (b *TransactionBuilder) PureU128(v *big.Int) Argument {
+	return b.pureUint(v, 16, "u128")
+}
+
+// PureU256 adds a pure u256 input.
+func (b *TransactionBuilder) PureU256(v *big.Int) Argument {
+	return b.pureUint(v, 32, "u256")
+}
+
+// pureUint adds v as a little-endian unsigned integer of size bytes.
+func (b *TransactionBuilder) pureUint(v *big.Int, size int, name string) Argument {
+	if b.err != nil {
+		return Argument{}
+	}
+	if v == nil || v.Sign() < 0 || v.BitLen() > size*8 {
+		b.setErr(fmt.Errorf("transaction: pure %s: %v out of range", name, v))
+		return Argument{}
+	}
+	encoded := v.FillBytes(make([]byte, size))
+	slices.Reverse(encoded)
+	return b.PureBytes(encoded)
+}
+
+// PureString adds a pure input holding a Move String (or vector<u8>).
+func (b *TransactionBuilder) PureString(v string) Argument {
+	return b.Pure(v)
+}
+
+// PureAddress adds a pure address input, accepting short or long hex forms.
+func (b *TransactionBuilder) PureAddress(address string) Argument {
+	if b.err != nil {
+		return Argument{}
+	}
+	addr, err := types.ParseAddress(address)
+	if err != nil {
+		b.setErr(fmt.Errorf("transaction: pure address: %w", err))
+		return Argument{}
+	}
+	return b.PureBytes(addr[:])
+}
+
+// Object adds an owned or immutable object input pinned to the given reference.
+func (b *TransactionBuilder) Object(ref *v2.ObjectReference) Argument {
+	if ref == nil {
+		b.setErr(fmt.Errorf("transaction: nil object reference"))
+		return Argument{}
+	}
+	return b.addObject(ref.GetObjectId(), &v2.Input{
+		Kind:     v2.Input_IMMUTABLE_OR_OWNED.Enum(),
+		ObjectId: stringPtr(ref.GetObjectId()),
+		Version:  cloneUint64(ref.Version),
+		Digest:   cloneString(ref.Digest),
+	})
+}
+
+// ObjectID adds an object input identified only by its ID. The fullnode fills
+// in the kind, version and digest, so transactions using unresolved inputs
+// must go through SimulateTransaction before they can be signed.
+func (b *TransactionBuilder) ObjectID(objectID string) Argument {
+	return b.addObject(objectID, &v2.Input{ObjectId: stringPtr(objectID)})
+}
+
+// SharedObject adds a shared object input. Using the same shared object more
+// than once widens the access to mutable if any use requests it.
+func (b *TransactionBuilder) SharedObject(objectID string, initialSharedVersion uint64, mutable bool) Argument {
+	return b.addObject(objectID, &v2.Input{
+		Kind:     v2.Input_SHARED.Enum(),
+		ObjectId: stringPtr(objectID),
+		Version:  &initialSharedVersion,
+		Mutable:  &mutable,
+	})
+}
+
+// ReceivingObject adds an object that is being received by another object in this transaction.
+func (b *TransactionBuilder) ReceivingObject(ref *v2.ObjectReference) Argument {
+	if ref == nil {
+		b.setErr(fmt.Errorf("transaction: nil object reference"))
+		return Argument{}
+	}
+	return b.addObject(ref.GetObjectId(), &v2.Input{
+		Kind:     v2.Input_RECEIVING.Enum(),
+		ObjectId: stringPtr(ref.GetObjectId()),
+		Version:  cloneUint64(ref.Version),
+		Digest:   cloneString(ref.Digest),
+	})
+}
+
+func (b *TransactionBuilder) addObject(objectID string, input *v2.Input) Argument {
+	if b.err != nil {
+		return Argument{}
+	}
+	addr, err := types.ParseAddress(objectID)
+	if err != nil {
+		b.setErr(fmt.Errorf("transaction: object input: %w", err))
+		return Argument{}
+	}
+	input.ObjectId = stringPtr(addr.String())
+
+	idx, ok := b.objectIndex[addr]
+	if !ok {
+		idx = uint32(len(b.inputs))
+		b.inputs = append(b.inputs, input)
+		b.objectIndex[addr] = idx
+		return inputArgument(idx)
+	}
+
+	existing := b.inputs[idx]
+	switch {
+	case input.Kind == nil:
+		// An unresolved reference never downgrades what we already know.
+	case existing.Kind == nil:
+		b.inputs[idx] = input
+	case existing.GetKind() != input.GetKind():
+		b.setErr(fmt.Errorf("transaction: object %s used as both %s and %s", addr, existing.GetKind(), input.GetKind()))
+		return Argument{}
+	case input.GetKind() == v2.Input_SHARED:
+		if existing.GetVersion() != input.GetVersion() {
+			b.setErr(fmt.Errorf("transaction: shared object %s has conflicting initial versions %d and %d", addr, existing.GetVersion(), input.GetVersion()))
+			return Argument{}
+		}
+		if input.GetMutable() {
+			existing.Mutable = proto.Bool(true)
+		}
+	case existing.GetVersion() != input.GetVersion() || existing.GetDigest() != input.GetDigest():
+		b.setErr(fmt.Errorf("transaction: object %s referenced at conflicting versions", addr))
+		return Argument{}
+	}
+	return inputArgument(idx)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func cloneString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	v := *s
+	return &v
+}
+
+func cloneUint64(v *uint64) *uint64 {
+	if v == nil {
+		return nil
+	}
+	out := *v
+	return &out
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// AddressLength is the size in bytes of a Sui address or object ID.
+const AddressLength = 32
+
+var ErrInvalidAddress = errors.New("invalid sui address")
+
+// Address is a 32-byte Sui account address or object ID.
+type Address [AddressLength]byte
+
+// ParseAddress decodes a hex-encoded address, accepting an optional 0x prefix
+// and short forms (e.g. "0x2") which are left-padded with zeros.
+func ParseAddress(raw string) (Address, error) {
+	s := strings.TrimSpace(raw)
+	s = strings.TrimPrefix(strings.TrimPrefix(s, "0x"), "0X")
+	if s == "" || len(s) > AddressLength*2 {
+		return Address{}, fmt.Errorf("%w: %q", ErrInvalidAddress, raw)
+	}
+	if len(s)%2 == 1 {
+		s = "0" + s
+	}
+
+	decoded, err := hex.DecodeString(s)
+	if err != nil {
+		return Address{}, fmt.Errorf("%w: %q: %v", ErrInvalidAddress, raw, err)
+	}
+
+	var addr Address
+	copy(addr[AddressLength-len(decoded):], decoded)
+	return addr, nil
+}
+
+// MustParseAddress is like ParseAddress but panics on malformed input. It is
+// intended for package-level constants such as framework addresses.
+func MustParseAddress(raw string) Address {
+	addr, err := ParseAddress(raw)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
+// NormalizeAddress returns the canonical 0x-prefixed, 64 hex character form of raw.
+func NormalizeAddress(raw string) (string, error) {
+	addr, err := ParseAddress(raw)
+	if err != nil {
+		return "", err
+	}
+	return addr.String(), nil
+}
+
+// String renders the address in its canonical 0x-prefixed long form.
+func (a Address) String() string {
+	return "0x" + hex.EncodeToString(a[:])
+}
+
+// Bytes returns a copy of the raw address bytes.
+func (a Address) Bytes() []byte {
+	out := make([]byte, AddressLength)
+	copy(out, a[:])
+	return out
+}
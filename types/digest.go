@@ -0,0 +1,55 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/iotaledger/bcs-go"
+)
+
+// DigestLength is the size in bytes of transaction, object and checkpoint digests.
+const DigestLength = 32
+
+var ErrInvalidDigest = errors.New("invalid digest")
+
+// Digest is a 32-byte Blake2b digest rendered as base58. On the wire it is
+// BCS-encoded as a length-prefixed byte vector.
+type Digest [DigestLength]byte
+
+// ParseDigest decodes a base58 digest string.
+func ParseDigest(encoded string) (Digest, error) {
+	raw := base58.Decode(encoded)
+	if len(raw) != DigestLength {
+		return Digest{}, fmt.Errorf("%w: %q", ErrInvalidDigest, encoded)
+	}
+	var d Digest
+	copy(d[:], raw)
+	return d, nil
+}
+
+// String renders the digest as base58.
+func (d Digest) String() string {
+	return base58.Encode(d[:])
+}
+
+func (d Digest) MarshalBCS(e *bcs.Encoder) error {
+	e.WriteLen(DigestLength)
+	_, _ = e.Write(d[:])
+	return nil
+}
+
+func (d *Digest) UnmarshalBCS(dec *bcs.Decoder) error {
+	if n := dec.ReadLen(); n != DigestLength {
+		return fmt.Errorf("%w: length %d", ErrInvalidDigest, n)
+	}
+	raw, err := dec.ReadN(DigestLength)
+	if err != nil {
+		return err
+	}
+	if len(raw) != DigestLength {
+		return fmt.Errorf("%w: short read", ErrInvalidDigest)
+	}
+	copy(d[:], raw)
+	return nil
+}
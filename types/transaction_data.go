@@ -0,0 +1,213 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/bcs-go"
+	"golang.org/x/crypto/blake2b"
+)
+
+// transactionDataDigestSalt is the type-name prefix Sui hashes together with
+// the BCS bytes of a TransactionData to produce its digest.
+const transactionDataDigestSalt = "TransactionData::"
+
+var ErrUnsupportedTransactionKind = errors.New("unsupported transaction kind")
+
+// The types below mirror the BCS layout of Sui's TransactionData. Enums are
+// modelled as structs with one pointer field per variant, declared in variant
+// order; exactly one field must be set. Only user (programmable) transactions
+// are modelled; system transaction kinds fail to decode with an error.
+
+// TransactionData is the payload signed by the sender and gas owner.
+type TransactionData struct {
+	V1 *TransactionDataV1
+}
+
+func (TransactionData) IsBcsEnum() {}
+
+// TransactionDataV1 is the only TransactionData version currently in use.
+type TransactionDataV1 struct {
+	Kind       TransactionKind
+	Sender     Address
+	GasData    GasData
+	Expiration TransactionExpiration
+}
+
+// TransactionKind describes what the transaction does.
+type TransactionKind struct {
+	ProgrammableTransaction *ProgrammableTransaction
+}
+
+func (TransactionKind) IsBcsEnum() {}
+
+// GasData describes the coins, owner, price and budget used to pay for execution.
+type GasData struct {
+	Payment []ObjectRef
+	Owner   Address
+	Price   uint64
+	Budget  uint64
+}
+
+// TransactionExpiration bounds the epoch in which the transaction may execute.
+type TransactionExpiration struct {
+	None  *bcs.None
+	Epoch *uint64
+}
+
+func (TransactionExpiration) IsBcsEnum() {}
+
+// ObjectRef pins an object to a specific version and digest.
+type ObjectRef struct {
+	ObjectID Address
+	Version  uint64
+	Digest   Digest
+}
+
+// ProgrammableTransaction is a sequence of commands over a shared set of inputs.
+type ProgrammableTransaction struct {
+	Inputs   []CallArg
+	Commands []Command
+}
+
+// CallArg is a programmable transaction input.
+type CallArg struct {
+	Pure   *[]byte
+	Object *ObjectArg
+}
+
+func (CallArg) IsBcsEnum() {}
+
+// ObjectArg describes how an object input is accessed.
+type ObjectArg struct {
+	ImmOrOwnedObject *ObjectRef
+	SharedObject     *SharedObjectArg
+	Receiving        *ObjectRef
+}
+
+func (ObjectArg) IsBcsEnum() {}
+
+// SharedObjectArg references a shared object by its initial shared version.
+type SharedObjectArg struct {
+	ObjectID             Address
+	InitialSharedVersion uint64
+	Mutable              bool
+}
+
+// Command is a single step of a programmable transaction.
+type Command struct {
+	MoveCall        *ProgrammableMoveCall
+	TransferObjects *TransferObjectsCommand
+	SplitCoins      *SplitCoinsCommand
+	MergeCoins      *MergeCoinsCommand
+	Publish         *PublishCommand
+	MakeMoveVec     *MakeMoveVecCommand
+	Upgrade         *UpgradeCommand
+}
+
+func (Command) IsBcsEnum() {}
+
+// ProgrammableMoveCall calls a Move function.
+type ProgrammableMoveCall struct {
+	Package       Address
+	Module        string
+	Function      string
+	TypeArguments []TypeTag
+	Arguments     []Argument
+}
+
+// TransferObjectsCommand sends objects to an address.
+type TransferObjectsCommand struct {
+	Objects []Argument
+	Address Argument
+}
+
+// SplitCoinsCommand splits a coin into new coins of the given amounts.
+type SplitCoinsCommand struct {
+	Coin    Argument
+	Amounts []Argument
+}
+
+// MergeCoinsCommand merges coins into a destination coin.
+type MergeCoinsCommand struct {
+	Destination Argument
+	Sources     []Argument
+}
+
+// PublishCommand publishes a new package.
+type PublishCommand struct {
+	Modules      [][]byte
+	Dependencies []Address
+}
+
+// MakeMoveVecCommand builds a vector, optionally with an explicit element type.
+type MakeMoveVecCommand struct {
+	Type     *TypeTag `bcs:"optional"`
+	Elements []Argument
+}
+
+// UpgradeCommand upgrades an existing package.
+type UpgradeCommand struct {
+	Modules      [][]byte
+	Dependencies []Address
+	Package      Address
+	Ticket       Argument
+}
+
+// Argument references the gas coin, an input, or a command result.
+type Argument struct {
+	GasCoin      *bcs.None
+	Input        *uint16
+	Result       *uint16
+	NestedResult *NestedResult
+}
+
+func (Argument) IsBcsEnum() {}
+
+// NestedResult selects one value of a command that returns several.
+type NestedResult struct {
+	Result    uint16
+	Subresult uint16
+}
+
+// MarshalTransactionData BCS-encodes data.
+func MarshalTransactionData(data *TransactionData) ([]byte, error) {
+	if data == nil {
+		return nil, errors.New("transaction data: nil value")
+	}
+	encoded, err := bcs.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("transaction data: marshal: %w", err)
+	}
+	return encoded, nil
+}
+
+// UnmarshalTransactionData decodes BCS-encoded TransactionData.
+func UnmarshalTransactionData(encoded []byte) (*TransactionData, error) {
+	data, err := bcs.Unmarshal[TransactionData](encoded)
+	if err != nil {
+		return nil, fmt.Errorf("transaction data: unmarshal: %w", err)
+	}
+	return &data, nil
+}
+
+// TransactionDigest computes the digest of BCS-encoded TransactionData, as it
+// appears in ExecutedTransaction.Digest once rendered with String.
+func TransactionDigest(encoded []byte) Digest {
+	hasher, _ := blake2b.New256(nil)
+	hasher.Write([]byte(transactionDataDigestSalt))
+	hasher.Write(encoded)
+
+	var d Digest
+	copy(d[:], hasher.Sum(nil))
+	return d
+}
+
+// Digest computes the transaction digest of data.
+func (data *TransactionData) Digest() (Digest, error) {
+	encoded, err := MarshalTransactionData(data)
+	if err != nil {
+		return Digest{}, err
+	}
+	return TransactionDigest(encoded), nil
+}
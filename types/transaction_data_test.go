@@ -0,0 +1,114 @@
+package types
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/iotaledger/bcs-go"
+	"golang.org/x/crypto/blake2b"
+)
+
+func TestParseTypeTagRoundTrip(t *testing.T) {
+	cases := map[string]string{
+		"u64":                            "u64",
+		"vector<vector<u8>>":             "vector<vector<u8>>",
+		"0x2::sui::SUI":                  "0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI",
+		"0x2::coin::Coin<0x2::sui::SUI>": "0x0000000000000000000000000000000000000000000000000000000000000002::coin::Coin<0x0000000000000000000000000000000000000000000000000000000000000002::sui::SUI>",
+		"0x1::m::Pair< u8 ,address >":    "0x0000000000000000000000000000000000000000000000000000000000000001::m::Pair<u8, address>",
+	}
+	for input, want := range cases {
+		tag, err := ParseTypeTag(input)
+		if err != nil {
+			t.Fatalf("parse %q: %v", input, err)
+		}
+		if got := tag.String(); got != want {
+			t.Fatalf("parse %q: got %s want %s", input, got, want)
+		}
+
+		encoded, err := bcs.Marshal(&tag)
+		if err != nil {
+			t.Fatalf("marshal %q: %v", input, err)
+		}
+		decoded, err := bcs.Unmarshal[TypeTag](encoded)
+		if err != nil {
+			t.Fatalf("unmarshal %q: %v", input, err)
+		}
+		if decoded.String() != want {
+			t.Fatalf("bcs round trip %q: got %s", input, decoded.String())
+		}
+	}
+
+	for _, bad := range []string{"", "vector<u8", "0x2::coin", "0x2::coin::Coin<u8", "u64 extra", "0xzz::m::S"} {
+		if _, err := ParseTypeTag(bad); err == nil {
+			t.Fatalf("expected %q to fail", bad)
+		}
+	}
+}
+
+func TestTransactionDataEncoding(t *testing.T) {
+	input := uint16(0)
+	pure := []byte{0x2a}
+	epoch := uint64(5)
+	data := &TransactionData{V1: &TransactionDataV1{
+		Kind: TransactionKind{ProgrammableTransaction: &ProgrammableTransaction{
+			Inputs: []CallArg{{Pure: &pure}},
+			Commands: []Command{{SplitCoins: &SplitCoinsCommand{
+				Coin:    Argument{GasCoin: &bcs.None{}},
+				Amounts: []Argument{{Input: &input}},
+			}}},
+		}},
+		Sender: MustParseAddress("0x1"),
+		GasData: GasData{
+			Payment: []ObjectRef{{ObjectID: MustParseAddress("0x5"), Version: 7, Digest: Digest{0xff}}},
+			Owner:   MustParseAddress("0x1"),
+			Price:   1000,
+			Budget:  2000,
+		},
+		Expiration: TransactionExpiration{Epoch: &epoch},
+	}}
+
+	encoded, err := MarshalTransactionData(data)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	// V1, ProgrammableTransaction, one pure input of one byte, one SplitCoins(GasCoin, [Input(0)]).
+	wantPrefix := []byte{0x00, 0x00, 0x01, 0x00, 0x01, 0x2a, 0x01, 0x02, 0x00, 0x01, 0x01, 0x00, 0x00}
+	if !bytes.HasPrefix(encoded, wantPrefix) {
+		t.Fatalf("unexpected prefix: %x", encoded[:len(wantPrefix)])
+	}
+	// The object digest is a length-prefixed vector.
+	digestOffset := len(wantPrefix) + AddressLength + 1 + AddressLength + 8
+	if encoded[digestOffset] != DigestLength || encoded[digestOffset+1] != 0xff {
+		t.Fatalf("object digest not length-prefixed: %x", encoded[digestOffset:digestOffset+2])
+	}
+	wantSuffix := []byte{0x01, 0x05, 0, 0, 0, 0, 0, 0, 0}
+	if !bytes.HasSuffix(encoded, wantSuffix) {
+		t.Fatalf("unexpected expiration encoding: %x", encoded[len(encoded)-len(wantSuffix):])
+	}
+
+	decoded, err := UnmarshalTransactionData(encoded)
+	if err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	reencoded, err := MarshalTransactionData(decoded)
+	if err != nil {
+		t.Fatalf("re-marshal: %v", err)
+	}
+	if !bytes.Equal(encoded, reencoded) {
+		t.Fatalf("round trip mismatch")
+	}
+
+	digest, err := data.Digest()
+	if err != nil {
+		t.Fatalf("digest: %v", err)
+	}
+	want := blake2b.Sum256(append([]byte("TransactionData::"), encoded...))
+	if digest != Digest(want) {
+		t.Fatalf("digest mismatch: got %s", digest)
+	}
+	parsed, err := ParseDigest(digest.String())
+	if err != nil || parsed != digest {
+		t.Fatalf("digest base58 round trip failed: %v", err)
+	}
+}
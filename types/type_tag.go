@@ -0,0 +1,218 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iotaledger/bcs-go"
+)
+
+// TypeTag mirrors Move's TypeTag enum. Exactly one field is set; the field
+// order matches the on-chain variant indices.
+type TypeTag struct {
+	Bool    *bcs.None
+	U8      *bcs.None
+	U64     *bcs.None
+	U128    *bcs.None
+	Address *bcs.None
+	Signer  *bcs.None
+	Vector  *TypeTag
+	Struct  *StructTag
+	U16     *bcs.None
+	U32     *bcs.None
+	U256    *bcs.None
+}
+
+func (TypeTag) IsBcsEnum() {}
+
+// StructTag identifies a Move struct type, including its type parameters.
+type StructTag struct {
+	Address    Address
+	Module     string
+	Name       string
+	TypeParams []TypeTag
+}
+
+var primitiveTypeTags = map[string]func() TypeTag{
+	"bool":    func() TypeTag { return TypeTag{Bool: &bcs.None{}} },
+	"u8":      func() TypeTag { return TypeTag{U8: &bcs.None{}} },
+	"u16":     func() TypeTag { return TypeTag{U16: &bcs.None{}} },
+	"u32":     func() TypeTag { return TypeTag{U32: &bcs.None{}} },
+	"u64":     func() TypeTag { return TypeTag{U64: &bcs.None{}} },
+	"u128":    func() TypeTag { return TypeTag{U128: &bcs.None{}} },
+	"u256":    func() TypeTag { return TypeTag{U256: &bcs.None{}} },
+	"address": func() TypeTag { return TypeTag{Address: &bcs.None{}} },
+	"signer":  func() TypeTag { return TypeTag{Signer: &bcs.None{}} },
+}
+
+// ParseTypeTag parses a Move type such as "u64", "vector<u8>" or
+// "0x2::coin::Coin<0x2::sui::SUI>". Addresses may use the short form.
+func ParseTypeTag(raw string) (TypeTag, error) {
+	p := &typeTagParser{input: raw}
+	tag, err := p.parseType()
+	if err != nil {
+		return TypeTag{}, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.input) {
+		return TypeTag{}, fmt.Errorf("type tag %q: unexpected trailing input at %d", raw, p.pos)
+	}
+	return tag, nil
+}
+
+// ParseStructTag parses a struct type such as "0x2::coin::Coin<0x2::sui::SUI>".
+func ParseStructTag(raw string) (StructTag, error) {
+	tag, err := ParseTypeTag(raw)
+	if err != nil {
+		return StructTag{}, err
+	}
+	if tag.Struct == nil {
+		return StructTag{}, fmt.Errorf("type tag %q is not a struct", raw)
+	}
+	return *tag.Struct, nil
+}
+
+// String renders the type tag in canonical form with long addresses.
+func (t TypeTag) String() string {
+	switch {
+	case t.Bool != nil:
+		return "bool"
+	case t.U8 != nil:
+		return "u8"
+	case t.U16 != nil:
+		return "u16"
+	case t.U32 != nil:
+		return "u32"
+	case t.U64 != nil:
+		return "u64"
+	case t.U128 != nil:
+		return "u128"
+	case t.U256 != nil:
+		return "u256"
+	case t.Address != nil:
+		return "address"
+	case t.Signer != nil:
+		return "signer"
+	case t.Vector != nil:
+		return "vector<" + t.Vector.String() + ">"
+	case t.Struct != nil:
+		return t.Struct.String()
+	default:
+		return "<invalid>"
+	}
+}
+
+// String renders the struct tag as address::module::Name<params>.
+func (s StructTag) String() string {
+	var sb strings.Builder
+	sb.WriteString(s.Address.String())
+	sb.WriteString("::")
+	sb.WriteString(s.Module)
+	sb.WriteString("::")
+	sb.WriteString(s.Name)
+	if len(s.TypeParams) > 0 {
+		sb.WriteByte('<')
+		for i, param := range s.TypeParams {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(param.String())
+		}
+		sb.WriteByte('>')
+	}
+	return sb.String()
+}
+
+type typeTagParser struct {
+	input string
+	pos   int
+}
+
+func (p *typeTagParser) skipSpaces() {
+	for p.pos < len(p.input) && p.input[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *typeTagParser) consume(token string) bool {
+	p.skipSpaces()
+	if strings.HasPrefix(p.input[p.pos:], token) {
+		p.pos += len(token)
+		return true
+	}
+	return false
+}
+
+func (p *typeTagParser) ident() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+		if c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.input[start:p.pos]
+}
+
+func (p *typeTagParser) parseType() (TypeTag, error) {
+	start := p.pos
+	name := p.ident()
+	if name == "" {
+		return TypeTag{}, fmt.Errorf("type tag %q: expected identifier at %d", p.input, p.pos)
+	}
+
+	if ctor, ok := primitiveTypeTags[name]; ok {
+		return ctor(), nil
+	}
+	if name == "vector" {
+		if !p.consume("<") {
+			return TypeTag{}, fmt.Errorf("type tag %q: expected '<' after vector", p.input)
+		}
+		inner, err := p.parseType()
+		if err != nil {
+			return TypeTag{}, err
+		}
+		if !p.consume(">") {
+			return TypeTag{}, fmt.Errorf("type tag %q: expected '>' to close vector", p.input)
+		}
+		return TypeTag{Vector: &inner}, nil
+	}
+
+	addr, err := ParseAddress(name)
+	if err != nil {
+		return TypeTag{}, fmt.Errorf("type tag %q: invalid address %q at %d", p.input, name, start)
+	}
+	if !p.consume("::") {
+		return TypeTag{}, fmt.Errorf("type tag %q: expected '::' after address", p.input)
+	}
+	module := p.ident()
+	if module == "" || !p.consume("::") {
+		return TypeTag{}, fmt.Errorf("type tag %q: expected module name", p.input)
+	}
+	structName := p.ident()
+	if structName == "" {
+		return TypeTag{}, fmt.Errorf("type tag %q: expected struct name", p.input)
+	}
+
+	tag := &StructTag{Address: addr, Module: module, Name: structName}
+	if p.consume("<") {
+		for {
+			param, err := p.parseType()
+			if err != nil {
+				return TypeTag{}, err
+			}
+			tag.TypeParams = append(tag.TypeParams, param)
+			if p.consume(",") {
+				continue
+			}
+			if p.consume(">") {
+				break
+			}
+			return TypeTag{}, fmt.Errorf("type tag %q: expected ',' or '>' at %d", p.input, p.pos)
+		}
+	}
+	return TypeTag{Struct: tag}, nil
+}
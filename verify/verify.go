@@ -0,0 +1,216 @@
+// Package verify checks serialized Sui signatures without holding a keypair or
+// calling a fullnode.
+//
+// The signature flag selects the scheme: ed25519, secp256k1 and secp256r1
+// signatures carry their public key, multisig signatures carry their
+// committee and passkey signatures carry their secp256r1 key, so the signer's
+// address can be derived and compared with the address the caller expects.
+// zkLogin signatures need the issuer's current JWKs and are verified by the
+// fullnode's SignatureVerificationService instead.
+package verify
+
+import (
+	"errors"
+	"fmt"
+
+	ed25519keys "github.com/0xdraco/sui-go-sdk/cryptography/ed25519"
+	"github.com/0xdraco/sui-go-sdk/cryptography/intent"
+	"github.com/0xdraco/sui-go-sdk/cryptography/personalmsg"
+	secp256k1keys "github.com/0xdraco/sui-go-sdk/cryptography/secp256k1"
+	secp256r1keys "github.com/0xdraco/sui-go-sdk/cryptography/secp256r1"
+	"github.com/0xdraco/sui-go-sdk/cryptography/txmsg"
+	"github.com/0xdraco/sui-go-sdk/keychain"
+	"github.com/0xdraco/sui-go-sdk/multisig"
+	"github.com/0xdraco/sui-go-sdk/passkey"
+	"github.com/0xdraco/sui-go-sdk/types"
+	"github.com/0xdraco/sui-go-sdk/zklogin"
+)
+
+const signatureSize = 64
+
+var (
+	// ErrAddressMismatch indicates a valid signature from a different address than expected.
+	ErrAddressMismatch = errors.New("verify: signer address does not match expected address")
+	// ErrUnsupportedScheme indicates the signature scheme cannot be verified locally.
+	ErrUnsupportedScheme = errors.New("verify: unsupported signature scheme")
+	// ErrInvalidSignature indicates a malformed serialized signature.
+	ErrInvalidSignature = errors.New("verify: invalid signature")
+	// ErrMissingAddress indicates Verify was called without an expected address.
+	ErrMissingAddress = errors.New("verify: expected address is empty")
+)
+
+// PersonalMessage verifies a serialized signature over a personal message and
+// checks that it was produced by expectedAddress.
+func PersonalMessage(message []byte, serialized []byte, expectedAddress string) error {
+	return Verify(intent.IntentScopePersonalMessage, message, serialized, expectedAddress)
+}
+
+// Transaction verifies a serialized signature over BCS-encoded TransactionData
+// and checks that it was produced by expectedAddress.
+func Transaction(txBytes []byte, serialized []byte, expectedAddress string) error {
+	return Verify(intent.IntentScopeTransactionData, txBytes, serialized, expectedAddress)
+}
+
+// Verify verifies a serialized signature over message signed under scope,
+// which must be IntentScopePersonalMessage or IntentScopeTransactionData, and
+// checks that the signer's address equals expectedAddress. An empty
+// expectedAddress fails with ErrMissingAddress rather than accepting any
+// signer.
+func Verify(scope intent.IntentScope, message []byte, serialized []byte, expectedAddress string) error {
+	if expectedAddress == "" {
+		return ErrMissingAddress
+	}
+	digest, err := intentDigest(scope, message)
+	if err != nil {
+		return err
+	}
+	address, err := verifyDigest(digest, serialized)
+	if err != nil {
+		return err
+	}
+
+	expected, err := types.ParseAddress(expectedAddress)
+	if err != nil {
+		return fmt.Errorf("verify: expected address: %w", err)
+	}
+	signer, err := types.ParseAddress(address)
+	if err != nil {
+		return fmt.Errorf("verify: signer address: %w", err)
+	}
+	if signer != expected {
+		return fmt.Errorf("%w: signer %s, expected %s", ErrAddressMismatch, address, expectedAddress)
+	}
+	return nil
+}
+
+// VerifyWithoutAddressCheck verifies a serialized signature over message
+// signed under scope without checking who signed it. A valid result only
+// proves that the key embedded in the signature signed message; callers
+// authenticating a user must compare SignerAddress with the address they
+// expect, or use Verify.
+func VerifyWithoutAddressCheck(scope intent.IntentScope, message []byte, serialized []byte) error {
+	digest, err := intentDigest(scope, message)
+	if err != nil {
+		return err
+	}
+	_, err = verifyDigest(digest, serialized)
+	return err
+}
+
+// SignerAddress derives the address that a serialized signature claims to be
+// from, without verifying the signature.
+func SignerAddress(serialized []byte) (string, error) {
+	if len(serialized) == 0 {
+		return "", fmt.Errorf("%w: empty signature", ErrInvalidSignature)
+	}
+	switch serialized[0] {
+	case multisig.SignatureFlag:
+		sig, err := multisig.ParseSignature(serialized)
+		if err != nil {
+			return "", err
+		}
+		return sig.Committee().Address()
+	case zklogin.SignatureFlag:
+		sig, err := zklogin.ParseSignature(serialized)
+		if err != nil {
+			return "", err
+		}
+		return sig.Address()
+	case passkey.SignatureFlag:
+		sig, err := passkey.ParseSignature(serialized)
+		if err != nil {
+			return "", err
+		}
+		return sig.Address()
+	default:
+		scheme, publicKey, _, err := parseSimple(serialized)
+		if err != nil {
+			return "", err
+		}
+		return keychain.AddressFromPublicKey(scheme, publicKey)
+	}
+}
+
+func intentDigest(scope intent.IntentScope, message []byte) ([32]byte, error) {
+	var (
+		out [32]byte
+		err error
+	)
+	switch scope {
+	case intent.IntentScopePersonalMessage:
+		out, err = personalmsg.Digest(message)
+	case intent.IntentScopeTransactionData:
+		out, err = txmsg.Digest(message)
+	default:
+		return out, fmt.Errorf("verify: unsupported intent scope %d", scope)
+	}
+	if err != nil {
+		return out, fmt.Errorf("verify: %w", err)
+	}
+	return out, nil
+}
+
+// verifyDigest verifies serialized over the intent digest and returns the signer's address.
+func verifyDigest(digest [32]byte, serialized []byte) (string, error) {
+	if len(serialized) == 0 {
+		return "", fmt.Errorf("%w: empty signature", ErrInvalidSignature)
+	}
+
+	switch serialized[0] {
+	case multisig.SignatureFlag:
+		sig, err := multisig.ParseSignature(serialized)
+		if err != nil {
+			return "", err
+		}
+		if err := sig.VerifyDigest(digest); err != nil {
+			return "", err
+		}
+		return sig.Committee().Address()
+	case passkey.SignatureFlag:
+		sig, err := passkey.ParseSignature(serialized)
+		if err != nil {
+			return "", err
+		}
+		if err := sig.VerifyDigest(digest); err != nil {
+			return "", err
+		}
+		return sig.Address()
+	case zklogin.SignatureFlag:
+		return "", fmt.Errorf("%w: zklogin signatures must be verified by a fullnode", ErrUnsupportedScheme)
+	}
+
+	scheme, publicKey, signature, err := parseSimple(serialized)
+	if err != nil {
+		return "", err
+	}
+	switch scheme {
+	case keychain.SchemeEd25519:
+		err = ed25519keys.VerifyDigest(publicKey, digest, signature)
+	case keychain.SchemeSecp256k1:
+		err = secp256k1keys.VerifyDigest(publicKey, digest, signature)
+	case keychain.SchemeSecp256r1:
+		err = secp256r1keys.VerifyDigest(publicKey, digest, signature)
+	default:
+		err = fmt.Errorf("%w: %s", ErrUnsupportedScheme, scheme.Label())
+	}
+	if err != nil {
+		return "", err
+	}
+	return keychain.AddressFromPublicKey(scheme, publicKey)
+}
+
+// parseSimple splits a `flag || sig || pubkey` signature.
+func parseSimple(serialized []byte) (keychain.Scheme, []byte, []byte, error) {
+	scheme, err := keychain.SchemeFromFlag(serialized[0])
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("%w: %v", ErrUnsupportedScheme, err)
+	}
+	publicKeySize := 33
+	if scheme == keychain.SchemeEd25519 {
+		publicKeySize = 32
+	}
+	if len(serialized) != 1+signatureSize+publicKeySize {
+		return 0, nil, nil, fmt.Errorf("%w: %s signature must be %d bytes, got %d", ErrInvalidSignature, scheme.Label(), 1+signatureSize+publicKeySize, len(serialized))
+	}
+	return scheme, serialized[1+signatureSize:], serialized[1 : 1+signatureSize], nil
+}
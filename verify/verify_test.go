@@ -0,0 +1,193 @@
+package verify_test
+
+import (
+	"context"
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/0xdraco/sui-go-sdk/cryptography/intent"
+	"github.com/0xdraco/sui-go-sdk/keychain"
+	"github.com/0xdraco/sui-go-sdk/keypair"
+	"github.com/0xdraco/sui-go-sdk/multisig"
+	"github.com/0xdraco/sui-go-sdk/passkey"
+	"github.com/0xdraco/sui-go-sdk/verify"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+var (
+	testMessage = []byte("sign in to example.com")
+	testTxBytes = []byte{0x00, 0x00, 0x01, 0x02, 0x03}
+)
+
+func generate(t *testing.T, scheme keychain.Scheme) keypair.Keypair {
+	t.Helper()
+	kp, err := keypair.Generate(scheme)
+	if err != nil {
+		t.Fatalf("generate %s: %v", scheme.Label(), err)
+	}
+	return kp
+}
+
+func TestSimpleSchemes(t *testing.T) {
+	for _, scheme := range []keychain.Scheme{keychain.SchemeEd25519, keychain.SchemeSecp256k1, keychain.SchemeSecp256r1} {
+		t.Run(scheme.Label(), func(t *testing.T) {
+			kp := generate(t, scheme)
+			address, err := kp.SuiAddress()
+			if err != nil {
+				t.Fatalf("address: %v", err)
+			}
+
+			personal, err := kp.SignPersonalMessage(testMessage)
+			if err != nil {
+				t.Fatalf("sign personal message: %v", err)
+			}
+			if err := verify.PersonalMessage(testMessage, personal, address); err != nil {
+				t.Fatalf("verify personal message: %v", err)
+			}
+			if err := verify.PersonalMessage([]byte("other"), personal, address); err == nil {
+				t.Fatalf("signature verified over another message")
+			}
+			if err := verify.Transaction(testMessage, personal, address); err == nil {
+				t.Fatalf("personal message signature verified as transaction")
+			}
+
+			tx, err := kp.SignTransaction(testTxBytes)
+			if err != nil {
+				t.Fatalf("sign transaction: %v", err)
+			}
+			if err := verify.Transaction(testTxBytes, tx, strings.ToUpper(address[2:])); err != nil {
+				t.Fatalf("verify transaction: %v", err)
+			}
+			if err := verify.Verify(intent.IntentScopeTransactionData, testTxBytes, tx, ""); !errors.Is(err, verify.ErrMissingAddress) {
+				t.Fatalf("expected ErrMissingAddress for empty address, got %v", err)
+			}
+			if err := verify.VerifyWithoutAddressCheck(intent.IntentScopeTransactionData, testTxBytes, tx); err != nil {
+				t.Fatalf("verify without address check: %v", err)
+			}
+			if err := verify.VerifyWithoutAddressCheck(intent.IntentScopeTransactionData, []byte("other"), tx); err == nil {
+				t.Fatalf("signature verified over another message without address check")
+			}
+
+			signer, err := verify.SignerAddress(tx)
+			if err != nil {
+				t.Fatalf("signer address: %v", err)
+			}
+			if signer != address {
+				t.Fatalf("signer address = %s, want %s", signer, address)
+			}
+		})
+	}
+}
+
+func TestAddressMismatch(t *testing.T) {
+	kp := generate(t, keychain.SchemeEd25519)
+	other, _ := generate(t, keychain.SchemeEd25519).SuiAddress()
+
+	sig, err := kp.SignPersonalMessage(testMessage)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if err := verify.PersonalMessage(testMessage, sig, other); !errors.Is(err, verify.ErrAddressMismatch) {
+		t.Fatalf("expected address mismatch, got %v", err)
+	}
+}
+
+func TestMultisig(t *testing.T) {
+	a := generate(t, keychain.SchemeEd25519)
+	b := generate(t, keychain.SchemeSecp256k1)
+	committee, err := multisig.NewCommittee(2, multisig.NewMember(a, 1), multisig.NewMember(b, 1))
+	if err != nil {
+		t.Fatalf("committee: %v", err)
+	}
+	address, _ := committee.Address()
+
+	sigA, _ := a.SignTransaction(testTxBytes)
+	sigB, _ := b.SignTransaction(testTxBytes)
+	combined, err := committee.Combine(sigA, sigB)
+	if err != nil {
+		t.Fatalf("combine: %v", err)
+	}
+	serialized, _ := combined.Bytes()
+	if err := verify.Transaction(testTxBytes, serialized, address); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+
+	partial, _ := committee.Combine(sigA)
+	partialBytes, _ := partial.Bytes()
+	if err := verify.Transaction(testTxBytes, partialBytes, address); !errors.Is(err, multisig.ErrThresholdNotMet) {
+		t.Fatalf("expected threshold error, got %v", err)
+	}
+}
+
+func TestPasskey(t *testing.T) {
+	auth, err := passkey.NewSoftwareAuthenticator("example.com", "https://example.com")
+	if err != nil {
+		t.Fatalf("authenticator: %v", err)
+	}
+	signer, err := passkey.NewSigner(auth)
+	if err != nil {
+		t.Fatalf("signer: %v", err)
+	}
+	address, _ := signer.SuiAddress()
+
+	sig, err := signer.SignPersonalMessage(context.Background(), testMessage)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	if err := verify.PersonalMessage(testMessage, sig, address); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+}
+
+func TestMalformedSignatures(t *testing.T) {
+	kp := generate(t, keychain.SchemeEd25519)
+	address, _ := kp.SuiAddress()
+	sig, _ := kp.SignPersonalMessage(testMessage)
+
+	cases := map[string][]byte{
+		"empty":     nil,
+		"truncated": sig[:len(sig)-1],
+		"unknown":   append([]byte{0x09}, sig[1:]...),
+		"zklogin":   append([]byte{0x05}, sig[1:]...),
+	}
+	for name, input := range cases {
+		if err := verify.PersonalMessage(testMessage, input, address); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+	if err := verify.Verify(intent.IntentScopeCheckpointSummary, testMessage, sig, address); err == nil {
+		t.Fatalf("expected error for unsupported scope")
+	}
+}
+
+func TestRejectsHighS(t *testing.T) {
+	orders := map[keychain.Scheme]*big.Int{
+		keychain.SchemeSecp256k1: secp256k1.S256().N,
+		keychain.SchemeSecp256r1: elliptic.P256().Params().N,
+	}
+	for scheme, order := range orders {
+		t.Run(scheme.Label(), func(t *testing.T) {
+			kp := generate(t, scheme)
+			address, _ := kp.SuiAddress()
+			sig, err := kp.SignPersonalMessage(testMessage)
+			if err != nil {
+				t.Fatalf("sign: %v", err)
+			}
+			if err := verify.PersonalMessage(testMessage, sig, address); err != nil {
+				t.Fatalf("verify: %v", err)
+			}
+
+			// Replace S with N - S, the other valid ECDSA solution.
+			malleated := slices.Clone(sig)
+			s := new(big.Int).SetBytes(malleated[33:65])
+			new(big.Int).Sub(order, s).FillBytes(malleated[33:65])
+			if err := verify.PersonalMessage(testMessage, malleated, address); err == nil {
+				t.Fatalf("high-S signature verified")
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Package zklogin derives zkLogin addresses and nonces and assembles zkLogin
+// signatures from prover output and an ephemeral key signature.
+//
+// A zkLogin address is bound to an OpenID provider (iss) and an address seed,
+// itself a Poseidon hash of the user's key claim, the client ID (aud) and a
+// user salt. The ephemeral key that signs transactions is committed to in the
+// JWT nonce.
+//
+// Ref: https://docs.sui.io/concepts/cryptography/zklogin
+package zklogin
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// SignatureFlag prefixes zkLogin signatures and address preimages.
+	SignatureFlag byte = 0x05
+
+	maxKeyClaimNameLength  = 32
+	maxKeyClaimValueLength = 115
+	maxAudValueLength      = 145
+	addressSeedSize        = 32
+)
+
+// ErrInvalidAddressSeed indicates the address seed is negative or does not fit in 32 bytes.
+var ErrInvalidAddressSeed = errors.New("zklogin: invalid address seed")
+
+// AddressSeed computes the address seed for a key claim (usually "sub"), its
+// value, the client ID and the user salt:
+// Poseidon(H(name), H(value), H(aud), Poseidon(salt)).
+func AddressSeed(salt *big.Int, claimName, claimValue, aud string) (*big.Int, error) {
+	if salt == nil || salt.Sign() < 0 {
+		return nil, errors.New("zklogin: salt must be a non-negative integer")
+	}
+
+	name, err := hashASCIIStrToField(claimName, maxKeyClaimNameLength)
+	if err != nil {
+		return nil, err
+	}
+	value, err := hashASCIIStrToField(claimValue, maxKeyClaimValueLength)
+	if err != nil {
+		return nil, err
+	}
+	audience, err := hashASCIIStrToField(aud, maxAudValueLength)
+	if err != nil {
+		return nil, err
+	}
+	hashedSalt, err := poseidonHash([]*big.Int{salt})
+	if err != nil {
+		return nil, fmt.Errorf("zklogin: hash salt: %w", err)
+	}
+	return poseidonHash([]*big.Int{name, value, audience, hashedSalt})
+}
+
+// PublicIdentifier returns the zkLogin public key bytes:
+// len(iss) || iss || address seed as big-endian bytes without leading zeros.
+// This is the form wallets and the reference SDKs derive addresses from.
+func PublicIdentifier(iss string, addressSeed *big.Int) ([]byte, error) {
+	if err := validateAddressSeed(addressSeed); err != nil {
+		return nil, err
+	}
+	seed := addressSeed.Bytes()
+	if len(seed) == 0 {
+		seed = []byte{0}
+	}
+	return publicIdentifier(iss, seed)
+}
+
+// PaddedPublicIdentifier is like PublicIdentifier but pads the address seed
+// to 32 bytes. It differs only for seeds with a leading zero byte; Sui accepts
+// signatures from the addresses of both forms.
+func PaddedPublicIdentifier(iss string, addressSeed *big.Int) ([]byte, error) {
+	if err := validateAddressSeed(addressSeed); err != nil {
+		return nil, err
+	}
+	return publicIdentifier(iss, addressSeed.FillBytes(make([]byte, addressSeedSize)))
+}
+
+// Address derives the zkLogin Sui address for an issuer and address seed from
+// PublicIdentifier.
+func Address(iss string, addressSeed *big.Int) (string, error) {
+	identifier, err := PublicIdentifier(iss, addressSeed)
+	if err != nil {
+		return "", err
+	}
+	return addressFromIdentifier(identifier)
+}
+
+// PaddedAddress derives the zkLogin Sui address from PaddedPublicIdentifier.
+func PaddedAddress(iss string, addressSeed *big.Int) (string, error) {
+	identifier, err := PaddedPublicIdentifier(iss, addressSeed)
+	if err != nil {
+		return "", err
+	}
+	return addressFromIdentifier(identifier)
+}
+
+func publicIdentifier(iss string, seed []byte) ([]byte, error) {
+	iss = normalizeIssuer(iss)
+	if len(iss) > 0xff {
+		return nil, fmt.Errorf("zklogin: issuer %q is too long", iss)
+	}
+
+	out := make([]byte, 0, 1+len(iss)+len(seed))
+	out = append(out, byte(len(iss)))
+	out = append(out, iss...)
+	out = append(out, seed...)
+	return out, nil
+}
+
+func addressFromIdentifier(identifier []byte) (string, error) {
+	hasher, err := blake2b.New256(nil)
+	if err != nil {
+		return "", fmt.Errorf("zklogin: blake2b init: %w", err)
+	}
+	hasher.Write([]byte{SignatureFlag})
+	hasher.Write(identifier)
+	return fmt.Sprintf("0x%x", hasher.Sum(nil)), nil
+}
+
+func validateAddressSeed(addressSeed *big.Int) error {
+	if addressSeed == nil || addressSeed.Sign() < 0 || addressSeed.BitLen() > 8*addressSeedSize {
+		return ErrInvalidAddressSeed
+	}
+	return nil
+}
+
+// normalizeIssuer matches the form Google uses in some tokens to the one the
+// circuit commits to.
+func normalizeIssuer(iss string) string {
+	if iss == "accounts.google.com" {
+		return "https://accounts.google.com"
+	}
+	return iss
+}
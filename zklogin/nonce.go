@@ -0,0 +1,50 @@
+package zklogin
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+
+	"github.com/0xdraco/sui-go-sdk/keypair"
+)
+
+const (
+	randomnessSize = 16
+	nonceSize      = 20
+)
+
+// GenerateRandomness returns fresh 128-bit randomness for Nonce.
+func GenerateRandomness() (*big.Int, error) {
+	buf := make([]byte, randomnessSize)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("zklogin: randomness: %w", err)
+	}
+	return new(big.Int).SetBytes(buf), nil
+}
+
+// Nonce computes the OpenID nonce that commits to the ephemeral public key,
+// the last epoch in which it may sign and the randomness. The nonce must be
+// passed to the provider when requesting the JWT.
+func Nonce(ephemeral keypair.Keypair, maxEpoch uint64, randomness *big.Int) (string, error) {
+	if ephemeral == nil {
+		return "", fmt.Errorf("zklogin: nil ephemeral keypair")
+	}
+	if randomness == nil || randomness.Sign() < 0 {
+		return "", fmt.Errorf("zklogin: randomness must be a non-negative integer")
+	}
+
+	suiPublicKey := append([]byte{ephemeral.Scheme().AddressFlag()}, ephemeral.PublicKeyBytes()...)
+	value := new(big.Int).SetBytes(suiPublicKey)
+	high := new(big.Int).Rsh(value, 128)
+	low := new(big.Int).Sub(value, new(big.Int).Lsh(high, 128))
+
+	hash, err := poseidonHash([]*big.Int{high, low, new(big.Int).SetUint64(maxEpoch), randomness})
+	if err != nil {
+		return "", fmt.Errorf("zklogin: nonce: %w", err)
+	}
+
+	// Keep the low 20 bytes of the hash.
+	full := hash.FillBytes(make([]byte, 32))
+	return base64.RawURLEncoding.EncodeToString(full[len(full)-nonceSize:]), nil
+}
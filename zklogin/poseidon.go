@@ -0,0 +1,59 @@
+package zklogin
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/iden3/go-iden3-crypto/poseidon"
+)
+
+// packWidth is the number of bytes packed into a single BN254 field element.
+const packWidth = 31
+
+// poseidonHash hashes up to 32 field elements with the circomlib Poseidon
+// parameters. More than 16 inputs are hashed in two halves and combined, as
+// the zkLogin circuits do.
+func poseidonHash(inputs []*big.Int) (*big.Int, error) {
+	switch {
+	case len(inputs) == 0:
+		return nil, fmt.Errorf("zklogin: poseidon requires at least one input")
+	case len(inputs) <= 16:
+		return poseidon.Hash(inputs)
+	case len(inputs) <= 32:
+		left, err := poseidon.Hash(inputs[:16])
+		if err != nil {
+			return nil, err
+		}
+		right, err := poseidon.Hash(inputs[16:])
+		if err != nil {
+			return nil, err
+		}
+		return poseidon.Hash([]*big.Int{left, right})
+	default:
+		return nil, fmt.Errorf("zklogin: poseidon supports at most 32 inputs, got %d", len(inputs))
+	}
+}
+
+// hashASCIIStrToField zero-pads s to maxSize bytes, packs it into 31-byte
+// big-endian field elements (the first chunk being the short one) and hashes
+// them with Poseidon.
+func hashASCIIStrToField(s string, maxSize int) (*big.Int, error) {
+	if len(s) > maxSize {
+		return nil, fmt.Errorf("zklogin: %q exceeds %d bytes", s, maxSize)
+	}
+	padded := make([]byte, maxSize)
+	copy(padded, s)
+
+	chunks := (maxSize + packWidth - 1) / packWidth
+	packed := make([]*big.Int, chunks)
+	end := maxSize
+	for i := chunks - 1; i >= 0; i-- {
+		start := end - packWidth
+		if start < 0 {
+			start = 0
+		}
+		packed[i] = new(big.Int).SetBytes(padded[start:end])
+		end = start
+	}
+	return poseidonHash(packed)
+}
@@ -0,0 +1,81 @@
+package zklogin
+
+import (
+	"encoding/base64"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/iden3/go-iden3-crypto/poseidon"
+)
+
+// Vectors from circomlib's Poseidon tests.
+func TestPoseidonHashMatchesCircomlib(t *testing.T) {
+	tests := []struct {
+		inputs []int64
+		want   string
+	}{
+		{[]int64{1}, "18586133768512220936620570745912940619677854269274689475585506675881198879027"},
+		{[]int64{1, 2}, "7853200120776062878684798364095072458815029376092732009249414926327459813530"},
+		{[]int64{1, 2, 0, 0, 0}, "1018317224307729531995786483840663576608797660851238720571059489595066344487"},
+	}
+	for _, tt := range tests {
+		inputs := make([]*big.Int, len(tt.inputs))
+		for i, v := range tt.inputs {
+			inputs[i] = big.NewInt(v)
+		}
+		got, err := poseidonHash(inputs)
+		if err != nil {
+			t.Fatalf("poseidon(%v): %v", tt.inputs, err)
+		}
+		if got.String() != tt.want {
+			t.Fatalf("poseidon(%v) = %s, want %s", tt.inputs, got, tt.want)
+		}
+	}
+}
+
+// The circuit packs a claim from its end, so "sub" padded to 32 bytes becomes
+// the one-byte chunk "s" followed by the 31-byte chunk "ub\x00...".
+func TestHashASCIIStrToFieldPacksFromTheEnd(t *testing.T) {
+	got, err := hashASCIIStrToField("sub", maxKeyClaimNameLength)
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	tail := make([]byte, packWidth)
+	copy(tail, "ub")
+	want, err := poseidon.Hash([]*big.Int{big.NewInt('s'), new(big.Int).SetBytes(tail)})
+	if err != nil {
+		t.Fatalf("poseidon: %v", err)
+	}
+	if got.Cmp(want) != 0 {
+		t.Fatalf("hashASCIIStrToField(sub) = %s, want %s", got, want)
+	}
+}
+
+func TestHashASCIIStrToFieldRejectsLongInput(t *testing.T) {
+	if _, err := hashASCIIStrToField(strings.Repeat("a", 33), maxKeyClaimNameLength); err == nil {
+		t.Fatalf("expected error for oversized claim name")
+	}
+}
+
+func TestExtractClaimValueAtEveryOffset(t *testing.T) {
+	const claim = `"iss":"https://accounts.google.com",`
+	for prefix := 0; prefix < 3; prefix++ {
+		payload := `{"a":"` + strings.Repeat("x", prefix) + `",` + claim + `"sub":"1"}`
+		encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+		start := strings.Index(payload, claim)
+		end := start + len(claim)
+		charStart, charEnd := 8*start/6, (8*end+5)/6
+
+		got, err := extractClaimValue(Claim{
+			Value:     encoded[charStart:charEnd],
+			IndexMod4: uint8(charStart % 4),
+		}, "iss")
+		if err != nil {
+			t.Fatalf("prefix %d: %v", prefix, err)
+		}
+		if got != "https://accounts.google.com" {
+			t.Fatalf("prefix %d: iss = %q", prefix, got)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package zklogin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/0xdraco/sui-go-sdk/keypair"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/iotaledger/bcs-go"
+)
+
+// ErrInvalidSignature indicates a malformed zkLogin signature or prover output.
+var ErrInvalidSignature = errors.New("zklogin: invalid signature")
+
+// ProofPoints is the Groth16 proof returned by the zkLogin prover, as decimal strings.
+type ProofPoints struct {
+	A []string   `json:"a"`
+	B [][]string `json:"b"`
+	C []string   `json:"c"`
+}
+
+// Claim is a base64url slice of the JWT payload together with its offset modulo 4.
+type Claim struct {
+	Value     string `json:"value"`
+	IndexMod4 uint8  `json:"indexMod4"`
+}
+
+// Inputs are the public inputs of a zkLogin proof. The prover response can be
+// decoded directly into Inputs; AddressSeed is filled in by NewSignature.
+type Inputs struct {
+	ProofPoints      ProofPoints `json:"proofPoints"`
+	IssBase64Details Claim       `json:"issBase64Details"`
+	HeaderBase64     string      `json:"headerBase64"`
+	AddressSeed      string      `json:"addressSeed"`
+}
+
+// Signature is a zkLogin signature: the proof inputs, the last epoch in which
+// the ephemeral key is valid and the ephemeral key's serialized signature.
+type Signature struct {
+	Inputs             Inputs
+	MaxEpoch           uint64
+	EphemeralSignature []byte
+}
+
+// ParseProverResponse decodes the JSON returned by the zkLogin prover service.
+func ParseProverResponse(raw []byte) (Inputs, error) {
+	var inputs Inputs
+	if err := json.Unmarshal(raw, &inputs); err != nil {
+		return Inputs{}, fmt.Errorf("zklogin: decode prover response: %w", err)
+	}
+	return inputs, nil
+}
+
+// NewSignature assembles a zkLogin signature from prover output, the address
+// seed and the ephemeral key's serialized signature (`flag || sig || pubkey`,
+// as returned by SignTransaction). A nil addressSeed keeps inputs.AddressSeed.
+func NewSignature(inputs Inputs, addressSeed *big.Int, maxEpoch uint64, ephemeralSignature []byte) (*Signature, error) {
+	if addressSeed != nil {
+		if err := validateAddressSeed(addressSeed); err != nil {
+			return nil, err
+		}
+		inputs.AddressSeed = addressSeed.String()
+	}
+	sig := &Signature{
+		Inputs:             cloneInputs(inputs),
+		MaxEpoch:           maxEpoch,
+		EphemeralSignature: append([]byte(nil), ephemeralSignature...),
+	}
+	if err := sig.validate(); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// ParseSignature decodes a serialized zkLogin signature (`0x05 || BCS(ZkLoginAuthenticator)`).
+func ParseSignature(serialized []byte) (*Signature, error) {
+	if len(serialized) == 0 || serialized[0] != SignatureFlag {
+		return nil, fmt.Errorf("%w: missing zklogin flag", ErrInvalidSignature)
+	}
+	sig, err := bcs.Unmarshal[Signature](serialized[1:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	if err := sig.validate(); err != nil {
+		return nil, err
+	}
+	return &sig, nil
+}
+
+// Bytes returns the serialized signature accepted by Sui.
+func (s *Signature) Bytes() ([]byte, error) {
+	if err := s.validate(); err != nil {
+		return nil, err
+	}
+	encoded, err := bcs.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("zklogin: marshal: %w", err)
+	}
+	return append([]byte{SignatureFlag}, encoded...), nil
+}
+
+// AddressSeed returns the address seed carried in the proof inputs.
+func (s *Signature) AddressSeed() (*big.Int, error) {
+	seed, ok := new(big.Int).SetString(s.Inputs.AddressSeed, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAddressSeed, s.Inputs.AddressSeed)
+	}
+	if err := validateAddressSeed(seed); err != nil {
+		return nil, err
+	}
+	return seed, nil
+}
+
+// Issuer decodes the iss claim committed to by the proof.
+func (s *Signature) Issuer() (string, error) {
+	return extractClaimValue(s.Inputs.IssBase64Details, "iss")
+}
+
+// Address derives the zkLogin address the signature authorizes.
+func (s *Signature) Address() (string, error) {
+	iss, err := s.Issuer()
+	if err != nil {
+		return "", err
+	}
+	seed, err := s.AddressSeed()
+	if err != nil {
+		return "", err
+	}
+	return Address(iss, seed)
+}
+
+// UserSignature converts the signature into the form expected by
+// ExecuteTransactionRequest.Signatures.
+func (s *Signature) UserSignature() (*v2.UserSignature, error) {
+	serialized, err := s.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	ephemeral, err := keypair.UserSignature(s.EphemeralSignature)
+	if err != nil {
+		return nil, fmt.Errorf("zklogin: ephemeral signature: %w", err)
+	}
+	iss, err := s.Issuer()
+	if err != nil {
+		return nil, err
+	}
+
+	points := s.Inputs.ProofPoints
+	maxEpoch := s.MaxEpoch
+	indexMod4 := uint32(s.Inputs.IssBase64Details.IndexMod4)
+	name := keypair.UserSignatureBcsName
+	scheme := v2.SignatureScheme_ZKLOGIN
+	return &v2.UserSignature{
+		Bcs:    &v2.Bcs{Name: &name, Value: serialized},
+		Scheme: &scheme,
+		Signature: &v2.UserSignature_Zklogin{Zklogin: &v2.ZkLoginAuthenticator{
+			Inputs: &v2.ZkLoginInputs{
+				ProofPoints: &v2.ZkLoginProof{
+					A: &v2.CircomG1{E0: &points.A[0], E1: &points.A[1], E2: &points.A[2]},
+					B: &v2.CircomG2{
+						E00: &points.B[0][0], E01: &points.B[0][1],
+						E10: &points.B[1][0], E11: &points.B[1][1],
+						E20: &points.B[2][0], E21: &points.B[2][1],
+					},
+					C: &v2.CircomG1{E0: &points.C[0], E1: &points.C[1], E2: &points.C[2]},
+				},
+				IssBase64Details: &v2.ZkLoginClaim{Value: &s.Inputs.IssBase64Details.Value, IndexMod_4: &indexMod4},
+				HeaderBase64:     &s.Inputs.HeaderBase64,
+				AddressSeed:      &s.Inputs.AddressSeed,
+			},
+			MaxEpoch:  &maxEpoch,
+			Signature: ephemeral.GetSimple(),
+			PublicIdentifier: &v2.ZkLoginPublicIdentifier{
+				Iss:         &iss,
+				AddressSeed: &s.Inputs.AddressSeed,
+			},
+		}},
+	}, nil
+}
+
+func (s *Signature) validate() error {
+	points := s.Inputs.ProofPoints
+	if len(points.A) != 3 || len(points.C) != 3 || len(points.B) != 3 {
+		return fmt.Errorf("%w: proof points must be G1 (3), G2 (3x2), G1 (3)", ErrInvalidSignature)
+	}
+	for _, row := range points.B {
+		if len(row) != 2 {
+			return fmt.Errorf("%w: proof point b must be 3x2", ErrInvalidSignature)
+		}
+	}
+	if s.Inputs.IssBase64Details.IndexMod4 > 3 {
+		return fmt.Errorf("%w: indexMod4 %d out of range", ErrInvalidSignature, s.Inputs.IssBase64Details.IndexMod4)
+	}
+	if _, err := s.AddressSeed(); err != nil {
+		return err
+	}
+	if _, err := keypair.UserSignature(s.EphemeralSignature); err != nil {
+		return fmt.Errorf("%w: ephemeral signature: %v", ErrInvalidSignature, err)
+	}
+	return nil
+}
+
+func cloneInputs(in Inputs) Inputs {
+	out := in
+	out.ProofPoints.A = append([]string(nil), in.ProofPoints.A...)
+	out.ProofPoints.C = append([]string(nil), in.ProofPoints.C...)
+	out.ProofPoints.B = make([][]string, len(in.ProofPoints.B))
+	for i, row := range in.ProofPoints.B {
+		out.ProofPoints.B[i] = append([]string(nil), row...)
+	}
+	return out
+}
+
+// extractClaimValue decodes a `"name":value,` fragment of the JWT payload.
+// The fragment is base64url text cut out of the payload at an arbitrary
+// offset, so the partial leading and trailing characters are trimmed.
+func extractClaimValue(claim Claim, name string) (string, error) {
+	decoded, err := decodeBase64URLFragment(claim.Value, int(claim.IndexMod4))
+	if err != nil {
+		return "", err
+	}
+	decoded = strings.TrimRight(decoded, ",}")
+
+	var parsed map[string]string
+	if err := json.Unmarshal([]byte("{"+decoded+"}"), &parsed); err != nil {
+		return "", fmt.Errorf("%w: claim %q: %v", ErrInvalidSignature, decoded, err)
+	}
+	value, ok := parsed[name]
+	if !ok || len(parsed) != 1 {
+		return "", fmt.Errorf("%w: claim is not %q", ErrInvalidSignature, name)
+	}
+	return value, nil
+}
+
+func decodeBase64URLFragment(s string, index int) (string, error) {
+	if len(s) < 2 {
+		return "", fmt.Errorf("%w: claim too short", ErrInvalidSignature)
+	}
+
+	bits := make([]byte, 0, 6*len(s))
+	for i := 0; i < len(s); i++ {
+		v := strings.IndexByte(base64URLAlphabet, s[i])
+		if v < 0 {
+			return "", fmt.Errorf("%w: invalid base64url character %q", ErrInvalidSignature, s[i])
+		}
+		for b := 5; b >= 0; b-- {
+			bits = append(bits, byte(v>>b)&1)
+		}
+	}
+
+	switch index % 4 {
+	case 0:
+	case 1:
+		bits = bits[2:]
+	case 2:
+		bits = bits[4:]
+	default:
+		return "", fmt.Errorf("%w: invalid claim offset", ErrInvalidSignature)
+	}
+	switch (index + len(s) - 1) % 4 {
+	case 3:
+	case 2:
+		bits = bits[:len(bits)-2]
+	case 1:
+		bits = bits[:len(bits)-4]
+	default:
+		return "", fmt.Errorf("%w: invalid claim length", ErrInvalidSignature)
+	}
+	if len(bits)%8 != 0 {
+		return "", fmt.Errorf("%w: claim is not byte aligned", ErrInvalidSignature)
+	}
+
+	out := make([]byte, len(bits)/8)
+	for i := range out {
+		for _, bit := range bits[8*i : 8*i+8] {
+			out[i] = out[i]<<1 | bit
+		}
+	}
+	return string(out), nil
+}
+
+const base64URLAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
@@ -0,0 +1,308 @@
+package zklogin_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/0xdraco/sui-go-sdk/keychain"
+	"github.com/0xdraco/sui-go-sdk/keypair"
+	v2 "github.com/0xdraco/sui-go-sdk/proto/sui/rpc/v2"
+	"github.com/0xdraco/sui-go-sdk/zklogin"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	testIssuer = "https://accounts.google.com"
+	testSub    = "106294049240999307923"
+	testAud    = "25769832374-famecqrhe2gkebt5fvqms2263046lj96.apps.googleusercontent.com"
+)
+
+var testSalt, _ = new(big.Int).SetString("129390038577185583942388216820280642146", 10)
+
+func testAddressSeed(t *testing.T) *big.Int {
+	t.Helper()
+	seed, err := zklogin.AddressSeed(testSalt, "sub", testSub, testAud)
+	if err != nil {
+		t.Fatalf("address seed: %v", err)
+	}
+	return seed
+}
+
+// issClaim cuts the iss claim out of a base64url JWT payload the way the prover does.
+func issClaim(iss string) zklogin.Claim {
+	claim := fmt.Sprintf(`"iss":%q,`, iss)
+	payload := `{` + claim + `"sub":"` + testSub + `"}`
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	start := strings.Index(payload, claim)
+	charStart, charEnd := 8*start/6, (8*(start+len(claim))+5)/6
+	return zklogin.Claim{Value: encoded[charStart:charEnd], IndexMod4: uint8(charStart % 4)}
+}
+
+func testInputs() zklogin.Inputs {
+	return zklogin.Inputs{
+		ProofPoints: zklogin.ProofPoints{
+			A: []string{"1", "2", "1"},
+			B: [][]string{{"3", "4"}, {"5", "6"}, {"1", "0"}},
+			C: []string{"7", "8", "1"},
+		},
+		IssBase64Details: issClaim(testIssuer),
+		HeaderBase64:     "eyJhbGciOiJSUzI1NiJ9",
+	}
+}
+
+func testSignature(t *testing.T) (*zklogin.Signature, []byte) {
+	t.Helper()
+	ephemeral, err := keypair.Generate(keychain.SchemeEd25519)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	userSig, err := ephemeral.SignTransaction([]byte{0, 1, 2, 3})
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	sig, err := zklogin.NewSignature(testInputs(), testAddressSeed(t), 42, userSig)
+	if err != nil {
+		t.Fatalf("new signature: %v", err)
+	}
+	return sig, userSig
+}
+
+func TestAddressSeedDependsOnEveryInput(t *testing.T) {
+	seed := testAddressSeed(t)
+	again := testAddressSeed(t)
+	if seed.Cmp(again) != 0 {
+		t.Fatalf("address seed is not deterministic")
+	}
+
+	variants := []struct {
+		name                  string
+		salt                  *big.Int
+		claimName, claimValue string
+		aud                   string
+	}{
+		{"salt", new(big.Int).Add(testSalt, big.NewInt(1)), "sub", testSub, testAud},
+		{"claim name", testSalt, "email", testSub, testAud},
+		{"claim value", testSalt, "sub", testSub + "0", testAud},
+		{"aud", testSalt, "sub", testSub, testAud + "x"},
+	}
+	for _, v := range variants {
+		other, err := zklogin.AddressSeed(v.salt, v.claimName, v.claimValue, v.aud)
+		if err != nil {
+			t.Fatalf("%s: %v", v.name, err)
+		}
+		if other.Cmp(seed) == 0 {
+			t.Fatalf("%s does not affect the address seed", v.name)
+		}
+	}
+}
+
+func TestAddressSeedRejectsOversizedClaims(t *testing.T) {
+	if _, err := zklogin.AddressSeed(testSalt, "sub", strings.Repeat("1", 116), testAud); err == nil {
+		t.Fatalf("expected error for oversized claim value")
+	}
+	if _, err := zklogin.AddressSeed(testSalt, "sub", testSub, strings.Repeat("a", 146)); err == nil {
+		t.Fatalf("expected error for oversized aud")
+	}
+}
+
+func TestAddressHashesPublicIdentifier(t *testing.T) {
+	seed := testAddressSeed(t)
+	identifier, err := zklogin.PublicIdentifier(testIssuer, seed)
+	if err != nil {
+		t.Fatalf("public identifier: %v", err)
+	}
+
+	want := append([]byte{byte(len(testIssuer))}, testIssuer...)
+	want = append(want, seed.Bytes()...)
+	if !bytes.Equal(identifier, want) {
+		t.Fatalf("public identifier = %x, want %x", identifier, want)
+	}
+
+	address, err := zklogin.Address(testIssuer, seed)
+	if err != nil {
+		t.Fatalf("address: %v", err)
+	}
+	sum := blake2b.Sum256(append([]byte{zklogin.SignatureFlag}, identifier...))
+	if address != fmt.Sprintf("0x%x", sum) {
+		t.Fatalf("address = %s, want 0x%x", address, sum)
+	}
+
+	short, err := zklogin.Address("accounts.google.com", seed)
+	if err != nil {
+		t.Fatalf("address: %v", err)
+	}
+	if short != address {
+		t.Fatalf("google issuer was not normalized: %s != %s", short, address)
+	}
+}
+
+// The expected addresses were computed independently as
+// blake2b-256(0x05 || len(iss) || iss || seed) over the 31-byte and the
+// 32-byte big-endian seed.
+func TestAddressStripsLeadingZeroSeedBytes(t *testing.T) {
+	seed, _ := new(big.Int).SetString("226156424291633194186662080095093570025917938800079226639565593765455343673", 10)
+
+	address, err := zklogin.Address(testIssuer, seed)
+	if err != nil {
+		t.Fatalf("address: %v", err)
+	}
+	if want := "0x9c0aea6c6742fe1f8cca07c17f46f1deb5813481cd2502d22abcacb38212e7c8"; address != want {
+		t.Fatalf("address = %s, want %s", address, want)
+	}
+	padded, err := zklogin.PaddedAddress(testIssuer, seed)
+	if err != nil {
+		t.Fatalf("padded address: %v", err)
+	}
+	if want := "0xbfdbd382b78f9767e8de0ad7eff6b21d7931be7f6f11f3f6d733aedf3222bfdc"; padded != want {
+		t.Fatalf("padded address = %s, want %s", padded, want)
+	}
+
+	identifier, err := zklogin.PaddedPublicIdentifier(testIssuer, seed)
+	if err != nil {
+		t.Fatalf("padded public identifier: %v", err)
+	}
+	if len(identifier) != 1+len(testIssuer)+32 || identifier[1+len(testIssuer)] != 0 {
+		t.Fatalf("padded public identifier = %x", identifier)
+	}
+}
+
+func TestNonce(t *testing.T) {
+	ephemeral, err := keypair.Generate(keychain.SchemeEd25519)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	randomness, err := zklogin.GenerateRandomness()
+	if err != nil {
+		t.Fatalf("randomness: %v", err)
+	}
+
+	nonce, err := zklogin.Nonce(ephemeral, 10, randomness)
+	if err != nil {
+		t.Fatalf("nonce: %v", err)
+	}
+	if len(nonce) != 27 {
+		t.Fatalf("nonce %q has length %d, want 27", nonce, len(nonce))
+	}
+	again, err := zklogin.Nonce(ephemeral, 10, randomness)
+	if err != nil {
+		t.Fatalf("nonce: %v", err)
+	}
+	if again != nonce {
+		t.Fatalf("nonce is not deterministic")
+	}
+	later, err := zklogin.Nonce(ephemeral, 11, randomness)
+	if err != nil {
+		t.Fatalf("nonce: %v", err)
+	}
+	if later == nonce {
+		t.Fatalf("max epoch does not affect the nonce")
+	}
+}
+
+func TestSignatureRoundTrip(t *testing.T) {
+	sig, userSig := testSignature(t)
+
+	serialized, err := sig.Bytes()
+	if err != nil {
+		t.Fatalf("bytes: %v", err)
+	}
+	if serialized[0] != zklogin.SignatureFlag {
+		t.Fatalf("flag = %#x", serialized[0])
+	}
+	parsed, err := zklogin.ParseSignature(serialized)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if parsed.MaxEpoch != 42 || !bytes.Equal(parsed.EphemeralSignature, userSig) {
+		t.Fatalf("parsed signature differs: %+v", parsed)
+	}
+	if parsed.Inputs.AddressSeed != testAddressSeed(t).String() {
+		t.Fatalf("address seed = %s", parsed.Inputs.AddressSeed)
+	}
+
+	iss, err := parsed.Issuer()
+	if err != nil {
+		t.Fatalf("issuer: %v", err)
+	}
+	if iss != testIssuer {
+		t.Fatalf("issuer = %q", iss)
+	}
+	address, err := parsed.Address()
+	if err != nil {
+		t.Fatalf("address: %v", err)
+	}
+	want, _ := zklogin.Address(testIssuer, testAddressSeed(t))
+	if address != want {
+		t.Fatalf("address = %s, want %s", address, want)
+	}
+}
+
+func TestSignatureUserSignature(t *testing.T) {
+	sig, userSig := testSignature(t)
+
+	out, err := sig.UserSignature()
+	if err != nil {
+		t.Fatalf("user signature: %v", err)
+	}
+	if out.GetScheme() != v2.SignatureScheme_ZKLOGIN {
+		t.Fatalf("scheme = %s", out.GetScheme())
+	}
+	serialized, _ := sig.Bytes()
+	if !bytes.Equal(out.GetBcs().GetValue(), serialized) {
+		t.Fatalf("bcs value does not match serialized signature")
+	}
+
+	auth := out.GetZklogin()
+	if auth.GetMaxEpoch() != 42 {
+		t.Fatalf("max epoch = %d", auth.GetMaxEpoch())
+	}
+	if auth.GetInputs().GetProofPoints().GetB().GetE21() != "0" {
+		t.Fatalf("proof point b not copied: %v", auth.GetInputs().GetProofPoints().GetB())
+	}
+	if auth.GetPublicIdentifier().GetIss() != testIssuer {
+		t.Fatalf("iss = %q", auth.GetPublicIdentifier().GetIss())
+	}
+	if !bytes.Equal(auth.GetSignature().GetSignature(), userSig[1:65]) {
+		t.Fatalf("ephemeral signature not copied")
+	}
+	if auth.GetSignature().GetScheme() != v2.SignatureScheme_ED25519 {
+		t.Fatalf("ephemeral scheme = %s", auth.GetSignature().GetScheme())
+	}
+}
+
+func TestNewSignatureRejectsMalformedInputs(t *testing.T) {
+	_, userSig := testSignature(t)
+	seed := testAddressSeed(t)
+
+	badPoints := testInputs()
+	badPoints.ProofPoints.B = badPoints.ProofPoints.B[:2]
+	if _, err := zklogin.NewSignature(badPoints, seed, 1, userSig); !errors.Is(err, zklogin.ErrInvalidSignature) {
+		t.Fatalf("short proof point b: %v", err)
+	}
+	if _, err := zklogin.NewSignature(testInputs(), seed, 1, userSig[:10]); !errors.Is(err, zklogin.ErrInvalidSignature) {
+		t.Fatalf("truncated ephemeral signature: %v", err)
+	}
+	if _, err := zklogin.NewSignature(testInputs(), nil, 1, userSig); !errors.Is(err, zklogin.ErrInvalidAddressSeed) {
+		t.Fatalf("missing address seed: %v", err)
+	}
+	if _, err := zklogin.ParseSignature([]byte{0x00, 0x01}); !errors.Is(err, zklogin.ErrInvalidSignature) {
+		t.Fatalf("wrong flag: %v", err)
+	}
+}
+
+func TestParseProverResponse(t *testing.T) {
+	raw := []byte(`{"proofPoints":{"a":["1","2","1"],"b":[["3","4"],["5","6"],["1","0"]],"c":["7","8","1"]},` +
+		`"issBase64Details":{"value":"abc","indexMod4":1},"headerBase64":"eyJ9"}`)
+	inputs, err := zklogin.ParseProverResponse(raw)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if inputs.IssBase64Details.IndexMod4 != 1 || inputs.ProofPoints.B[1][0] != "5" || inputs.HeaderBase64 != "eyJ9" {
+		t.Fatalf("unexpected inputs: %+v", inputs)
+	}
+}